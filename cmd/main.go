@@ -4,11 +4,20 @@ import (
 	"context"
 	"time"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/config"
 	"github.com/Meystergod/online-store/internal/controller"
 	"github.com/Meystergod/online-store/internal/delivery/http/httpecho"
+	"github.com/Meystergod/online-store/internal/events"
+	"github.com/Meystergod/online-store/internal/i18n"
+	"github.com/Meystergod/online-store/internal/inventory"
+	"github.com/Meystergod/online-store/internal/media"
+	"github.com/Meystergod/online-store/internal/pricing"
+	"github.com/Meystergod/online-store/internal/recommendation"
 	"github.com/Meystergod/online-store/internal/repository/mongo"
 	"github.com/Meystergod/online-store/internal/utils"
+	"github.com/Meystergod/online-store/internal/webhook"
+	"github.com/Meystergod/online-store/internal/worker"
 	"github.com/Meystergod/online-store/pkg/client"
 	"github.com/Meystergod/online-store/pkg/httpserver"
 	"github.com/Meystergod/online-store/pkg/logging"
@@ -33,6 +42,10 @@ func Run() error {
 		return errors.Wrap(err, "reading config")
 	}
 
+	if err := cfg.Validate(); err != nil {
+		return errors.Wrap(err, "validating config")
+	}
+
 	ctx := context.Background()
 	runner, ctx := errgroup.WithContext(ctx)
 
@@ -58,6 +71,12 @@ func Run() error {
 
 	httpServer.Server().Validator = utils.NewValidator()
 
+	var debugServer *httpserver.Server
+	if cfg.Debug.Address != "" {
+		debugServer = httpserver.NewServer(&httpserver.ServerDeps{Address: cfg.Debug.Address})
+		httpecho.SetDebugRoutes(debugServer.Server())
+	}
+
 	dbConfig := client.NewMongoConfig(
 		cfg.Database.Auth,
 		cfg.Database.Username,
@@ -65,6 +84,7 @@ func Run() error {
 		cfg.Database.Host,
 		cfg.Database.Port,
 		cfg.Database.Name,
+		cfg.Database.ReplicaSet,
 	)
 
 	db, err := client.NewMongoClient(ctx, dbConfig)
@@ -72,26 +92,333 @@ func Run() error {
 		return errors.Wrap(err, "connecting database")
 	}
 
+	if err = mongo.EnsureTransactions(ctx, db); err != nil {
+		return errors.Wrap(err, "checking database topology")
+	}
+
+	if err = mongo.EnsureIndexes(ctx, db); err != nil {
+		return errors.Wrap(err, "creating database indexes")
+	}
+
+	if err = mongo.BackfillSlugs(ctx, db); err != nil {
+		return errors.Wrap(err, "backfilling slugs")
+	}
+
+	if err = mongo.MigrateCouponUsages(ctx, db); err != nil {
+		return errors.Wrap(err, "migrating coupon usages")
+	}
+
+	if err = mongo.BackfillTagUsage(ctx, db); err != nil {
+		return errors.Wrap(err, "backfilling tag usage")
+	}
+
+	if err = mongo.MigrateOpeningStock(ctx, db, cfg.Inventory.DefaultWarehouse); err != nil {
+		return errors.Wrap(err, "migrating opening stock")
+	}
+
+	unlinked, err := mongo.LinkLegacySubcategories(ctx, db)
+	if err != nil {
+		return errors.Wrap(err, "linking legacy subcategories")
+	}
+
+	if len(unlinked) > 0 {
+		logger.Warn().Strs("subcategory_ids", unlinked).Msg("subcategories without a category must be relinked with PUT /api/v1/category/:id/subcategories/:subcategory_id")
+	}
+
+	httpecho.SetAuthMiddleware(httpServer.Server(), cfg.Auth.Tokens, cfg.HTTPServer.TrustProxy)
+
+	auditRepository := mongo.NewAuditRepository(db, utils.CollNameAudit)
+	auditRecorder := audit.NewRecorder(auditRepository)
+	auditController := controller.NewAuditController(auditRepository)
+	httpecho.SetAuditApiRoutes(httpServer.Server(), auditController)
+
+	localizer := i18n.NewLocalizer(cfg.Locale.Default, cfg.Locale.Fallbacks)
+
 	categoryRepository := mongo.NewCategoryRepository(db, utils.CollNameCategory)
-	categoryController := controller.NewCategoryController(categoryRepository)
+	productRepository := mongo.NewProductRepository(db, utils.CollNameProduct)
+	tagRepository := mongo.NewTagRepository(db, utils.CollNameTag)
+
+	categoryController := controller.NewCategoryController(categoryRepository, productRepository, localizer, auditRecorder)
 	httpecho.SetCategoryApiRoutes(httpServer.Server(), categoryController)
 
 	subcategoryRepository := mongo.NewSubcategoryRepository(db, utils.CollNameSubcategory)
-	subcategoryController := controller.NewSubcategoryController(subcategoryRepository)
+	subcategoryController := controller.NewSubcategoryController(subcategoryRepository, categoryRepository, localizer, auditRecorder)
 	httpecho.SetSubcategoryApiRoutes(httpServer.Server(), subcategoryController)
 
 	discountRepository := mongo.NewDiscountRepository(db, utils.CollNameDiscount)
-	discountController := controller.NewDiscountController(discountRepository)
+	discountController := controller.NewDiscountController(discountRepository, auditRecorder)
 	httpecho.SetDiscountApiRoutes(httpServer.Server(), discountController)
 
-	productRepository := mongo.NewProductRepository(db, utils.CollNameProduct)
-	productController := controller.NewProductController(productRepository)
+	couponRepository := mongo.NewCouponRepository(db, utils.CollNameCoupon)
+	couponController := controller.NewCouponController(couponRepository, discountRepository, auditRecorder)
+	httpecho.SetCouponApiRoutes(httpServer.Server(), couponController)
+
+	pricingRuleRepository := mongo.NewPricingRuleRepository(db, utils.CollNamePricingRule)
+	exchangeRateRepository := mongo.NewExchangeRateRepository(db, utils.CollNameExchangeRate)
+
+	pricingEngineDeps := &pricing.EngineDeps{
+		PricingRuleRepository:  pricingRuleRepository,
+		ExchangeRateRepository: exchangeRateRepository,
+		BaseCurrency:           cfg.Currency.Base,
+		SupportedCurrencies:    cfg.Currency.Supported,
+	}
+
+	pricingEngine := pricing.NewEngine(pricingEngineDeps)
+
+	if cfg.Currency.RatesFile != "" {
+		rates, err := pricing.LoadExchangeRates(cfg.Currency.RatesFile)
+		if err != nil {
+			return errors.Wrap(err, "loading exchange rates")
+		}
+
+		if err = pricingEngine.ValidateRates(rates); err != nil {
+			return errors.Wrap(err, "validating exchange rates")
+		}
+
+		if err = exchangeRateRepository.SaveExchangeRates(ctx, rates); err != nil {
+			return errors.Wrap(err, "saving exchange rates")
+		}
+	}
+
+	currencyController := controller.NewCurrencyController(exchangeRateRepository, pricingEngine, auditRecorder)
+	httpecho.SetCurrencyApiRoutes(httpServer.Server(), currencyController)
+
+	var blobStore media.BlobStore = media.NewLocalStore(cfg.Media.Dir, cfg.Media.BaseURL)
+	if cfg.Media.Storage == "s3" {
+		s3StoreDeps := &media.S3StoreDeps{
+			Endpoint:  cfg.Media.S3Endpoint,
+			Bucket:    cfg.Media.S3Bucket,
+			Region:    cfg.Media.S3Region,
+			AccessKey: cfg.Media.S3AccessKey,
+			SecretKey: cfg.Media.S3SecretKey,
+			PublicURL: cfg.Media.S3PublicURL,
+			Timeout:   cfg.Media.S3Timeout,
+		}
+
+		blobStore = media.NewS3Store(s3StoreDeps)
+	} else {
+		httpecho.SetMediaRoutes(httpServer.Server(), cfg.Media.BaseURL, cfg.Media.Dir)
+	}
+
+	mediaServiceDeps := &media.ServiceDeps{
+		Store:          blobStore,
+		MaxSize:        cfg.Media.MaxSize,
+		ThumbnailSizes: cfg.Media.ThumbnailSizes,
+	}
+
+	mediaService := media.NewService(mediaServiceDeps)
+
+	inventoryRepository := mongo.NewInventoryRepository(db, utils.CollNameStockMovement)
+	warehouseRepository := mongo.NewWarehouseRepository(db, utils.CollNameWarehouse)
+
+	recommendationRepository := mongo.NewRecommendationRepository(db, utils.CollNameCoPurchase)
+
+	recommendationServiceDeps := &recommendation.ServiceDeps{
+		RecommendationRepository: recommendationRepository,
+		ProductRepository:        productRepository,
+		Limit:                    cfg.Recommendation.Limit,
+		CandidateLimit:           cfg.Recommendation.CandidateLimit,
+	}
+
+	recommendationService := recommendation.NewService(recommendationServiceDeps)
+
+	productControllerDeps := &controller.ProductControllerDeps{
+		ProductRepository:        productRepository,
+		CategoryRepository:       categoryRepository,
+		SubcategoryRepository:    subcategoryRepository,
+		TagRepository:            tagRepository,
+		PricingEngine:            pricingEngine,
+		MediaService:             mediaService,
+		InventoryRepository:      inventoryRepository,
+		RecommendationRepository: recommendationRepository,
+		RecommendationService:    recommendationService,
+		Localizer:                localizer,
+		AuditRecorder:            auditRecorder,
+	}
+
+	productController := controller.NewProductController(productControllerDeps)
 	httpecho.SetProductApiRoutes(httpServer.Server(), productController)
 
-	tagRepository := mongo.NewTagRepository(db, utils.CollNameTag)
-	tagController := controller.NewTagController(tagRepository)
+	stockAlertRepository := mongo.NewStockAlertRepository(db, utils.CollNameStockAlert)
+
+	inventoryControllerDeps := &controller.InventoryControllerDeps{
+		InventoryRepository:  inventoryRepository,
+		ProductRepository:    productRepository,
+		WarehouseRepository:  warehouseRepository,
+		StockAlertRepository: stockAlertRepository,
+		AllocationStrategy:   cfg.Inventory.AllocationStrategy,
+		AuditRecorder:        auditRecorder,
+	}
+
+	inventoryController := controller.NewInventoryController(inventoryControllerDeps)
+	httpecho.SetInventoryApiRoutes(httpServer.Server(), inventoryController)
+
+	warehouseController := controller.NewWarehouseController(warehouseRepository, inventoryRepository, auditRecorder)
+	httpecho.SetWarehouseApiRoutes(httpServer.Server(), warehouseController)
+
+	reviewRepository := mongo.NewReviewRepository(db, utils.CollNameReview)
+
+	reviewControllerDeps := &controller.ReviewControllerDeps{
+		ReviewRepository:    reviewRepository,
+		ProductRepository:   productRepository,
+		InventoryRepository: inventoryRepository,
+		AuditRecorder:       auditRecorder,
+	}
+
+	reviewController := controller.NewReviewController(reviewControllerDeps)
+	httpecho.SetReviewApiRoutes(httpServer.Server(), reviewController)
+
+	orderRepository := mongo.NewOrderRepository(db, utils.CollNameOrder)
+	orderController := controller.NewOrderController(orderRepository)
+	httpecho.SetOrderApiRoutes(httpServer.Server(), orderController)
+
+	cartRepository := mongo.NewCartRepository(db, utils.CollNameCart)
+	cartControllerDeps := &controller.CartControllerDeps{
+		CartRepository:      cartRepository,
+		ProductRepository:   productRepository,
+		OrderRepository:     orderRepository,
+		InventoryRepository: inventoryRepository,
+		WarehouseRepository: warehouseRepository,
+		PricingEngine:       pricingEngine,
+		AllocationStrategy:  cfg.Inventory.AllocationStrategy,
+	}
+
+	cartController := controller.NewCartController(cartControllerDeps)
+	httpecho.SetCartApiRoutes(httpServer.Server(), cartController)
+
+	wishlistRepository := mongo.NewWishlistRepository(db, utils.CollNameWishlist)
+	wishlistController := controller.NewWishlistController(wishlistRepository, productRepository, cartRepository, auditRecorder)
+	httpecho.SetWishlistApiRoutes(httpServer.Server(), wishlistController)
+
+	pricingController := controller.NewPricingController(pricingRuleRepository, productRepository, pricingEngine, auditRecorder)
+	httpecho.SetPricingApiRoutes(httpServer.Server(), pricingController)
+
+	tagController := controller.NewTagController(tagRepository, localizer, auditRecorder)
 	httpecho.SetTagApiRoutes(httpServer.Server(), tagController)
 
+	webhookRepository := mongo.NewWebhookRepository(db, utils.CollNameWebhook)
+	webhookDeliveryRepository := mongo.NewWebhookDeliveryRepository(db, utils.CollNameWebhookDelivery)
+	webhookController := controller.NewWebhookController(webhookRepository, webhookDeliveryRepository, cfg.Webhook.AllowPrivateTargets)
+	httpecho.SetWebhookApiRoutes(httpServer.Server(), webhookController)
+
+	webhookDispatcher := webhook.NewDispatcher(webhookRepository, webhookDeliveryRepository)
+
+	notifiers := inventory.MultiNotifier{}
+	for _, notifier := range cfg.Alert.Notifiers {
+		switch notifier {
+		case "log":
+			notifiers = append(notifiers, inventory.LogNotifier{})
+		case "webhook":
+			notifiers = append(notifiers, inventory.NewWebhookNotifier(cfg.Alert.WebhookURL, cfg.Alert.WebhookTimeout))
+		case "email":
+			emailNotifierDeps := &inventory.EmailNotifierDeps{
+				Address:  cfg.Alert.SMTPAddress,
+				From:     cfg.Alert.SMTPFrom,
+				To:       cfg.Alert.SMTPTo,
+				Username: cfg.Alert.SMTPUsername,
+				Password: cfg.Alert.SMTPPassword,
+			}
+
+			notifiers = append(notifiers, inventory.NewEmailNotifier(emailNotifierDeps))
+		default:
+			return errors.Errorf("unknown alert notifier %q", notifier)
+		}
+	}
+
+	stockCheckerDeps := &inventory.CheckerDeps{
+		ProductRepository:    productRepository,
+		CategoryRepository:   categoryRepository,
+		StockAlertRepository: stockAlertRepository,
+		Notifier:             notifiers,
+	}
+
+	stockChecker := inventory.NewChecker(stockCheckerDeps)
+
+	publishers := events.MultiPublisher{webhookDispatcher, stockChecker}
+	if cfg.Outbox.LogEvents {
+		publishers = append(publishers, events.LogPublisher{})
+	}
+
+	outboxRepository := mongo.NewOutboxRepository(db, utils.CollNameOutbox)
+
+	outboxRelayDeps := &worker.OutboxRelayDeps{
+		OutboxRepository: outboxRepository,
+		Publisher:        publishers,
+		PollInterval:     cfg.Outbox.PollInterval,
+		Lease:            cfg.Outbox.Lease,
+		BackoffBase:      cfg.Outbox.BackoffBase,
+		BackoffMax:       cfg.Outbox.BackoffMax,
+	}
+
+	outboxRelay := worker.NewOutboxRelay(outboxRelayDeps)
+
+	webhookWorkerDeps := &worker.WebhookWorkerDeps{
+		WebhookRepository:         webhookRepository,
+		WebhookDeliveryRepository: webhookDeliveryRepository,
+		Sender:                    webhook.NewSender(cfg.Webhook.Timeout, cfg.Webhook.AllowPrivateTargets),
+		PollInterval:              cfg.Webhook.PollInterval,
+		Lease:                     cfg.Webhook.Lease,
+		MaxAttempts:               cfg.Webhook.MaxAttempts,
+		BackoffBase:               cfg.Webhook.BackoffBase,
+		BackoffMax:                cfg.Webhook.BackoffMax,
+	}
+
+	webhookWorker := worker.NewWebhookWorker(webhookWorkerDeps)
+
+	purgeWorkerDeps := &worker.PurgeWorkerDeps{
+		Retention: cfg.Trash.Retention,
+		Interval:  cfg.Trash.PurgeInterval,
+		Targets: map[string]worker.PurgeFunc{
+			utils.CollNameCategory:    categoryRepository.PurgeCategories,
+			utils.CollNameSubcategory: subcategoryRepository.PurgeSubcategories,
+			utils.CollNameDiscount:    discountRepository.PurgeDiscounts,
+			utils.CollNameProduct:     worker.PurgeProducts(productRepository, mediaService),
+			utils.CollNameTag:         tagRepository.PurgeTags,
+		},
+	}
+
+	purgeWorker := worker.NewPurgeWorker(purgeWorkerDeps)
+
+	outboxPurgeWorkerDeps := &worker.PurgeWorkerDeps{
+		Retention: cfg.Outbox.Retention,
+		Interval:  cfg.Trash.PurgeInterval,
+		Targets: map[string]worker.PurgeFunc{
+			utils.CollNameOutbox: outboxRepository.PurgePublishedOutboxMessages,
+		},
+	}
+
+	outboxPurgeWorker := worker.NewPurgeWorker(outboxPurgeWorkerDeps)
+
+	discountSchedulerDeps := &worker.DiscountSchedulerDeps{
+		DiscountRepository: discountRepository,
+		Interval:           cfg.Discount.ScheduleInterval,
+	}
+
+	discountScheduler := worker.NewDiscountScheduler(discountSchedulerDeps)
+
+	publicationSchedulerDeps := &worker.PublicationSchedulerDeps{
+		ProductRepository: productRepository,
+		Interval:          cfg.Publication.ScheduleInterval,
+	}
+
+	publicationScheduler := worker.NewPublicationScheduler(publicationSchedulerDeps)
+
+	coPurchaseWorkerDeps := &worker.CoPurchaseWorkerDeps{
+		RecommendationRepository: recommendationRepository,
+		Interval:                 cfg.Recommendation.RefreshInterval,
+		Window:                   cfg.Recommendation.Window,
+		Depth:                    cfg.Recommendation.Depth,
+	}
+
+	coPurchaseWorker := worker.NewCoPurchaseWorker(coPurchaseWorkerDeps)
+
+	alertWorkerDeps := &worker.AlertWorkerDeps{
+		Renotifier: stockChecker,
+		Interval:   cfg.Alert.RetryInterval,
+	}
+
+	alertWorker := worker.NewAlertWorker(alertWorkerDeps)
+
 	logger.Info().Msgf("start %s %s on %s", cfg.Application.Name, cfg.Application.Version, cfg.HTTPServer.Address)
 
 	defer logger.Info().Msg("service done")
@@ -104,6 +431,80 @@ func Run() error {
 		return nil
 	})
 
+	if debugServer != nil {
+		runner.Go(func() error {
+			if err := debugServer.Start(ctx); err != nil {
+				return errors.Wrap(err, "listening and starting debug http")
+			}
+
+			return nil
+		})
+	}
+
+	runner.Go(func() error {
+		if err := purgeWorker.Run(ctx); err != nil {
+			return errors.Wrap(err, "running trash purge worker")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := outboxPurgeWorker.Run(ctx); err != nil {
+			return errors.Wrap(err, "running outbox purge worker")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := discountScheduler.Run(ctx); err != nil {
+			return errors.Wrap(err, "running discount scheduler")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := publicationScheduler.Run(ctx); err != nil {
+			return errors.Wrap(err, "running publication scheduler")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := coPurchaseWorker.Run(ctx); err != nil {
+			return errors.Wrap(err, "running co-purchase worker")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := alertWorker.Run(ctx); err != nil {
+			return errors.Wrap(err, "running stock alert worker")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := outboxRelay.Run(ctx); err != nil {
+			return errors.Wrap(err, "running outbox relay")
+		}
+
+		return nil
+	})
+
+	runner.Go(func() error {
+		if err := webhookWorker.Run(ctx); err != nil {
+			return errors.Wrap(err, "running webhook worker")
+		}
+
+		return nil
+	})
+
 	runner.Go(func() error {
 		if err := ossignal.DefaultSignalWaiter(ctx); err != nil {
 			return errors.Wrap(err, "os signal waiter")
@@ -124,6 +525,12 @@ func Run() error {
 			logger.Error().Err(err).Msg("shutdown http server")
 		}
 
+		if debugServer != nil {
+			if err := debugServer.Shutdown(ctxSignal); err != nil {
+				logger.Error().Err(err).Msg("shutdown debug http server")
+			}
+		}
+
 		return nil
 	})
 
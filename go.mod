@@ -3,7 +3,6 @@ module github.com/Meystergod/online-store
 go 1.20
 
 require (
-	github.com/Meystergod/online-store v0.0.0-20230704132313-bd03395eced4
 	github.com/go-playground/validator/v10 v10.14.1
 	github.com/kelseyhightower/envconfig v1.4.0
 	github.com/labstack/echo/v4 v4.10.2
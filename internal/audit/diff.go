@@ -0,0 +1,78 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+)
+
+const fieldID = "uuid"
+
+func Diff(before interface{}, after interface{}) ([]model.AuditChange, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(beforeFields)+len(afterFields))
+	for name := range beforeFields {
+		names = append(names, name)
+	}
+
+	for name := range afterFields {
+		if _, ok := beforeFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	changes := make([]model.AuditChange, 0, len(names))
+
+	for _, name := range names {
+		if name == fieldID {
+			continue
+		}
+
+		if reflect.DeepEqual(beforeFields[name], afterFields[name]) {
+			continue
+		}
+
+		changes = append(changes, model.AuditChange{
+			Field:  name,
+			Before: beforeFields[name],
+			After:  afterFields[name],
+		})
+	}
+
+	return changes, nil
+}
+
+func fields(value interface{}) (map[string]interface{}, error) {
+	var object map[string]interface{}
+
+	if value == nil {
+		return object, nil
+	}
+
+	valueByte, err := json.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorMarshal.Error())
+	}
+
+	if err = json.Unmarshal(valueByte, &object); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorUnmarshal.Error())
+	}
+
+	return object, nil
+}
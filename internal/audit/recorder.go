@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog"
+)
+
+type Recorder struct {
+	auditRepository repository.AuditRepository
+}
+
+func NewRecorder(auditRepository repository.AuditRepository) *Recorder {
+	return &Recorder{auditRepository: auditRepository}
+}
+
+func (recorder *Recorder) Entry(c echo.Context, entityType string, entityID string, operation string, before interface{}, after interface{}) model.AuditEntry {
+	changes, err := Diff(before, after)
+	if err != nil {
+		zerolog.Ctx(c.Request().Context()).Error().Err(err).Str("entity_id", entityID).Msg("diff audit entry")
+	}
+
+	return model.AuditEntry{
+		Actor:      utils.GetActor(c),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Operation:  operation,
+		Changes:    changes,
+		RemoteAddr: c.RealIP(),
+		RequestID:  utils.GetRequestID(c),
+		Timestamp:  time.Now().UTC(),
+	}
+}
+
+func (recorder *Recorder) Record(c echo.Context, entityType string, entityID string, operation string, before interface{}, after interface{}) {
+	recorder.Save(c, recorder.Entry(c, entityType, entityID, operation, before, after))
+}
+
+func (recorder *Recorder) Save(c echo.Context, entries ...model.AuditEntry) {
+	if err := recorder.auditRepository.CreateAuditEntries(c.Request().Context(), entries); err != nil {
+		zerolog.Ctx(c.Request().Context()).Error().Err(err).Int("entries", len(entries)).Msg("save audit entries")
+	}
+}
@@ -1,5 +1,13 @@
 package config
 
+import (
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+
+	"github.com/pkg/errors"
+)
+
 type Config struct {
 	Log struct {
 		LogLevel string `envconfig:"LOG_LEVEL" default:"debug"`
@@ -9,16 +17,109 @@ type Config struct {
 	}
 
 	HTTPServer struct {
-		Address string `envconfig:"HTTP_ADDR" default:"0.0.0.0:8000"`
+		Address    string `envconfig:"HTTP_ADDR" default:"0.0.0.0:8000"`
+		TrustProxy bool   `envconfig:"HTTP_TRUST_PROXY" default:"false"`
+	}
+
+	Debug struct {
+		Address string `envconfig:"DEBUG_ADDR"`
+	}
+
+	Auth struct {
+		Tokens map[string]string `envconfig:"AUTH_TOKENS"`
 	}
 
 	Database struct {
-		Host     string `envconfig:"DB_HOST" default:"localhost"`
-		Port     string `envconfig:"DB_PORT" default:"27017"`
-		Username string `envconfig:"DB_USERNAME"`
-		Password string `envconfig:"DB_PASSWORD"`
-		Auth     string `envconfig:"DB_AUTH"`
-		Name     string `envconfig:"DB_NAME" default:"onlinestoredb"`
+		Host       string `envconfig:"DB_HOST" default:"localhost"`
+		Port       string `envconfig:"DB_PORT" default:"27017"`
+		Username   string `envconfig:"DB_USERNAME"`
+		Password   string `envconfig:"DB_PASSWORD"`
+		Auth       string `envconfig:"DB_AUTH"`
+		Name       string `envconfig:"DB_NAME" default:"onlinestoredb"`
+		ReplicaSet string `envconfig:"DB_REPLICA_SET"`
+	}
+
+	Trash struct {
+		Retention     time.Duration `envconfig:"TRASH_RETENTION" default:"720h"`
+		PurgeInterval time.Duration `envconfig:"TRASH_PURGE_INTERVAL" default:"1h"`
+	}
+
+	Discount struct {
+		ScheduleInterval time.Duration `envconfig:"DISCOUNT_SCHEDULE_INTERVAL" default:"30s"`
+	}
+
+	Publication struct {
+		ScheduleInterval time.Duration `envconfig:"PUBLICATION_SCHEDULE_INTERVAL" default:"30s"`
+	}
+
+	Outbox struct {
+		PollInterval time.Duration `envconfig:"OUTBOX_POLL_INTERVAL" default:"1s"`
+		Lease        time.Duration `envconfig:"OUTBOX_LEASE" default:"1m"`
+		BackoffBase  time.Duration `envconfig:"OUTBOX_BACKOFF_BASE" default:"1s"`
+		BackoffMax   time.Duration `envconfig:"OUTBOX_BACKOFF_MAX" default:"5m"`
+		Retention    time.Duration `envconfig:"OUTBOX_RETENTION" default:"168h"`
+		LogEvents    bool          `envconfig:"OUTBOX_LOG_EVENTS" default:"false"`
+	}
+
+	Webhook struct {
+		Timeout             time.Duration `envconfig:"WEBHOOK_TIMEOUT" default:"10s"`
+		PollInterval        time.Duration `envconfig:"WEBHOOK_POLL_INTERVAL" default:"1s"`
+		Lease               time.Duration `envconfig:"WEBHOOK_LEASE" default:"1m"`
+		MaxAttempts         int           `envconfig:"WEBHOOK_MAX_ATTEMPTS" default:"10"`
+		BackoffBase         time.Duration `envconfig:"WEBHOOK_BACKOFF_BASE" default:"5s"`
+		BackoffMax          time.Duration `envconfig:"WEBHOOK_BACKOFF_MAX" default:"1h"`
+		AllowPrivateTargets bool          `envconfig:"WEBHOOK_ALLOW_PRIVATE_TARGETS" default:"false"`
+	}
+
+	Currency struct {
+		Base      string   `envconfig:"CURRENCY_BASE" default:"RUB"`
+		Supported []string `envconfig:"CURRENCY_SUPPORTED" default:"RUB,USD,EUR"`
+		RatesFile string   `envconfig:"CURRENCY_RATES_FILE"`
+	}
+
+	Locale struct {
+		Default   string   `envconfig:"LOCALE_DEFAULT" default:"ru"`
+		Fallbacks []string `envconfig:"LOCALE_FALLBACKS" default:"en"`
+	}
+
+	Recommendation struct {
+		Limit           int           `envconfig:"RECOMMENDATION_LIMIT" default:"8"`
+		CandidateLimit  int64         `envconfig:"RECOMMENDATION_CANDIDATE_LIMIT" default:"200"`
+		RefreshInterval time.Duration `envconfig:"RECOMMENDATION_REFRESH_INTERVAL" default:"1h"`
+		Window          time.Duration `envconfig:"RECOMMENDATION_WINDOW" default:"2160h"`
+		Depth           int           `envconfig:"RECOMMENDATION_DEPTH" default:"50"`
+	}
+
+	Inventory struct {
+		AllocationStrategy string `envconfig:"INVENTORY_ALLOCATION_STRATEGY" default:"priority"`
+		DefaultWarehouse   string `envconfig:"INVENTORY_DEFAULT_WAREHOUSE" default:"DEFAULT"`
+	}
+
+	Alert struct {
+		Notifiers      []string      `envconfig:"ALERT_NOTIFIERS" default:"log"`
+		WebhookURL     string        `envconfig:"ALERT_WEBHOOK_URL"`
+		WebhookTimeout time.Duration `envconfig:"ALERT_WEBHOOK_TIMEOUT" default:"10s"`
+		SMTPAddress    string        `envconfig:"ALERT_SMTP_ADDR" default:"localhost:1025"`
+		SMTPFrom       string        `envconfig:"ALERT_SMTP_FROM" default:"store@localhost"`
+		SMTPTo         []string      `envconfig:"ALERT_SMTP_TO"`
+		SMTPUsername   string        `envconfig:"ALERT_SMTP_USERNAME"`
+		SMTPPassword   string        `envconfig:"ALERT_SMTP_PASSWORD"`
+		RetryInterval  time.Duration `envconfig:"ALERT_RETRY_INTERVAL" default:"5m"`
+	}
+
+	Media struct {
+		Storage        string        `envconfig:"MEDIA_STORAGE" default:"local"`
+		Dir            string        `envconfig:"MEDIA_DIR" default:"./media"`
+		BaseURL        string        `envconfig:"MEDIA_BASE_URL" default:"/media"`
+		MaxSize        int64         `envconfig:"MEDIA_MAX_SIZE" default:"10485760"`
+		ThumbnailSizes []int         `envconfig:"MEDIA_THUMBNAIL_SIZES" default:"128,256,512"`
+		S3Endpoint     string        `envconfig:"MEDIA_S3_ENDPOINT" default:"http://localhost:9000"`
+		S3Bucket       string        `envconfig:"MEDIA_S3_BUCKET" default:"online-store"`
+		S3Region       string        `envconfig:"MEDIA_S3_REGION" default:"us-east-1"`
+		S3AccessKey    string        `envconfig:"MEDIA_S3_ACCESS_KEY"`
+		S3SecretKey    string        `envconfig:"MEDIA_S3_SECRET_KEY"`
+		S3PublicURL    string        `envconfig:"MEDIA_S3_PUBLIC_URL"`
+		S3Timeout      time.Duration `envconfig:"MEDIA_S3_TIMEOUT" default:"30s"`
 	}
 
 	Application struct {
@@ -26,3 +127,31 @@ type Config struct {
 		Version string `envconfig:"APP_VERSION" default:"v0.0.1"`
 	}
 }
+
+func (config *Config) Validate() error {
+	intervals := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"TRASH_PURGE_INTERVAL", config.Trash.PurgeInterval},
+		{"DISCOUNT_SCHEDULE_INTERVAL", config.Discount.ScheduleInterval},
+		{"PUBLICATION_SCHEDULE_INTERVAL", config.Publication.ScheduleInterval},
+		{"OUTBOX_POLL_INTERVAL", config.Outbox.PollInterval},
+		{"WEBHOOK_POLL_INTERVAL", config.Webhook.PollInterval},
+		{"RECOMMENDATION_REFRESH_INTERVAL", config.Recommendation.RefreshInterval},
+		{"ALERT_RETRY_INTERVAL", config.Alert.RetryInterval},
+	}
+
+	for _, interval := range intervals {
+		if interval.value <= 0 {
+			return errors.Errorf("%s must be positive, got %s", interval.name, interval.value)
+		}
+	}
+
+	if !model.IsAllocationStrategy(config.Inventory.AllocationStrategy) {
+		return errors.Errorf("INVENTORY_ALLOCATION_STRATEGY must be one of %s, %s or %s, got %q",
+			model.AllocationNearest, model.AllocationMostStock, model.AllocationPriority, config.Inventory.AllocationStrategy)
+	}
+
+	return nil
+}
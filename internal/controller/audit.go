@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditController struct {
+	auditRepository repository.AuditRepository
+}
+
+func NewAuditController(auditRepository repository.AuditRepository) *AuditController {
+	return &AuditController{auditRepository: auditRepository}
+}
+
+func (auditController *AuditController) GetAuditEntries(c echo.Context) error {
+	filter := model.AuditFilter{
+		EntityType: c.QueryParam("entity"),
+		EntityID:   c.QueryParam("id"),
+		Actor:      c.QueryParam("actor"),
+		Limit:      defaultAuditLimit,
+	}
+
+	if from := c.QueryParam("from"); from != utils.EmptyString {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		filter.From = &parsed
+	}
+
+	if to := c.QueryParam("to"); to != utils.EmptyString {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		filter.To = &parsed
+	}
+
+	if limit := c.QueryParam("limit"); limit != utils.EmptyString {
+		parsed, err := strconv.ParseInt(limit, 10, 64)
+		if err != nil || parsed <= 0 || parsed > maxAuditLimit {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		filter.Limit = parsed
+	}
+
+	entries, err := auditController.auditRepository.GetAuditEntries(c.Request().Context(), filter)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, entries)
+}
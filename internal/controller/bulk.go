@@ -0,0 +1,243 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type bulkBatch struct {
+	ordered   bool
+	stopped   bool
+	results   []model.BulkResult
+	positions []int
+}
+
+func newBulkBatch(size int, ordered bool) *bulkBatch {
+	results := make([]model.BulkResult, size)
+	for i := range results {
+		results[i].Index = i
+	}
+
+	return &bulkBatch{
+		ordered:   ordered,
+		results:   results,
+		positions: make([]int, 0, size),
+	}
+}
+
+func (batch *bulkBatch) skip(index int) bool {
+	if !batch.stopped {
+		return false
+	}
+
+	batch.results[index].Status = model.BulkStatusSkipped
+
+	return true
+}
+
+func (batch *bulkBatch) reject(index int, status string, message string) {
+	batch.results[index].Status = status
+	batch.results[index].Error = message
+	batch.stopped = batch.ordered
+}
+
+func (batch *bulkBatch) accept(index int) {
+	batch.positions = append(batch.positions, index)
+}
+
+func (batch *bulkBatch) merge(results []model.BulkResult) []model.BulkResult {
+	for i, result := range results {
+		position := batch.positions[i]
+		result.Index = position
+		batch.results[position] = result
+	}
+
+	return batch.results
+}
+
+type bulkRejection struct {
+	status  string
+	message string
+}
+
+func (rejection *bulkRejection) Error() string {
+	return rejection.message
+}
+
+func bulkConflict(message string) error {
+	return &bulkRejection{status: model.BulkStatusConflict, message: message}
+}
+
+func bulkRejectionStatus(err error) string {
+	if rejection, ok := err.(*bulkRejection); ok {
+		return rejection.status
+	}
+
+	return model.BulkStatusInvalid
+}
+
+type bulkItems[I any, M any] struct {
+	entityType string
+	operation  string
+	applied    string
+	title      func(item *I) string
+	exists     func(ctx context.Context, title string) bool
+	prepare    func(ctx context.Context, item *I) (*M, *M, error)
+	write      func(ctx context.Context, models []M, ordered bool) ([]model.BulkResult, error)
+	identify   func(entity *M, id string)
+}
+
+func runBulkItems[I any, M any](c echo.Context, auditRecorder *audit.Recorder, items []I, ordered bool, spec bulkItems[I, M]) error {
+	ctx := c.Request().Context()
+
+	batch := newBulkBatch(len(items), ordered)
+	entities := make([]M, 0, len(items))
+	befores := make([]*M, 0, len(items))
+	titles := make(map[string]bool, len(items))
+
+	for i := range items {
+		if batch.skip(i) {
+			continue
+		}
+
+		item := &items[i]
+
+		if err := c.Validate(item); err != nil {
+			batch.reject(i, model.BulkStatusInvalid, err.Error())
+			continue
+		}
+
+		title := utils.EmptyString
+
+		if spec.title != nil {
+			title = spec.title(item)
+
+			if titles[title] {
+				batch.reject(i, model.BulkStatusConflict, spec.entityType+" with this title is duplicated in batch")
+				continue
+			}
+
+			if spec.exists(ctx, title) {
+				batch.reject(i, model.BulkStatusConflict, spec.entityType+" with this title is exist")
+				continue
+			}
+		}
+
+		entity, before, err := spec.prepare(ctx, item)
+		if err != nil {
+			batch.reject(i, bulkRejectionStatus(err), err.Error())
+			continue
+		}
+
+		if spec.title != nil {
+			titles[title] = true
+		}
+
+		entities = append(entities, *entity)
+		befores = append(befores, before)
+		batch.accept(i)
+	}
+
+	if len(entities) == 0 {
+		return utils.Negotiate(c, http.StatusOK, batch.results)
+	}
+
+	results, err := spec.write(ctx, entities, ordered)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	entries := make([]model.AuditEntry, 0, len(results))
+	for i, result := range results {
+		if result.Status != spec.applied {
+			continue
+		}
+
+		if spec.identify != nil {
+			spec.identify(&entities[i], result.ID)
+		}
+
+		var before interface{}
+		if befores[i] != nil {
+			before = befores[i]
+		}
+
+		entries = append(entries, auditRecorder.Entry(c, spec.entityType, result.ID, spec.operation, before, entities[i]))
+	}
+
+	auditRecorder.Save(c, entries...)
+
+	return utils.Negotiate(c, http.StatusOK, batch.merge(results))
+}
+
+type bulkIDs[M any] struct {
+	entityType string
+	operation  string
+	applied    string
+	check      func(ctx context.Context, id string) error
+	get        func(ctx context.Context, id string) (*M, error)
+	write      func(ctx context.Context, ids []string, ordered bool) ([]model.BulkResult, error)
+	after      func(before *M) interface{}
+}
+
+func runBulkIDs[M any](c echo.Context, auditRecorder *audit.Recorder, ids []string, ordered bool, spec bulkIDs[M]) error {
+	ctx := c.Request().Context()
+
+	batch := newBulkBatch(len(ids), ordered)
+	accepted := make([]string, 0, len(ids))
+	befores := make([]*M, 0, len(ids))
+
+	for i, id := range ids {
+		batch.results[i].ID = id
+
+		if batch.skip(i) {
+			continue
+		}
+
+		if spec.check != nil {
+			if err := spec.check(ctx, id); err != nil {
+				batch.reject(i, bulkRejectionStatus(err), err.Error())
+				continue
+			}
+		}
+
+		before, _ := spec.get(ctx, id)
+
+		accepted = append(accepted, id)
+		befores = append(befores, before)
+		batch.accept(i)
+	}
+
+	if len(accepted) == 0 {
+		return utils.Negotiate(c, http.StatusOK, batch.results)
+	}
+
+	results, err := spec.write(ctx, accepted, ordered)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	entries := make([]model.AuditEntry, 0, len(results))
+	for i, result := range results {
+		if result.Status != spec.applied || befores[i] == nil {
+			continue
+		}
+
+		var after interface{}
+		if spec.after != nil {
+			after = spec.after(befores[i])
+		}
+
+		entries = append(entries, auditRecorder.Entry(c, spec.entityType, result.ID, spec.operation, befores[i], after))
+	}
+
+	auditRecorder.Save(c, entries...)
+
+	return utils.Negotiate(c, http.StatusOK, batch.merge(results))
+}
@@ -0,0 +1,293 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/inventory"
+	"github.com/Meystergod/online-store/internal/pricing"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type CartControllerDeps struct {
+	CartRepository      repository.CartRepository
+	ProductRepository   repository.ProductRepository
+	OrderRepository     repository.OrderRepository
+	InventoryRepository repository.InventoryRepository
+	WarehouseRepository repository.WarehouseRepository
+	PricingEngine       *pricing.Engine
+	AllocationStrategy  string
+}
+
+type CartController struct {
+	cartRepository      repository.CartRepository
+	productRepository   repository.ProductRepository
+	orderRepository     repository.OrderRepository
+	inventoryRepository repository.InventoryRepository
+	warehouseRepository repository.WarehouseRepository
+	pricingEngine       *pricing.Engine
+	allocationStrategy  string
+}
+
+func NewCartController(deps *CartControllerDeps) *CartController {
+	return &CartController{
+		cartRepository:      deps.CartRepository,
+		productRepository:   deps.ProductRepository,
+		orderRepository:     deps.OrderRepository,
+		inventoryRepository: deps.InventoryRepository,
+		warehouseRepository: deps.WarehouseRepository,
+		pricingEngine:       deps.PricingEngine,
+		allocationStrategy:  deps.AllocationStrategy,
+	}
+}
+
+func (cartController *CartController) GetCart(c echo.Context) error {
+	customerID := c.Param("customer")
+	if customerID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	rate, err := cartController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	cart, err := cartController.cartRepository.GetCart(c.Request().Context(), customerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if err = cartController.resolveCart(c, cart, rate); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, cart)
+}
+
+func (cartController *CartController) SetCartItem(c echo.Context) error {
+	customerID := c.Param("customer")
+	sku := c.Param("sku")
+	if customerID == "" || sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.SetCartItem
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	rate, err := cartController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	product, err := cartController.productRepository.GetProductBySKU(c.Request().Context(), sku)
+	if err != nil || !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	_, check, err := putCartItem(c.Request().Context(), cartController.cartRepository, customerID, product, sku, payload.Quantity)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if check != nil {
+		return utils.Negotiate(c, http.StatusConflict, check)
+	}
+
+	cart, err := cartController.cartRepository.GetCart(c.Request().Context(), customerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if err = cartController.resolveCart(c, cart, rate); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, cart)
+}
+
+func (cartController *CartController) RemoveCartItem(c echo.Context) error {
+	customerID := c.Param("customer")
+	sku := c.Param("sku")
+	if customerID == "" || sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := cartController.cartRepository.RemoveCartItem(c.Request().Context(), customerID, sku)
+	if errors.Is(err, utils.ErrorCartItemNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (cartController *CartController) ClearCart(c echo.Context) error {
+	customerID := c.Param("customer")
+	if customerID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	if err := cartController.cartRepository.ClearCart(c.Request().Context(), customerID); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (cartController *CartController) Checkout(c echo.Context) error {
+	customerID := c.Param("customer")
+	if customerID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	rate, err := cartController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	cart, err := cartController.cartRepository.GetCart(c.Request().Context(), customerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(cart.Items) == 0 {
+		return utils.Negotiate(c, http.StatusBadRequest, "cart is empty")
+	}
+
+	if err = cartController.resolveCart(c, cart, rate); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !cart.Ready() {
+		return utils.Negotiate(c, http.StatusConflict, cart)
+	}
+
+	order := cart.Order()
+
+	movements, err := cartController.allocateCart(c, cart)
+	if errors.Is(err, utils.ErrorInsufficientStock) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	_, err = cartController.orderRepository.CreateOrder(c.Request().Context(), order, cart, movements)
+	if errors.Is(err, utils.ErrorCartChanged) || errors.Is(err, utils.ErrorInsufficientStock) || errors.Is(err, utils.ErrorVariantNotFound) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusCreated, order)
+}
+
+func (cartController *CartController) resolveCart(c echo.Context, cart *model.Cart, rate *model.ExchangeRate) error {
+	cart.Currency = rate.Currency
+
+	if len(cart.Items) == 0 {
+		return nil
+	}
+
+	products, err := cartController.productRepository.GetProductsByIDs(c.Request().Context(), cart.ProductIDs())
+	if err != nil {
+		return err
+	}
+
+	cart.Resolve(*products)
+
+	priceable, quantities, positions := cart.Priceable()
+	if len(priceable) == 0 {
+		return nil
+	}
+
+	quotes, err := cartController.pricingEngine.QuoteProducts(c.Request().Context(), priceable, quantities, rate)
+	if err != nil {
+		return err
+	}
+
+	for i := range quotes {
+		cart.Items[positions[i]].Pricing = &quotes[i]
+		cart.Total += quotes[i].Total
+	}
+
+	cart.Total = rate.Round(cart.Total)
+
+	return nil
+}
+
+func (cartController *CartController) allocateCart(c echo.Context, cart *model.Cart) ([]*model.StockMovement, error) {
+	warehouses, err := cartController.warehouseRepository.GetAllWarehouses(c.Request().Context())
+	if err != nil {
+		return nil, err
+	}
+
+	stocks, err := cartController.inventoryRepository.GetWarehouseStocks(c.Request().Context(), cart.ProductIDs())
+	if err != nil {
+		return nil, err
+	}
+
+	lines := make([]model.StockLine, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		lines = append(lines, model.StockLine{ProductID: item.ProductID, Quantity: item.Quantity})
+	}
+
+	allocated, err := inventory.AllocateLines(cartController.allocationStrategy, nil, lines, *warehouses, stocks)
+	if err != nil {
+		return nil, err
+	}
+
+	actor := utils.GetActor(c)
+	movements := make([]*model.StockMovement, 0, len(cart.Items))
+
+	for i, item := range cart.Items {
+		sku := utils.EmptyString
+		if _, ok := item.Product.Variant(item.SKU); ok {
+			sku = item.SKU
+		}
+
+		for _, allocation := range allocated[i] {
+			movements = append(movements, &model.StockMovement{
+				ProductID:   item.ProductID,
+				SKU:         sku,
+				Type:        model.StockMovementReservation,
+				Quantity:    allocation.Quantity,
+				Reason:      "checkout",
+				WarehouseID: allocation.WarehouseID,
+				Actor:       actor,
+			})
+		}
+	}
+
+	return movements, nil
+}
+
+func putCartItem(ctx context.Context, cartRepository repository.CartRepository, customerID string, product *model.Product, sku string, quantity int) (*model.CartItem, *model.StockCheck, error) {
+	available, _ := product.AvailableSKU(sku)
+	if available < quantity {
+		return nil, &model.StockCheck{SKU: sku, ProductID: product.ID, Requested: quantity, Available: available}, nil
+	}
+
+	item := &model.CartItem{SKU: sku, ProductID: product.ID, Quantity: quantity}
+
+	if err := cartRepository.SetCartItem(ctx, customerID, item); err != nil {
+		return nil, nil, err
+	}
+
+	return item, nil, nil
+}
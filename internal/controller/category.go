@@ -1,21 +1,30 @@
 package controller
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/i18n"
 	"github.com/Meystergod/online-store/internal/repository"
 	"github.com/Meystergod/online-store/internal/utils"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 type CategoryController struct {
 	categoryRepository repository.CategoryRepository
+	productRepository  repository.ProductRepository
+	localizer          *i18n.Localizer
+	auditRecorder      *audit.Recorder
 }
 
-func NewCategoryController(categoryRepository repository.CategoryRepository) *CategoryController {
-	return &CategoryController{categoryRepository: categoryRepository}
+func NewCategoryController(categoryRepository repository.CategoryRepository, productRepository repository.ProductRepository, localizer *i18n.Localizer, auditRecorder *audit.Recorder) *CategoryController {
+	return &CategoryController{categoryRepository: categoryRepository, productRepository: productRepository, localizer: localizer, auditRecorder: auditRecorder}
 }
 
 func (categoryController *CategoryController) CreateCategory(c echo.Context) error {
@@ -25,16 +34,35 @@ func (categoryController *CategoryController) CreateCategory(c echo.Context) err
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
-	_, err := categoryController.categoryRepository.GetCategoryByTitle(c.Request().Context(), payload.Title)
+	_, err := categoryController.categoryRepository.GetCategoryByTitle(c.Request().Context(), payload.Title, utils.EmptyString)
 	if err == nil {
 		return utils.Negotiate(c, http.StatusConflict, "category with this title is exist")
 	}
 
-	createdCategoryID, err := categoryController.categoryRepository.CreateCategory(c.Request().Context(), payload.ToModel())
+	if payload.ParentID != utils.EmptyString {
+		if _, err = categoryController.categoryRepository.GetCategory(c.Request().Context(), payload.ParentID); err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, "parent category is not found")
+		}
+	}
+
+	category := payload.ToModel()
+
+	if err = model.ValidateAttributeDefinitions(category.Attributes); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	createdCategoryID, err := categoryController.categoryRepository.CreateCategory(c.Request().Context(), category)
+	if errors.Is(err, utils.ErrorSlugExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	category.ID = createdCategoryID
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, createdCategoryID, model.AuditOperationCreate, nil, category)
+
 	return utils.Negotiate(c, http.StatusCreated, createdCategoryID)
 }
 
@@ -44,6 +72,11 @@ func (categoryController *CategoryController) GetAllCategories(c echo.Context) e
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	chain := categoryController.localizer.Chain(c)
+	for i := range *categories {
+		(*categories)[i].Localize(chain)
+	}
+
 	return utils.Negotiate(c, http.StatusOK, categories)
 }
 
@@ -58,6 +91,28 @@ func (categoryController *CategoryController) GetCategory(c echo.Context) error
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	category.Localize(categoryController.localizer.Chain(c))
+
+	return utils.Negotiate(c, http.StatusOK, category)
+}
+
+func (categoryController *CategoryController) GetCategoryBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+	if slug == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	category, err := categoryController.categoryRepository.GetCategoryBySlug(c.Request().Context(), slug)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if category.Slug != slug {
+		return slugRedirect(c, category.Slug)
+	}
+
+	category.Localize(categoryController.localizer.Chain(c))
+
 	return utils.Negotiate(c, http.StatusOK, category)
 }
 
@@ -67,11 +122,24 @@ func (categoryController *CategoryController) GetCategoryByTitle(c echo.Context)
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	category, err := categoryController.categoryRepository.GetCategoryByTitle(c.Request().Context(), title)
+	chain := categoryController.localizer.Chain(c)
+
+	var category *model.Category
+	var err error
+
+	for _, locale := range categoryController.localizer.Lookup(chain) {
+		category, err = categoryController.categoryRepository.GetCategoryByTitle(c.Request().Context(), title, locale)
+		if err == nil {
+			break
+		}
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	category.Localize(chain)
+
 	return utils.Negotiate(c, http.StatusOK, category)
 }
 
@@ -87,14 +155,38 @@ func (categoryController *CategoryController) UpdateCategory(c echo.Context) err
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
+	before, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
 	category := payload.ToModel()
 	category.ID = id
 
-	err := categoryController.categoryRepository.UpdateCategory(c.Request().Context(), category)
+	if err = model.ValidateAttributeDefinitions(category.Attributes); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	violations, err := categoryController.attributeViolations(c.Request().Context(), category)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(violations) > 0 {
+		return utils.Negotiate(c, http.StatusConflict, violations)
+	}
+
+	err = categoryController.categoryRepository.UpdateCategory(c.Request().Context(), category)
+	if errors.Is(err, utils.ErrorSlugExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationUpdate, before, category)
+
 	return utils.Negotiate(c, http.StatusOK, category)
 }
 
@@ -104,10 +196,375 @@ func (categoryController *CategoryController) DeleteCategory(c echo.Context) err
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	err := categoryController.categoryRepository.DeleteCategory(c.Request().Context(), id)
+	before, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	descendants, err := categoryController.categoryRepository.GetCategoryDescendants(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(*descendants) > 0 {
+		return utils.Negotiate(c, http.StatusConflict, "category has child categories")
+	}
+
+	err = categoryController.categoryRepository.DeleteCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (categoryController *CategoryController) GetDeletedCategories(c echo.Context) error {
+	categories, err := categoryController.categoryRepository.GetDeletedCategories(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, categories)
+}
+
+func (categoryController *CategoryController) RestoreCategory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := categoryController.categoryRepository.RestoreCategory(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorTitleExists) || errors.Is(err, utils.ErrorCategoryParentDeleted) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	after, _ := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationRestore, nil, after)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (categoryController *CategoryController) BulkCreateCategories(c echo.Context) error {
+	var payload dto.BulkCreateCategories
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, categoryController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.CreateCategory, model.Category]{
+		entityType: utils.CollNameCategory,
+		operation:  model.AuditOperationCreate,
+		applied:    model.BulkStatusCreated,
+		title:      func(item *dto.CreateCategory) string { return item.Title },
+		exists: func(ctx context.Context, title string) bool {
+			_, err := categoryController.categoryRepository.GetCategoryByTitle(ctx, title, utils.EmptyString)
+			return err == nil
+		},
+		prepare: func(ctx context.Context, item *dto.CreateCategory) (*model.Category, *model.Category, error) {
+			if err := model.ValidateAttributeDefinitions(item.Attributes); err != nil {
+				return nil, nil, err
+			}
+
+			if item.ParentID != utils.EmptyString {
+				if _, err := categoryController.categoryRepository.GetCategory(ctx, item.ParentID); err != nil {
+					return nil, nil, errors.New("parent category is not found")
+				}
+			}
+
+			return item.ToModel(), nil, nil
+		},
+		write:    categoryController.categoryRepository.BulkCreateCategories,
+		identify: func(category *model.Category, id string) { category.ID = id },
+	})
+}
+
+func (categoryController *CategoryController) BulkUpdateCategories(c echo.Context) error {
+	var payload dto.BulkUpdateCategories
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, categoryController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.BulkUpdateCategory, model.Category]{
+		entityType: utils.CollNameCategory,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		prepare: func(ctx context.Context, item *dto.BulkUpdateCategory) (*model.Category, *model.Category, error) {
+			if err := model.ValidateAttributeDefinitions(item.Attributes); err != nil {
+				return nil, nil, err
+			}
+
+			before, _ := categoryController.categoryRepository.GetCategory(ctx, item.ID)
+
+			category := item.ToModel()
+			category.ID = item.ID
+
+			violations, err := categoryController.attributeViolations(ctx, category)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if len(violations) > 0 {
+				return nil, nil, bulkConflict(fmt.Sprintf("%d products violate the attributes, first %s: %s", len(violations), violations[0].ProductID, violations[0].Error))
+			}
+
+			return category, before, nil
+		},
+		write: categoryController.categoryRepository.BulkUpdateCategories,
+	})
+}
+
+func (categoryController *CategoryController) BulkDeleteCategories(c echo.Context) error {
+	var payload dto.BulkDelete
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, categoryController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Category]{
+		entityType: utils.CollNameCategory,
+		operation:  model.AuditOperationDelete,
+		applied:    model.BulkStatusDeleted,
+		check: func(ctx context.Context, id string) error {
+			descendants, err := categoryController.categoryRepository.GetCategoryDescendants(ctx, id)
+			if err == nil && len(*descendants) > 0 {
+				return bulkConflict("category has child categories")
+			}
+
+			return nil
+		},
+		get:   categoryController.categoryRepository.GetCategory,
+		write: categoryController.categoryRepository.BulkDeleteCategories,
+	})
+}
+
+func (categoryController *CategoryController) GetCategoryTree(c echo.Context) error {
+	categories, err := categoryController.categoryRepository.GetAllCategories(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	chain := categoryController.localizer.Chain(c)
+	for i := range *categories {
+		(*categories)[i].Localize(chain)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, model.BuildCategoryTree(*categories, utils.EmptyString))
+}
+
+func (categoryController *CategoryController) GetCategorySubtree(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	category, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	descendants, err := categoryController.categoryRepository.GetCategoryDescendants(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	chain := categoryController.localizer.Chain(c)
+
+	category.Localize(chain)
+	for i := range *descendants {
+		(*descendants)[i].Localize(chain)
+	}
+
+	node := model.CategoryNode{
+		Category: *category,
+		Children: model.BuildCategoryTree(*descendants, id),
+	}
+
+	return utils.Negotiate(c, http.StatusOK, node)
+}
+
+func (categoryController *CategoryController) GetCategoryBreadcrumbs(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	breadcrumbs, err := categoryBreadcrumbs(c, categoryController.categoryRepository, id, categoryController.localizer.Chain(c))
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	return utils.Negotiate(c, http.StatusOK, breadcrumbs)
+}
+
+func (categoryController *CategoryController) MoveCategory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.MoveCategory
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	category, err := categoryController.categoryRepository.MoveCategory(c.Request().Context(), id, payload.ParentID)
+	if errors.Is(err, utils.ErrorCategoryCycle) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationUpdate, before, category)
+
+	return utils.Negotiate(c, http.StatusOK, category)
+}
+
+func (categoryController *CategoryController) GetCategoryProducts(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	descendants, err := categoryController.categoryRepository.GetCategoryDescendants(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	ids := make([]string, 0, len(*descendants)+1)
+	ids = append(ids, id)
+	for _, descendant := range *descendants {
+		ids = append(ids, descendant.ID)
+	}
+
+	products, err := categoryController.productRepository.GetProductsByCategories(c.Request().Context(), ids)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	chain := categoryController.localizer.Chain(c)
+	for i := range *products {
+		(*products)[i].Localize(chain)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, products)
+}
+
+func (categoryController *CategoryController) SetCategoryTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, categoryController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	var payload dto.SetTranslation
+
+	if err = utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	existing, err := categoryController.categoryRepository.GetCategoryByTitle(c.Request().Context(), payload.Title, locale)
+	if err == nil && existing.ID != id {
+		return utils.Negotiate(c, http.StatusConflict, "category with this title is exist")
+	}
+
+	translation := payload.ToModel()
+
+	err = categoryController.categoryRepository.SetCategoryTranslation(c.Request().Context(), id, locale, translation)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	category := *before
+	category.Translations = model.WithTranslation(before.Translations, locale, translation)
+
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationUpdate, before, category)
+
+	return utils.Negotiate(c, http.StatusOK, category)
+}
+
+func (categoryController *CategoryController) DeleteCategoryTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, categoryController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	before, err := categoryController.categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if _, ok := before.Translations[locale]; !ok {
+		return utils.Negotiate(c, http.StatusNotFound, "translation is not found")
+	}
+
+	err = categoryController.categoryRepository.SetCategoryTranslation(c.Request().Context(), id, locale, nil)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	category := *before
+	category.Translations = model.WithTranslation(before.Translations, locale, nil)
+
+	categoryController.auditRecorder.Record(c, utils.CollNameCategory, id, model.AuditOperationUpdate, before, category)
+
 	return utils.Negotiate(c, http.StatusNoContent, nil)
 }
+
+func categoryBreadcrumbs(c echo.Context, categoryRepository repository.CategoryRepository, id string, chain model.LocaleChain) ([]model.Breadcrumb, error) {
+	category, err := categoryRepository.GetCategory(c.Request().Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := categoryRepository.GetCategoryAncestors(c.Request().Context(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	breadcrumbs := make([]model.Breadcrumb, 0, len(*ancestors)+1)
+	for _, ancestor := range append(*ancestors, *category) {
+		ancestor.Localize(chain)
+		breadcrumbs = append(breadcrumbs, model.Breadcrumb{ID: ancestor.ID, Title: ancestor.Title, Type: model.BreadcrumbCategory})
+	}
+
+	return breadcrumbs, nil
+}
+
+func (categoryController *CategoryController) attributeViolations(ctx context.Context, category *model.Category) ([]model.AttributeViolation, error) {
+	products, err := categoryController.productRepository.GetCategoryProductAttributes(ctx, category.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return category.AttributeViolations(*products), nil
+}
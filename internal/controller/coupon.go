@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CouponController struct {
+	couponRepository   repository.CouponRepository
+	discountRepository repository.DiscountRepository
+	auditRecorder      *audit.Recorder
+}
+
+func NewCouponController(couponRepository repository.CouponRepository, discountRepository repository.DiscountRepository, auditRecorder *audit.Recorder) *CouponController {
+	return &CouponController{
+		couponRepository:   couponRepository,
+		discountRepository: discountRepository,
+		auditRecorder:      auditRecorder,
+	}
+}
+
+func (couponController *CouponController) CreateCoupon(c echo.Context) error {
+	var payload dto.CreateCoupon
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	coupon := payload.ToModel()
+
+	_, err := couponController.couponRepository.GetCouponByCode(c.Request().Context(), coupon.Code)
+	if err == nil {
+		return utils.Negotiate(c, http.StatusConflict, "coupon with this code is exist")
+	}
+
+	if coupon.DiscountID != utils.EmptyString {
+		if _, err = couponController.discountRepository.GetDiscount(c.Request().Context(), coupon.DiscountID); err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+		}
+	}
+
+	createdCouponID, err := couponController.couponRepository.CreateCoupon(c.Request().Context(), coupon)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	coupon.ID = createdCouponID
+	couponController.auditRecorder.Record(c, utils.CollNameCoupon, createdCouponID, model.AuditOperationCreate, nil, coupon)
+
+	return utils.Negotiate(c, http.StatusCreated, createdCouponID)
+}
+
+func (couponController *CouponController) GetAllCoupons(c echo.Context) error {
+	coupons, err := couponController.couponRepository.GetAllCoupons(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, coupons)
+}
+
+func (couponController *CouponController) GetCoupon(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	coupon, err := couponController.couponRepository.GetCoupon(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, coupon)
+}
+
+func (couponController *CouponController) UpdateCoupon(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateCoupon
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := couponController.couponRepository.GetCoupon(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	coupon := payload.ToModel()
+	coupon.ID = id
+	coupon.Code = before.Code
+
+	if coupon.DiscountID != utils.EmptyString {
+		if _, err = couponController.discountRepository.GetDiscount(c.Request().Context(), coupon.DiscountID); err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+		}
+	}
+
+	err = couponController.couponRepository.UpdateCoupon(c.Request().Context(), coupon)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	coupon.Used = before.Used
+	coupon.CreatedAt = before.CreatedAt
+
+	couponController.auditRecorder.Record(c, utils.CollNameCoupon, id, model.AuditOperationUpdate, before, coupon)
+
+	return utils.Negotiate(c, http.StatusOK, coupon)
+}
+
+func (couponController *CouponController) DeleteCoupon(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := couponController.couponRepository.GetCoupon(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = couponController.couponRepository.DeleteCoupon(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	couponController.auditRecorder.Record(c, utils.CollNameCoupon, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (couponController *CouponController) ValidateCoupon(c echo.Context) error {
+	var payload dto.RedeemCoupon
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	code := dto.NormalizeCouponCode(payload.Code)
+
+	coupon, err := couponController.couponRepository.GetCouponByCode(c.Request().Context(), code)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return utils.Negotiate(c, http.StatusOK, rejectCoupon(code, payload.OrderValue, model.CouponRejectNotFound))
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	redemptions, err := couponController.couponRepository.CountCouponRedemptions(c.Request().Context(), code, payload.CustomerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if reason := coupon.Reject(redemptions, payload.OrderValue, time.Now()); reason != utils.EmptyString {
+		return utils.Negotiate(c, http.StatusOK, rejectCoupon(code, payload.OrderValue, reason))
+	}
+
+	discount, reason, err := couponController.linkedDiscount(c.Request().Context(), coupon)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if reason != utils.EmptyString {
+		return utils.Negotiate(c, http.StatusOK, rejectCoupon(code, payload.OrderValue, reason))
+	}
+
+	return utils.Negotiate(c, http.StatusOK, coupon.Apply(payload.OrderValue, discount))
+}
+
+func (couponController *CouponController) RedeemCoupon(c echo.Context) error {
+	var payload dto.RedeemCoupon
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	code := dto.NormalizeCouponCode(payload.Code)
+
+	coupon, err := couponController.couponRepository.GetCouponByCode(c.Request().Context(), code)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return utils.Negotiate(c, http.StatusUnprocessableEntity, rejectCoupon(code, payload.OrderValue, model.CouponRejectNotFound))
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	discount, reason, err := couponController.linkedDiscount(c.Request().Context(), coupon)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if reason != utils.EmptyString {
+		return utils.Negotiate(c, http.StatusUnprocessableEntity, rejectCoupon(code, payload.OrderValue, reason))
+	}
+
+	now := time.Now()
+
+	redeemed, redemption, err := couponController.couponRepository.RedeemCoupon(c.Request().Context(), code, payload.CustomerID, payload.OrderValue, now)
+	if errors.Is(err, utils.ErrorCouponCustomerLimit) {
+		return utils.Negotiate(c, http.StatusUnprocessableEntity, rejectCoupon(code, payload.OrderValue, model.CouponRejectCustomerUsageLimit))
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		current, err := couponController.couponRepository.GetCouponByCode(c.Request().Context(), code)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusUnprocessableEntity, rejectCoupon(code, payload.OrderValue, model.CouponRejectNotFound))
+		}
+
+		reason = current.Reject(0, payload.OrderValue, now)
+		if reason == utils.EmptyString {
+			reason = model.CouponRejectUsageLimit
+		}
+
+		return utils.Negotiate(c, http.StatusUnprocessableEntity, rejectCoupon(code, payload.OrderValue, reason))
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	check := redeemed.Apply(payload.OrderValue, discount)
+	check.RedemptionID = redemption.ID
+
+	return utils.Negotiate(c, http.StatusOK, check)
+}
+
+func (couponController *CouponController) ReleaseCoupon(c echo.Context) error {
+	var payload dto.ReleaseCoupon
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	_, err := couponController.couponRepository.ReleaseCoupon(c.Request().Context(), payload.RedemptionID)
+	if errors.Is(err, utils.ErrorCouponRedemptionNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (couponController *CouponController) linkedDiscount(ctx context.Context, coupon *model.Coupon) (*model.Discount, string, error) {
+	if coupon.DiscountID == utils.EmptyString {
+		return nil, utils.EmptyString, nil
+	}
+
+	discount, err := couponController.discountRepository.GetDiscount(ctx, coupon.DiscountID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, model.CouponRejectDiscountInactive, nil
+	}
+
+	if err != nil {
+		return nil, utils.EmptyString, err
+	}
+
+	if !discount.ActiveAt(time.Now()) {
+		return nil, model.CouponRejectDiscountInactive, nil
+	}
+
+	return discount, utils.EmptyString, nil
+}
+
+func rejectCoupon(code string, orderValue float64, reason string) model.CouponCheck {
+	return model.CouponCheck{
+		Code:   code,
+		Reason: reason,
+		Total:  orderValue,
+	}
+}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/pricing"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type CurrencyController struct {
+	exchangeRateRepository repository.ExchangeRateRepository
+	pricingEngine          *pricing.Engine
+	auditRecorder          *audit.Recorder
+}
+
+func NewCurrencyController(exchangeRateRepository repository.ExchangeRateRepository, pricingEngine *pricing.Engine, auditRecorder *audit.Recorder) *CurrencyController {
+	return &CurrencyController{
+		exchangeRateRepository: exchangeRateRepository,
+		pricingEngine:          pricingEngine,
+		auditRecorder:          auditRecorder,
+	}
+}
+
+func (currencyController *CurrencyController) GetCurrencies(c echo.Context) error {
+	currencies, err := currencyController.pricingEngine.Currencies(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, currencies)
+}
+
+func (currencyController *CurrencyController) SaveExchangeRates(c echo.Context) error {
+	var payload dto.SaveExchangeRates
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	if err := currencyController.pricingEngine.ValidateRates(payload.Rates); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	err := currencyController.exchangeRateRepository.SaveExchangeRates(c.Request().Context(), payload.Rates)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	entries := make([]model.AuditEntry, 0, len(payload.Rates))
+	for _, rate := range payload.Rates {
+		entries = append(entries, currencyController.auditRecorder.Entry(c, utils.CollNameExchangeRate, rate.Currency, model.AuditOperationUpdate, nil, rate))
+	}
+
+	currencyController.auditRecorder.Save(c, entries...)
+
+	return utils.Negotiate(c, http.StatusOK, payload.Rates)
+}
+
+func (currencyController *CurrencyController) DeleteExchangeRate(c echo.Context) error {
+	currency := strings.ToUpper(c.Param("currency"))
+	if currency == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := currencyController.exchangeRateRepository.GetExchangeRate(c.Request().Context(), currency)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = currencyController.exchangeRateRepository.DeleteExchangeRate(c.Request().Context(), currency)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	currencyController.auditRecorder.Record(c, utils.CollNameExchangeRate, currency, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func currencyStatus(err error) int {
+	if errors.Is(err, utils.ErrorUnsupportedCurrency) || errors.Is(err, utils.ErrorExchangeRateMissing) {
+		return http.StatusBadRequest
+	}
+
+	return http.StatusInternalServerError
+}
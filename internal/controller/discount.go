@@ -1,21 +1,26 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
 	"github.com/Meystergod/online-store/internal/repository"
 	"github.com/Meystergod/online-store/internal/utils"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 type DiscountController struct {
 	discountRepository repository.DiscountRepository
+	auditRecorder      *audit.Recorder
 }
 
-func NewDiscountController(discountRepository repository.DiscountRepository) *DiscountController {
-	return &DiscountController{discountRepository: discountRepository}
+func NewDiscountController(discountRepository repository.DiscountRepository, auditRecorder *audit.Recorder) *DiscountController {
+	return &DiscountController{discountRepository: discountRepository, auditRecorder: auditRecorder}
 }
 
 func (discountController *DiscountController) CreateDiscount(c echo.Context) error {
@@ -25,16 +30,24 @@ func (discountController *DiscountController) CreateDiscount(c echo.Context) err
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
+	discount := payload.ToModel()
+	if !discount.ValidWindow() {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorDiscountWindow.Error())
+	}
+
 	_, err := discountController.discountRepository.GetDiscountByTitle(c.Request().Context(), payload.Title)
 	if err == nil {
 		return utils.Negotiate(c, http.StatusConflict, "discount with this title is exist")
 	}
 
-	createdDiscountID, err := discountController.discountRepository.CreateDiscount(c.Request().Context(), payload.ToModel())
+	createdDiscountID, err := discountController.discountRepository.CreateDiscount(c.Request().Context(), discount)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	discount.ID = createdDiscountID
+	discountController.auditRecorder.Record(c, utils.CollNameDiscount, createdDiscountID, model.AuditOperationCreate, nil, discount)
+
 	return utils.Negotiate(c, http.StatusCreated, createdDiscountID)
 }
 
@@ -76,11 +89,22 @@ func (discountController *DiscountController) UpdateDiscount(c echo.Context) err
 	discount := payload.ToModel()
 	discount.ID = id
 
-	err := discountController.discountRepository.UpdateDiscount(c.Request().Context(), discount)
+	if !discount.ValidWindow() {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorDiscountWindow.Error())
+	}
+
+	before, err := discountController.discountRepository.GetDiscount(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = discountController.discountRepository.UpdateDiscount(c.Request().Context(), discount)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	discountController.auditRecorder.Record(c, utils.CollNameDiscount, id, model.AuditOperationUpdate, before, discount)
+
 	return utils.Negotiate(c, http.StatusOK, discount)
 }
 
@@ -90,10 +114,120 @@ func (discountController *DiscountController) DeleteDiscount(c echo.Context) err
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	err := discountController.discountRepository.DeleteDiscount(c.Request().Context(), id)
+	before, err := discountController.discountRepository.GetDiscount(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = discountController.discountRepository.DeleteDiscount(c.Request().Context(), id)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	discountController.auditRecorder.Record(c, utils.CollNameDiscount, id, model.AuditOperationDelete, before, nil)
+
 	return utils.Negotiate(c, http.StatusNoContent, nil)
 }
+
+func (discountController *DiscountController) GetDeletedDiscounts(c echo.Context) error {
+	discounts, err := discountController.discountRepository.GetDeletedDiscounts(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, discounts)
+}
+
+func (discountController *DiscountController) RestoreDiscount(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := discountController.discountRepository.RestoreDiscount(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorTitleExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	after, _ := discountController.discountRepository.GetDiscount(c.Request().Context(), id)
+
+	discountController.auditRecorder.Record(c, utils.CollNameDiscount, id, model.AuditOperationRestore, nil, after)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (discountController *DiscountController) BulkCreateDiscounts(c echo.Context) error {
+	var payload dto.BulkCreateDiscounts
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, discountController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.CreateDiscount, model.Discount]{
+		entityType: utils.CollNameDiscount,
+		operation:  model.AuditOperationCreate,
+		applied:    model.BulkStatusCreated,
+		title:      func(item *dto.CreateDiscount) string { return item.Title },
+		exists: func(ctx context.Context, title string) bool {
+			_, err := discountController.discountRepository.GetDiscountByTitle(ctx, title)
+			return err == nil
+		},
+		prepare: func(ctx context.Context, item *dto.CreateDiscount) (*model.Discount, *model.Discount, error) {
+			discount := item.ToModel()
+			if !discount.ValidWindow() {
+				return nil, nil, utils.ErrorDiscountWindow
+			}
+
+			return discount, nil, nil
+		},
+		write:    discountController.discountRepository.BulkCreateDiscounts,
+		identify: func(discount *model.Discount, id string) { discount.ID = id },
+	})
+}
+
+func (discountController *DiscountController) BulkUpdateDiscounts(c echo.Context) error {
+	var payload dto.BulkUpdateDiscounts
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, discountController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.BulkUpdateDiscount, model.Discount]{
+		entityType: utils.CollNameDiscount,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		prepare: func(ctx context.Context, item *dto.BulkUpdateDiscount) (*model.Discount, *model.Discount, error) {
+			discount := item.ToModel()
+			discount.ID = item.ID
+
+			if !discount.ValidWindow() {
+				return nil, nil, utils.ErrorDiscountWindow
+			}
+
+			before, _ := discountController.discountRepository.GetDiscount(ctx, item.ID)
+
+			return discount, before, nil
+		},
+		write: discountController.discountRepository.BulkUpdateDiscounts,
+	})
+}
+
+func (discountController *DiscountController) BulkDeleteDiscounts(c echo.Context) error {
+	var payload dto.BulkDelete
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, discountController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Discount]{
+		entityType: utils.CollNameDiscount,
+		operation:  model.AuditOperationDelete,
+		applied:    model.BulkStatusDeleted,
+		get:        discountController.discountRepository.GetDiscount,
+		write:      discountController.discountRepository.BulkDeleteDiscounts,
+	})
+}
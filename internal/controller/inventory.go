@@ -0,0 +1,271 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/inventory"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultMovementLimit = 100
+	maxMovementLimit     = 1000
+)
+
+type InventoryControllerDeps struct {
+	InventoryRepository  repository.InventoryRepository
+	ProductRepository    repository.ProductRepository
+	WarehouseRepository  repository.WarehouseRepository
+	StockAlertRepository repository.StockAlertRepository
+	AllocationStrategy   string
+	AuditRecorder        *audit.Recorder
+}
+
+type InventoryController struct {
+	inventoryRepository  repository.InventoryRepository
+	productRepository    repository.ProductRepository
+	warehouseRepository  repository.WarehouseRepository
+	stockAlertRepository repository.StockAlertRepository
+	allocationStrategy   string
+	auditRecorder        *audit.Recorder
+}
+
+func NewInventoryController(deps *InventoryControllerDeps) *InventoryController {
+	return &InventoryController{
+		inventoryRepository:  deps.InventoryRepository,
+		productRepository:    deps.ProductRepository,
+		warehouseRepository:  deps.WarehouseRepository,
+		stockAlertRepository: deps.StockAlertRepository,
+		allocationStrategy:   deps.AllocationStrategy,
+		auditRecorder:        deps.AuditRecorder,
+	}
+}
+
+func (inventoryController *InventoryController) PostStockMovement(c echo.Context) error {
+	var payload dto.PostStockMovement
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	movement := payload.ToModel()
+	movement.Actor = utils.GetActor(c)
+
+	if !movement.Valid() {
+		return utils.Negotiate(c, http.StatusBadRequest, "warehouse is required and quantity must be positive for receipts, sales and returns and non-zero otherwise")
+	}
+
+	if _, err := inventoryController.warehouseRepository.GetWarehouse(c.Request().Context(), movement.WarehouseID); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	level, err := inventoryController.inventoryRepository.PostStockMovement(c.Request().Context(), movement)
+	if errors.Is(err, utils.ErrorInsufficientStock) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if errors.Is(err, utils.ErrorVariantNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	inventoryController.auditRecorder.Record(c, utils.CollNameStockMovement, movement.ID, model.AuditOperationCreate, nil, movement)
+
+	return utils.Negotiate(c, http.StatusCreated, level)
+}
+
+func (inventoryController *InventoryController) TransferStock(c echo.Context) error {
+	var payload dto.TransferStock
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	movement := payload.ToModel()
+	movement.Actor = utils.GetActor(c)
+
+	for _, warehouseID := range []string{movement.WarehouseID, movement.TargetWarehouseID} {
+		if _, err := inventoryController.warehouseRepository.GetWarehouse(c.Request().Context(), warehouseID); err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+		}
+	}
+
+	level, err := inventoryController.inventoryRepository.PostStockMovement(c.Request().Context(), movement)
+	if errors.Is(err, utils.ErrorInsufficientStock) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	inventoryController.auditRecorder.Record(c, utils.CollNameStockMovement, movement.ID, model.AuditOperationCreate, nil, movement)
+
+	return utils.Negotiate(c, http.StatusCreated, level)
+}
+
+func (inventoryController *InventoryController) ReserveStock(c echo.Context) error {
+	var payload dto.ReserveStock
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	strategy := payload.Strategy
+	if strategy == utils.EmptyString {
+		strategy = inventoryController.allocationStrategy
+	}
+
+	warehouses, err := inventoryController.warehouseRepository.GetAllWarehouses(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	stocks, err := inventoryController.inventoryRepository.GetWarehouseStocks(c.Request().Context(), []string{payload.ProductID})
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	allocations, err := inventory.Allocate(strategy, payload.Origin, payload.Quantity, *warehouses, stocks)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	actor := utils.GetActor(c)
+	movements := make([]*model.StockMovement, 0, len(allocations))
+
+	for _, allocation := range allocations {
+		movements = append(movements, &model.StockMovement{
+			ProductID:   payload.ProductID,
+			SKU:         payload.SKU,
+			Type:        model.StockMovementReservation,
+			Quantity:    allocation.Quantity,
+			Reason:      "allocated with " + strategy + " strategy",
+			OrderID:     payload.OrderID,
+			WarehouseID: allocation.WarehouseID,
+			Actor:       actor,
+		})
+	}
+
+	level, err := inventoryController.inventoryRepository.PostStockMovements(c.Request().Context(), movements)
+	if errors.Is(err, utils.ErrorInsufficientStock) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if errors.Is(err, utils.ErrorVariantNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	entries := make([]model.AuditEntry, 0, len(movements))
+	for _, movement := range movements {
+		entries = append(entries, inventoryController.auditRecorder.Entry(c, utils.CollNameStockMovement, movement.ID, model.AuditOperationCreate, nil, movement))
+	}
+
+	inventoryController.auditRecorder.Save(c, entries...)
+
+	return utils.Negotiate(c, http.StatusCreated, &model.Reservation{Level: level, Allocations: allocations})
+}
+
+func (inventoryController *InventoryController) GetWarehouseStocks(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	if !inventoryController.isPublished(c, id) {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	stocks, err := inventoryController.inventoryRepository.GetWarehouseStocks(c.Request().Context(), []string{id})
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, stocks)
+}
+
+func (inventoryController *InventoryController) GetStockLevel(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	if !inventoryController.isPublished(c, id) {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	level, err := inventoryController.inventoryRepository.GetStockLevel(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, level)
+}
+
+func (inventoryController *InventoryController) GetStockMovements(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	limit := int64(defaultMovementLimit)
+
+	if value := c.QueryParam("limit"); value != utils.EmptyString {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 || parsed > maxMovementLimit {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		limit = parsed
+	}
+
+	if !inventoryController.isPublished(c, id) {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	movements, err := inventoryController.inventoryRepository.GetStockMovements(c.Request().Context(), id, limit)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, movements)
+}
+
+func (inventoryController *InventoryController) GetLowStockProducts(c echo.Context) error {
+	items, err := inventoryController.inventoryRepository.GetLowStockProducts(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, items)
+}
+
+func (inventoryController *InventoryController) GetStockAlerts(c echo.Context) error {
+	alerts, err := inventoryController.stockAlertRepository.GetOpenStockAlerts(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, alerts)
+}
+
+func (inventoryController *InventoryController) isPublished(c echo.Context, id string) bool {
+	product, err := inventoryController.productRepository.GetProduct(c.Request().Context(), id)
+
+	return err == nil && product.IsPublished()
+}
@@ -0,0 +1,21 @@
+package controller
+
+import (
+	"github.com/Meystergod/online-store/internal/i18n"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+func translationLocale(c echo.Context, localizer *i18n.Localizer) (string, error) {
+	locale, err := localizer.Normalize(c.Param("locale"))
+	if err != nil {
+		return utils.EmptyString, err
+	}
+
+	if locale == localizer.Default() {
+		return utils.EmptyString, utils.ErrorDefaultLocale
+	}
+
+	return locale, nil
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type OrderController struct {
+	orderRepository repository.OrderRepository
+}
+
+func NewOrderController(orderRepository repository.OrderRepository) *OrderController {
+	return &OrderController{orderRepository: orderRepository}
+}
+
+func (orderController *OrderController) GetOrder(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	order, err := orderController.orderRepository.GetOrder(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorOrderNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, order)
+}
+
+func (orderController *OrderController) GetCustomerOrders(c echo.Context) error {
+	customerID := c.QueryParam("customer")
+	if customerID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	orders, err := orderController.orderRepository.GetOrdersByCustomer(c.Request().Context(), customerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, orders)
+}
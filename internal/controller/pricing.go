@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/pricing"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+type PricingController struct {
+	pricingRuleRepository repository.PricingRuleRepository
+	productRepository     repository.ProductRepository
+	pricingEngine         *pricing.Engine
+	auditRecorder         *audit.Recorder
+}
+
+func NewPricingController(pricingRuleRepository repository.PricingRuleRepository, productRepository repository.ProductRepository, pricingEngine *pricing.Engine, auditRecorder *audit.Recorder) *PricingController {
+	return &PricingController{
+		pricingRuleRepository: pricingRuleRepository,
+		productRepository:     productRepository,
+		pricingEngine:         pricingEngine,
+		auditRecorder:         auditRecorder,
+	}
+}
+
+func (pricingController *PricingController) CreatePricingRule(c echo.Context) error {
+	var payload dto.CreatePricingRule
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	rule := payload.ToModel()
+
+	createdRuleID, err := pricingController.pricingRuleRepository.CreatePricingRule(c.Request().Context(), rule)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	rule.ID = createdRuleID
+	pricingController.auditRecorder.Record(c, utils.CollNamePricingRule, createdRuleID, model.AuditOperationCreate, nil, rule)
+
+	return utils.Negotiate(c, http.StatusCreated, createdRuleID)
+}
+
+func (pricingController *PricingController) GetAllPricingRules(c echo.Context) error {
+	rules, err := pricingController.pricingRuleRepository.GetAllPricingRules(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, rules)
+}
+
+func (pricingController *PricingController) GetPricingRule(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	rule, err := pricingController.pricingRuleRepository.GetPricingRule(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, rule)
+}
+
+func (pricingController *PricingController) UpdatePricingRule(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdatePricingRule
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := pricingController.pricingRuleRepository.GetPricingRule(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	rule := payload.ToModel()
+	rule.ID = id
+	rule.CreatedAt = before.CreatedAt
+
+	err = pricingController.pricingRuleRepository.UpdatePricingRule(c.Request().Context(), rule)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	pricingController.auditRecorder.Record(c, utils.CollNamePricingRule, id, model.AuditOperationUpdate, before, rule)
+
+	return utils.Negotiate(c, http.StatusOK, rule)
+}
+
+func (pricingController *PricingController) DeletePricingRule(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := pricingController.pricingRuleRepository.GetPricingRule(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = pricingController.pricingRuleRepository.DeletePricingRule(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	pricingController.auditRecorder.Record(c, utils.CollNamePricingRule, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (pricingController *PricingController) Quote(c echo.Context) error {
+	var payload dto.PricingQuote
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	rate, err := pricingController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	products := make([]model.Product, 0, len(payload.Items))
+	quantities := make([]int, 0, len(payload.Items))
+
+	for _, item := range payload.Items {
+		product, err := pricingController.productRepository.GetProduct(c.Request().Context(), item.ProductID)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusNotFound, err.Error())
+		}
+
+		quantity := item.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+
+		products = append(products, *product)
+		quantities = append(quantities, quantity)
+	}
+
+	quotes, err := pricingController.pricingEngine.QuoteProducts(c.Request().Context(), products, quantities, rate)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	for _, item := range quotes {
+		if item.Error != utils.EmptyString {
+			return utils.Negotiate(c, http.StatusUnprocessableEntity, item)
+		}
+	}
+
+	quote := model.OrderQuote{Currency: rate.Currency, Items: quotes}
+	for _, item := range quotes {
+		quote.Total += item.Total
+	}
+
+	quote.Total = rate.Round(quote.Total)
+
+	return utils.Negotiate(c, http.StatusOK, quote)
+}
@@ -1,21 +1,77 @@
 package controller
 
 import (
+	"context"
+	"io"
+	"mime/multipart"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/i18n"
+	"github.com/Meystergod/online-store/internal/media"
+	"github.com/Meystergod/online-store/internal/pricing"
+	"github.com/Meystergod/online-store/internal/recommendation"
 	"github.com/Meystergod/online-store/internal/repository"
 	"github.com/Meystergod/online-store/internal/utils"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
 )
 
+const (
+	maxRelatedLimit   = 50
+	defaultFacetLimit = 20
+	maxFacetLimit     = 100
+)
+
+type ProductControllerDeps struct {
+	ProductRepository        repository.ProductRepository
+	CategoryRepository       repository.CategoryRepository
+	SubcategoryRepository    repository.SubcategoryRepository
+	TagRepository            repository.TagRepository
+	PricingEngine            *pricing.Engine
+	MediaService             *media.Service
+	InventoryRepository      repository.InventoryRepository
+	RecommendationRepository repository.RecommendationRepository
+	RecommendationService    *recommendation.Service
+	Localizer                *i18n.Localizer
+	AuditRecorder            *audit.Recorder
+}
+
 type ProductController struct {
-	productRepository repository.ProductRepository
+	productRepository        repository.ProductRepository
+	categoryRepository       repository.CategoryRepository
+	subcategoryRepository    repository.SubcategoryRepository
+	tagRepository            repository.TagRepository
+	pricingEngine            *pricing.Engine
+	mediaService             *media.Service
+	inventoryRepository      repository.InventoryRepository
+	recommendationRepository repository.RecommendationRepository
+	recommendationService    *recommendation.Service
+	localizer                *i18n.Localizer
+	auditRecorder            *audit.Recorder
 }
 
-func NewProductController(productRepository repository.ProductRepository) *ProductController {
-	return &ProductController{productRepository: productRepository}
+func NewProductController(deps *ProductControllerDeps) *ProductController {
+	return &ProductController{
+		productRepository:        deps.ProductRepository,
+		categoryRepository:       deps.CategoryRepository,
+		subcategoryRepository:    deps.SubcategoryRepository,
+		tagRepository:            deps.TagRepository,
+		pricingEngine:            deps.PricingEngine,
+		mediaService:             deps.MediaService,
+		inventoryRepository:      deps.InventoryRepository,
+		recommendationRepository: deps.RecommendationRepository,
+		recommendationService:    deps.RecommendationService,
+		localizer:                deps.Localizer,
+		auditRecorder:            deps.AuditRecorder,
+	}
 }
 
 func (productController *ProductController) CreateProduct(c echo.Context) error {
@@ -25,28 +81,136 @@ func (productController *ProductController) CreateProduct(c echo.Context) error
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
-	_, err := productController.productRepository.GetProductByTitle(c.Request().Context(), payload.Title)
+	_, err := productController.productRepository.GetProductByTitle(c.Request().Context(), payload.Title, utils.EmptyString)
 	if err == nil {
 		return utils.Negotiate(c, http.StatusConflict, "product with this title is exist")
 	}
 
-	createdProductID, err := productController.productRepository.CreateProduct(c.Request().Context(), payload.ToModel())
+	product := payload.ToModel()
+
+	if err = productController.validateProduct(c.Request().Context(), product); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err = product.ValidateVariants(); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if productController.skuTaken(c.Request().Context(), utils.EmptyString, product.SKUs()) {
+		return utils.Negotiate(c, http.StatusConflict, utils.ErrorSKUExists.Error())
+	}
+
+	if !product.ValidSchedule() {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorPublicationSchedule.Error())
+	}
+
+	createdProductID, err := productController.productRepository.CreateProduct(c.Request().Context(), product)
+	if errors.Is(err, utils.ErrorSKUExists) || errors.Is(err, utils.ErrorSlugExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	product.ID = createdProductID
+	productController.auditRecorder.Record(c, utils.CollNameProduct, createdProductID, model.AuditOperationCreate, nil, product)
+
 	return utils.Negotiate(c, http.StatusCreated, createdProductID)
 }
 
 func (productController *ProductController) GetAllProducts(c echo.Context) error {
-	products, err := productController.productRepository.GetAllProducts(c.Request().Context())
+	filter, err := productFilter(c)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter.Statuses = []string{model.ProductStatusPublished}
+
+	return productController.listProducts(c, filter)
+}
+
+func (productController *ProductController) GetAdminProducts(c echo.Context) error {
+	filter, err := productFilter(c)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter.Statuses, err = productStatuses(c)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	return productController.listProducts(c, filter)
+}
+
+func (productController *ProductController) listProducts(c echo.Context, filter model.ProductFilter) error {
+	rate, err := productController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	products, err := productController.productRepository.GetAllProducts(c.Request().Context(), filter)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	productController.priceProducts(c, *products, rate)
+	productController.attachAvailability(c, *products)
+	productController.localizeProducts(c, *products)
+
 	return utils.Negotiate(c, http.StatusOK, products)
 }
 
+func (productController *ProductController) GetProductFacets(c echo.Context) error {
+	filter, err := productFilter(c)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	boundaries, err := priceBoundaries(c)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	filter.Statuses = []string{model.ProductStatusPublished}
+	filter.Limit = defaultFacetLimit
+
+	if value := c.QueryParam("limit"); value != utils.EmptyString {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed <= 0 || parsed > maxFacetLimit {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		filter.Limit = parsed
+	}
+
+	if value := c.QueryParam("offset"); value != utils.EmptyString {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 0 {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		filter.Offset = parsed
+	}
+
+	rate, err := productController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	facets, err := productController.productRepository.GetProductFacets(c.Request().Context(), filter, boundaries)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.priceProducts(c, facets.Products, rate)
+	productController.attachAvailability(c, facets.Products)
+	productController.localizeProducts(c, facets.Products)
+
+	return utils.Negotiate(c, http.StatusOK, facets)
+}
+
 func (productController *ProductController) GetProduct(c echo.Context) error {
 	id := c.Param("id")
 	if id == "" {
@@ -58,6 +222,64 @@ func (productController *ProductController) GetProduct(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	return productController.respondProduct(c, product)
+}
+
+func (productController *ProductController) GetAdminProduct(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return productController.respondProduct(c, product)
+}
+
+func (productController *ProductController) GetProductBySlug(c echo.Context) error {
+	slug := c.Param("slug")
+	if slug == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProductBySlug(c.Request().Context(), slug)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	if product.Slug != slug {
+		return slugRedirect(c, product.Slug)
+	}
+
+	return productController.respondProduct(c, product)
+}
+
+func (productController *ProductController) respondProduct(c echo.Context, product *model.Product) error {
+	rate, err := productController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	priced := []model.Product{*product}
+	productController.priceProducts(c, priced, rate)
+	product.Pricing = priced[0].Pricing
+
+	productController.attachAvailability(c, priced)
+	product.Availability = priced[0].Availability
+
+	product.Localize(productController.localizer.Chain(c))
+
 	return utils.Negotiate(c, http.StatusOK, product)
 }
 
@@ -73,14 +295,84 @@ func (productController *ProductController) UpdateProduct(c echo.Context) error
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
+	before, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
 	product := payload.ToModel()
 	product.ID = id
+	product.Quantity, product.Reserved = before.Quantity, before.Reserved
+	product.Rating = before.Rating
+
+	if err = productController.validateProduct(c.Request().Context(), product); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if err = reshapeVariants(before, product); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if productController.skuTaken(c.Request().Context(), id, product.SKUs()) {
+		return utils.Negotiate(c, http.StatusConflict, utils.ErrorSKUExists.Error())
+	}
+
+	err = productController.productRepository.UpdateProduct(c.Request().Context(), product)
+	if errors.Is(err, utils.ErrorSKUExists) || errors.Is(err, utils.ErrorSlugExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, product)
+
+	return utils.Negotiate(c, http.StatusOK, product)
+}
+
+func (productController *ProductController) SetProductStatus(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.SetProductStatus
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	from := before.PublicationStatus()
+	if payload.Status != from && !before.CanTransit(payload.Status) {
+		return utils.Negotiate(c, http.StatusConflict, utils.ErrorProductStatus.Error())
+	}
+
+	product := *before
+	product.Status = payload.Status
+	product.PublishAt = payload.PublishAt
+	product.UnpublishAt = payload.UnpublishAt
+
+	if !product.ValidSchedule() {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorPublicationSchedule.Error())
+	}
+
+	err = productController.productRepository.SetProductStatus(c.Request().Context(), &product, from)
+	if errors.Is(err, utils.ErrorProductStatus) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
 
-	err := productController.productRepository.UpdateProduct(c.Request().Context(), product)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, product)
+
 	return utils.Negotiate(c, http.StatusOK, product)
 }
 
@@ -90,10 +382,1120 @@ func (productController *ProductController) DeleteProduct(c echo.Context) error
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	err := productController.productRepository.DeleteProduct(c.Request().Context(), id)
+	before, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = productController.productRepository.DeleteProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (productController *ProductController) GetDeletedProducts(c echo.Context) error {
+	products, err := productController.productRepository.GetDeletedProducts(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, products)
+}
+
+func (productController *ProductController) RestoreProduct(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := productController.productRepository.RestoreProduct(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorTitleExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	after, _ := productController.productRepository.GetProduct(c.Request().Context(), id)
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationRestore, nil, after)
+
 	return utils.Negotiate(c, http.StatusNoContent, nil)
 }
+
+func (productController *ProductController) BulkCreateProducts(c echo.Context) error {
+	var payload dto.BulkCreateProducts
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, productController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.CreateProduct, model.Product]{
+		entityType: utils.CollNameProduct,
+		operation:  model.AuditOperationCreate,
+		applied:    model.BulkStatusCreated,
+		title:      func(item *dto.CreateProduct) string { return item.Title },
+		exists: func(ctx context.Context, title string) bool {
+			_, err := productController.productRepository.GetProductByTitle(ctx, title, utils.EmptyString)
+			return err == nil
+		},
+		prepare: func(ctx context.Context, item *dto.CreateProduct) (*model.Product, *model.Product, error) {
+			product := item.ToModel()
+
+			if err := productController.validateProduct(ctx, product); err != nil {
+				return nil, nil, err
+			}
+
+			if err := product.ValidateVariants(); err != nil {
+				return nil, nil, err
+			}
+
+			if productController.skuTaken(ctx, utils.EmptyString, product.SKUs()) {
+				return nil, nil, utils.ErrorSKUExists
+			}
+
+			if !product.ValidSchedule() {
+				return nil, nil, utils.ErrorPublicationSchedule
+			}
+
+			return product, nil, nil
+		},
+		write:    productController.productRepository.BulkCreateProducts,
+		identify: func(product *model.Product, id string) { product.ID = id },
+	})
+}
+
+func (productController *ProductController) BulkUpdateProducts(c echo.Context) error {
+	var payload dto.BulkUpdateProducts
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, productController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.BulkUpdateProduct, model.Product]{
+		entityType: utils.CollNameProduct,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		prepare: func(ctx context.Context, item *dto.BulkUpdateProduct) (*model.Product, *model.Product, error) {
+			product := item.ToModel()
+			product.ID = item.ID
+
+			if err := productController.validateProduct(ctx, product); err != nil {
+				return nil, nil, err
+			}
+
+			before, _ := productController.productRepository.GetProduct(ctx, item.ID)
+			if before != nil {
+				product.Quantity, product.Reserved = before.Quantity, before.Reserved
+				product.Rating = before.Rating
+
+				if err := reshapeVariants(before, product); err != nil {
+					return nil, nil, err
+				}
+			}
+
+			if productController.skuTaken(ctx, item.ID, product.SKUs()) {
+				return nil, nil, utils.ErrorSKUExists
+			}
+
+			return product, before, nil
+		},
+		write: productController.productRepository.BulkUpdateProducts,
+	})
+}
+
+func (productController *ProductController) BulkDeleteProducts(c echo.Context) error {
+	var payload dto.BulkDelete
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, productController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Product]{
+		entityType: utils.CollNameProduct,
+		operation:  model.AuditOperationDelete,
+		applied:    model.BulkStatusDeleted,
+		get:        productController.productRepository.GetProduct,
+		write:      productController.productRepository.BulkDeleteProducts,
+	})
+}
+
+func (productController *ProductController) BulkAddProductTags(c echo.Context) error {
+	var payload dto.BulkProductTags
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	tags := make([]model.Tag, 0, len(payload.TagIDs))
+	for _, tagID := range payload.TagIDs {
+		tag, err := productController.tagRepository.GetTag(c.Request().Context(), tagID)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, "tag is not found")
+		}
+
+		tag.Usage = nil
+		tags = append(tags, *tag)
+	}
+
+	return runBulkIDs(c, productController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Product]{
+		entityType: utils.CollNameProduct,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		get:        productController.productRepository.GetProduct,
+		write: func(ctx context.Context, ids []string, ordered bool) ([]model.BulkResult, error) {
+			return productController.productRepository.BulkAddProductTags(ctx, ids, tags, ordered)
+		},
+		after: func(before *model.Product) interface{} {
+			after := *before
+			after.Tags = append([]model.Tag(nil), before.Tags...)
+			after.AddTags(tags)
+
+			return after
+		},
+	})
+}
+
+func (productController *ProductController) BulkRemoveProductTags(c echo.Context) error {
+	var payload dto.BulkProductTags
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, productController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Product]{
+		entityType: utils.CollNameProduct,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		get:        productController.productRepository.GetProduct,
+		write: func(ctx context.Context, ids []string, ordered bool) ([]model.BulkResult, error) {
+			return productController.productRepository.BulkRemoveProductTags(ctx, ids, payload.TagIDs, ordered)
+		},
+		after: func(before *model.Product) interface{} {
+			after := *before
+			after.RemoveTags(payload.TagIDs)
+
+			return after
+		},
+	})
+}
+
+func (productController *ProductController) GetRelatedProducts(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	limit := 0
+
+	if value := c.QueryParam("limit"); value != utils.EmptyString {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxRelatedLimit {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		limit = parsed
+	}
+
+	rate, err := productController.pricingEngine.Rate(c.Request().Context(), c.QueryParam("currency"))
+	if err != nil {
+		return utils.Negotiate(c, currencyStatus(err), err.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	related, err := productController.recommendationService.Related(c.Request().Context(), product, limit)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	for _, products := range related.All() {
+		productController.priceProducts(c, products, rate)
+		productController.attachAvailability(c, products)
+		productController.localizeProducts(c, products)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, related)
+}
+
+func (productController *ProductController) GetRelatedOverride(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	override, err := productController.recommendationRepository.GetRelatedOverride(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, override)
+}
+
+func (productController *ProductController) SetRelatedOverride(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.SetRelatedOverride
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	if _, err := productController.productRepository.GetProduct(c.Request().Context(), id); err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	for _, pinned := range payload.Pinned {
+		if pinned == id {
+			return utils.Negotiate(c, http.StatusBadRequest, "product cannot be related to itself")
+		}
+	}
+
+	pinned, err := productController.productRepository.GetProductsByIDs(c.Request().Context(), payload.Pinned)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if len(*pinned) != len(payload.Pinned) {
+		return utils.Negotiate(c, http.StatusBadRequest, "pinned product is not found")
+	}
+
+	before, err := productController.recommendationRepository.GetRelatedOverride(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	override := payload.ToModel()
+	override.ProductID = id
+
+	if err = productController.recommendationRepository.SaveRelatedOverride(c.Request().Context(), override); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if before.UpdatedAt.IsZero() {
+		productController.auditRecorder.Record(c, utils.CollNameRelatedOverride, id, model.AuditOperationCreate, nil, override)
+	} else {
+		productController.auditRecorder.Record(c, utils.CollNameRelatedOverride, id, model.AuditOperationUpdate, before, override)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, override)
+}
+
+func (productController *ProductController) DeleteRelatedOverride(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := productController.recommendationRepository.GetRelatedOverride(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if before.UpdatedAt.IsZero() {
+		return utils.Negotiate(c, http.StatusNotFound, "related override is not found")
+	}
+
+	if err = productController.recommendationRepository.DeleteRelatedOverride(c.Request().Context(), id); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameRelatedOverride, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (productController *ProductController) GetProductBreadcrumbs(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	chain := productController.localizer.Chain(c)
+	breadcrumbs := make([]model.Breadcrumb, 0)
+
+	if product.Category.ID != utils.EmptyString {
+		breadcrumbs, err = categoryBreadcrumbs(c, productController.categoryRepository, product.Category.ID, chain)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if product.Subcategory.ID != utils.EmptyString {
+		product.Subcategory.Localize(chain)
+		breadcrumbs = append(breadcrumbs, model.Breadcrumb{ID: product.Subcategory.ID, Title: product.Subcategory.Title, Type: model.BreadcrumbSubcategory})
+	}
+
+	return utils.Negotiate(c, http.StatusOK, breadcrumbs)
+}
+
+func (productController *ProductController) GetProductBySKU(c echo.Context) error {
+	sku := c.Param("sku")
+	if sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProductBySKU(c.Request().Context(), sku)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	product.Localize(productController.localizer.Chain(c))
+
+	return utils.Negotiate(c, http.StatusOK, product)
+}
+
+func (productController *ProductController) GetProductVariants(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	variants := product.Variants
+	if variants == nil {
+		variants = []model.Variant{}
+	}
+
+	return utils.Negotiate(c, http.StatusOK, variants)
+}
+
+func (productController *ProductController) GetProductVariant(c echo.Context) error {
+	id := c.Param("id")
+	sku := c.Param("sku")
+	if id == "" || sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	variant, ok := product.Variant(sku)
+	if !ok {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorVariantNotFound.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, variant)
+}
+
+func (productController *ProductController) CreateProductVariant(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.CreateVariant
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	variant := payload.ToModel()
+
+	if err = product.ValidateVariant(variant, utils.EmptyString); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if productController.skuTaken(c.Request().Context(), id, []string{variant.SKU}) {
+		return utils.Negotiate(c, http.StatusConflict, utils.ErrorSKUExists.Error())
+	}
+
+	err = productController.productRepository.AddProductVariant(c.Request().Context(), id, variant)
+	if errors.Is(err, utils.ErrorSKUExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, nil, variant)
+
+	return utils.Negotiate(c, http.StatusCreated, variant)
+}
+
+func (productController *ProductController) UpdateProductVariant(c echo.Context) error {
+	id := c.Param("id")
+	sku := c.Param("sku")
+	if id == "" || sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateVariant
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	before, ok := product.Variant(sku)
+	if !ok {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorVariantNotFound.Error())
+	}
+
+	variant := payload.ToModel()
+	variant.SKU = sku
+	variant.Quantity = before.Quantity
+	variant.Reserved = before.Reserved
+
+	if err = product.ValidateVariant(variant, sku); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	err = productController.productRepository.UpdateProductVariant(c.Request().Context(), id, sku, variant)
+	if errors.Is(err, utils.ErrorVariantNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, variant)
+
+	return utils.Negotiate(c, http.StatusOK, variant)
+}
+
+func (productController *ProductController) DeleteProductVariant(c echo.Context) error {
+	id := c.Param("id")
+	sku := c.Param("sku")
+	if id == "" || sku == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	before, ok := product.Variant(sku)
+	if !ok {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorVariantNotFound.Error())
+	}
+
+	if before.Quantity != 0 {
+		return utils.Negotiate(c, http.StatusConflict, "variant has stock, post an adjustment before deleting it")
+	}
+
+	err = productController.productRepository.DeleteProductVariant(c.Request().Context(), id, sku)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (productController *ProductController) CheckStock(c echo.Context) error {
+	var payload dto.StockCheck
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	checks := make([]model.StockCheck, 0, len(payload.Items))
+
+	for _, item := range payload.Items {
+		check := model.StockCheck{SKU: item.SKU, Requested: item.Quantity}
+
+		product, err := productController.productRepository.GetProductBySKU(c.Request().Context(), item.SKU)
+		if err == nil && product.IsPublished() {
+			if available, ok := product.AvailableSKU(item.SKU); ok {
+				check.ProductID = product.ID
+				check.Available = available
+				check.InStock = available >= item.Quantity
+			}
+		}
+
+		checks = append(checks, check)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, checks)
+}
+
+func (productController *ProductController) GetProductImages(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	images := product.Images
+	if images == nil {
+		images = []model.ProductImage{}
+	}
+
+	return utils.Negotiate(c, http.StatusOK, images)
+}
+
+func (productController *ProductController) UploadProductImages(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil || len(form.File["images"]) == 0 {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	images := make([]model.ProductImage, 0, len(form.File["images"]))
+	position := len(product.Images)
+	if position > 0 {
+		position = product.Images[position-1].Position + 1
+	}
+
+	for _, header := range form.File["images"] {
+		image, err := productController.uploadImage(c, id, header)
+		if err != nil {
+			_ = productController.mediaService.Remove(c.Request().Context(), images...)
+			return utils.Negotiate(c, mediaStatus(err), err.Error())
+		}
+
+		image.Position = position
+		position++
+
+		images = append(images, *image)
+	}
+
+	err = productController.productRepository.AddProductImages(c.Request().Context(), id, images)
+	if err != nil {
+		_ = productController.mediaService.Remove(c.Request().Context(), images...)
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, nil, images)
+
+	return utils.Negotiate(c, http.StatusCreated, images)
+}
+
+func (productController *ProductController) ReorderProductImages(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.ReorderImages
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	byID := make(map[string]model.ProductImage, len(product.Images))
+	for _, image := range product.Images {
+		byID[image.ID] = image
+	}
+
+	if len(payload.IDs) != len(byID) {
+		return utils.Negotiate(c, http.StatusBadRequest, "order must list every product image exactly once")
+	}
+
+	images := make([]model.ProductImage, 0, len(payload.IDs))
+	for position, imageID := range payload.IDs {
+		image, ok := byID[imageID]
+		if !ok {
+			return utils.Negotiate(c, http.StatusBadRequest, "order must list every product image exactly once")
+		}
+
+		delete(byID, imageID)
+
+		image.Position = position
+		images = append(images, image)
+	}
+
+	err = productController.productRepository.SetProductImages(c.Request().Context(), id, images)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, product.Images, images)
+
+	return utils.Negotiate(c, http.StatusOK, images)
+}
+
+func (productController *ProductController) DeleteProductImage(c echo.Context) error {
+	id := c.Param("id")
+	imageID := c.Param("image_id")
+	if id == "" || imageID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	var before *model.ProductImage
+	for i := range product.Images {
+		if product.Images[i].ID == imageID {
+			before = &product.Images[i]
+		}
+	}
+
+	if before == nil {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorImageNotFound.Error())
+	}
+
+	err = productController.productRepository.DeleteProductImage(c.Request().Context(), id, imageID)
+	if errors.Is(err, utils.ErrorImageNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if err = productController.mediaService.Remove(c.Request().Context(), *before); err != nil {
+		zerolog.Ctx(c.Request().Context()).Error().Err(err).Str("image", imageID).Msg("remove product image blobs")
+	}
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (productController *ProductController) SetProductTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, productController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	var payload dto.SetTranslation
+
+	if err = utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	existing, err := productController.productRepository.GetProductByTitle(c.Request().Context(), payload.Title, locale)
+	if err == nil && existing.ID != id {
+		return utils.Negotiate(c, http.StatusConflict, "product with this title is exist")
+	}
+
+	translation := payload.ToModel()
+
+	err = productController.productRepository.SetProductTranslation(c.Request().Context(), id, locale, translation)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	product := *before
+	product.Translations = model.WithTranslation(before.Translations, locale, translation)
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, product)
+
+	return utils.Negotiate(c, http.StatusOK, product)
+}
+
+func (productController *ProductController) DeleteProductTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, productController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	before, err := productController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if _, ok := before.Translations[locale]; !ok {
+		return utils.Negotiate(c, http.StatusNotFound, "translation is not found")
+	}
+
+	err = productController.productRepository.SetProductTranslation(c.Request().Context(), id, locale, nil)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	product := *before
+	product.Translations = model.WithTranslation(before.Translations, locale, nil)
+
+	productController.auditRecorder.Record(c, utils.CollNameProduct, id, model.AuditOperationUpdate, before, product)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (productController *ProductController) uploadImage(c echo.Context, productID string, header *multipart.FileHeader) (*model.ProductImage, error) {
+	if header.Size > productController.mediaService.MaxSize() {
+		return nil, utils.ErrorMediaSize
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, productController.mediaService.MaxSize()+1))
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return productController.mediaService.Upload(c.Request().Context(), productID, data)
+}
+
+func mediaStatus(err error) int {
+	switch {
+	case errors.Is(err, utils.ErrorMediaSize):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, utils.ErrorMediaType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, utils.ErrorBindAndValidatePayload):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (productController *ProductController) priceProducts(c echo.Context, products []model.Product, rate *model.ExchangeRate) {
+	if len(products) == 0 {
+		return
+	}
+
+	quotes, err := productController.pricingEngine.QuoteProducts(c.Request().Context(), products, nil, rate)
+	if err != nil {
+		zerolog.Ctx(c.Request().Context()).Error().Err(err).Msg("price products")
+		return
+	}
+
+	for i := range quotes {
+		products[i].Pricing = &quotes[i]
+	}
+}
+
+func (productController *ProductController) attachAvailability(c echo.Context, products []model.Product) {
+	if len(products) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	stocks, err := productController.inventoryRepository.GetWarehouseStocks(c.Request().Context(), ids)
+	if err != nil {
+		zerolog.Ctx(c.Request().Context()).Error().Err(err).Msg("attach availability")
+		return
+	}
+
+	byProduct := make(map[string][]model.WarehouseStock, len(products))
+	for _, stock := range stocks {
+		byProduct[stock.ProductID] = append(byProduct[stock.ProductID], stock)
+	}
+
+	for i := range products {
+		products[i].Availability = model.NewAvailability(byProduct[products[i].ID])
+	}
+}
+
+func (productController *ProductController) localizeProducts(c echo.Context, products []model.Product) {
+	chain := productController.localizer.Chain(c)
+
+	for i := range products {
+		products[i].Localize(chain)
+	}
+}
+
+func (productController *ProductController) validateProduct(ctx context.Context, product *model.Product) error {
+	if err := productController.resolveCategory(ctx, product); err != nil {
+		return err
+	}
+
+	if err := productController.resolveSubcategory(ctx, product); err != nil {
+		return err
+	}
+
+	if err := productController.validateAttributes(product); err != nil {
+		return err
+	}
+
+	return productController.pricingEngine.ValidatePrices(product)
+}
+
+func (productController *ProductController) resolveCategory(ctx context.Context, product *model.Product) error {
+	if product.Category.ID == utils.EmptyString {
+		product.Category = model.Category{}
+		return nil
+	}
+
+	category, err := productController.categoryRepository.GetCategory(ctx, product.Category.ID)
+	if err != nil {
+		return errors.Wrap(err, "category is not found")
+	}
+
+	product.Category = *category
+
+	return nil
+}
+
+func (productController *ProductController) resolveSubcategory(ctx context.Context, product *model.Product) error {
+	if product.Subcategory.ID == utils.EmptyString {
+		product.Subcategory = model.Subcategory{}
+		return nil
+	}
+
+	subcategory, err := productController.subcategoryRepository.GetSubcategory(ctx, product.Subcategory.ID)
+	if err != nil {
+		return errors.Wrap(err, "subcategory is not found")
+	}
+
+	if subcategory.CategoryID == utils.EmptyString || subcategory.CategoryID != product.Category.ID {
+		return utils.ErrorSubcategoryMembership
+	}
+
+	product.Subcategory = *subcategory
+
+	return nil
+}
+
+func (productController *ProductController) validateAttributes(product *model.Product) error {
+	if product.Category.ID == utils.EmptyString {
+		if len(product.Attributes) > 0 {
+			return errors.New("attributes require a category")
+		}
+
+		return nil
+	}
+
+	return product.Category.ValidateAttributes(product.Attributes)
+}
+
+func productFilter(c echo.Context) (model.ProductFilter, error) {
+	filter := model.ProductFilter{
+		CategoryID:    c.QueryParam("category"),
+		SubcategoryID: c.QueryParam("subcategory"),
+		TagID:         c.QueryParam("tag"),
+		DiscountID:    c.QueryParam("discount"),
+		Sort:          c.QueryParam("sort"),
+	}
+
+	if filter.Sort != utils.EmptyString && filter.Sort != model.ProductSortRating {
+		return filter, utils.ErrorGetUrlParams
+	}
+
+	for param, bound := range map[string]**float64{"price.min": &filter.MinPrice, "price.max": &filter.MaxPrice} {
+		value := c.QueryParam(param)
+		if value == utils.EmptyString {
+			continue
+		}
+
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return filter, utils.ErrorGetUrlParams
+		}
+
+		*bound = &number
+	}
+
+	attributes := make(map[string]*model.AttributeFilter)
+	names := make([]string, 0)
+
+	for key, values := range c.QueryParams() {
+		name, found := strings.CutPrefix(key, "attr.")
+		if !found {
+			continue
+		}
+
+		bound := utils.EmptyString
+		if trimmed, ok := strings.CutSuffix(name, ".min"); ok {
+			name, bound = trimmed, "min"
+		} else if trimmed, ok = strings.CutSuffix(name, ".max"); ok {
+			name, bound = trimmed, "max"
+		}
+
+		if name == utils.EmptyString || strings.ContainsAny(name, ".$") {
+			return filter, utils.ErrorGetUrlParams
+		}
+
+		attribute, ok := attributes[name]
+		if !ok {
+			attribute = &model.AttributeFilter{Name: name}
+			attributes[name] = attribute
+			names = append(names, name)
+		}
+
+		if bound == utils.EmptyString {
+			for _, value := range values {
+				attribute.Values = append(attribute.Values, strings.Split(value, ",")...)
+			}
+
+			continue
+		}
+
+		number, err := strconv.ParseFloat(values[0], 64)
+		if err != nil {
+			return filter, utils.ErrorGetUrlParams
+		}
+
+		if bound == "min" {
+			attribute.Min = &number
+		} else {
+			attribute.Max = &number
+		}
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		filter.Attributes = append(filter.Attributes, *attributes[name])
+	}
+
+	return filter, nil
+}
+
+func productStatuses(c echo.Context) ([]string, error) {
+	value := c.QueryParam("status")
+	if value == utils.EmptyString {
+		return nil, nil
+	}
+
+	statuses := strings.Split(value, ",")
+
+	for _, status := range statuses {
+		valid := false
+
+		for _, known := range model.ProductStatuses {
+			if status == known {
+				valid = true
+			}
+		}
+
+		if !valid {
+			return nil, utils.ErrorGetUrlParams
+		}
+	}
+
+	return statuses, nil
+}
+
+func priceBoundaries(c echo.Context) ([]float64, error) {
+	value := c.QueryParam("price.buckets")
+	if value == utils.EmptyString {
+		return model.DefaultPriceBoundaries, nil
+	}
+
+	parts := strings.Split(value, ",")
+	if len(parts) < 2 {
+		return nil, utils.ErrorGetUrlParams
+	}
+
+	boundaries := make([]float64, 0, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || (i > 0 && number <= boundaries[i-1]) {
+			return nil, utils.ErrorGetUrlParams
+		}
+
+		boundaries = append(boundaries, number)
+	}
+
+	return boundaries, nil
+}
+
+func (productController *ProductController) skuTaken(ctx context.Context, id string, skus []string) bool {
+	for _, sku := range skus {
+		owner, err := productController.productRepository.GetProductBySKU(ctx, sku)
+		if err == nil && owner.ID != id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func reshapeVariants(before *model.Product, product *model.Product) error {
+	reshaped := *before
+	reshaped.SKU = product.SKU
+
+	if product.Options != nil {
+		reshaped.Options = product.Options
+	}
+
+	return reshaped.ValidateVariants()
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+var reviewStatuses = map[string]bool{
+	model.ReviewPending:  true,
+	model.ReviewApproved: true,
+	model.ReviewRejected: true,
+}
+
+type ReviewControllerDeps struct {
+	ReviewRepository    repository.ReviewRepository
+	ProductRepository   repository.ProductRepository
+	InventoryRepository repository.InventoryRepository
+	AuditRecorder       *audit.Recorder
+}
+
+type ReviewController struct {
+	reviewRepository    repository.ReviewRepository
+	productRepository   repository.ProductRepository
+	inventoryRepository repository.InventoryRepository
+	auditRecorder       *audit.Recorder
+}
+
+func NewReviewController(deps *ReviewControllerDeps) *ReviewController {
+	return &ReviewController{
+		reviewRepository:    deps.ReviewRepository,
+		productRepository:   deps.ProductRepository,
+		inventoryRepository: deps.InventoryRepository,
+		auditRecorder:       deps.AuditRecorder,
+	}
+}
+
+func (reviewController *ReviewController) CreateReview(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.CreateReview
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := reviewController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil || !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	review := payload.ToModel()
+	review.ProductID = id
+
+	if review.OrderID != utils.EmptyString {
+		verified, err := reviewController.inventoryRepository.HasOrderSale(c.Request().Context(), id, review.OrderID)
+		if err != nil {
+			return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+		}
+
+		review.VerifiedPurchase = verified
+	}
+
+	createdReviewID, err := reviewController.reviewRepository.CreateReview(c.Request().Context(), review)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	reviewController.auditRecorder.Record(c, utils.CollNameReview, createdReviewID, model.AuditOperationCreate, nil, review)
+
+	return utils.Negotiate(c, http.StatusCreated, createdReviewID)
+}
+
+func (reviewController *ReviewController) GetProductReviews(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	product, err := reviewController.productRepository.GetProduct(c.Request().Context(), id)
+	if err != nil || !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorProductNotFound.Error())
+	}
+
+	filter := model.ReviewFilter{ProductID: id, Status: model.ReviewApproved}
+
+	reviews, err := reviewController.reviewRepository.GetReviews(c.Request().Context(), filter)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, reviews)
+}
+
+func (reviewController *ReviewController) GetAllReviews(c echo.Context) error {
+	filter := model.ReviewFilter{
+		ProductID: c.QueryParam("product"),
+		Status:    c.QueryParam("status"),
+	}
+
+	if filter.Status != utils.EmptyString && !reviewStatuses[filter.Status] {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	reviews, err := reviewController.reviewRepository.GetReviews(c.Request().Context(), filter)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, reviews)
+}
+
+func (reviewController *ReviewController) GetReview(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	review, err := reviewController.reviewRepository.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, review)
+}
+
+func (reviewController *ReviewController) UpdateReview(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateReview
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := reviewController.reviewRepository.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	review := payload.ToModel()
+	review.ID = id
+
+	err = reviewController.reviewRepository.UpdateReview(c.Request().Context(), review)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	after, err := reviewController.reviewRepository.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	reviewController.auditRecorder.Record(c, utils.CollNameReview, id, model.AuditOperationUpdate, before, after)
+
+	return utils.Negotiate(c, http.StatusOK, after)
+}
+
+func (reviewController *ReviewController) ModerateReview(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.ModerateReview
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := reviewController.reviewRepository.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	now := time.Now().UTC()
+
+	review := *before
+	review.Status = payload.Status
+	review.ModerationNote = payload.Note
+	review.ModeratedBy = utils.GetActor(c)
+	review.ModeratedAt = &now
+
+	err = reviewController.reviewRepository.ModerateReview(c.Request().Context(), &review)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	reviewController.auditRecorder.Record(c, utils.CollNameReview, id, model.AuditOperationUpdate, before, review)
+
+	return utils.Negotiate(c, http.StatusOK, review)
+}
+
+func (reviewController *ReviewController) DeleteReview(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := reviewController.reviewRepository.GetReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = reviewController.reviewRepository.DeleteReview(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	reviewController.auditRecorder.Record(c, utils.CollNameReview, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
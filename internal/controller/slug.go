@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+func slugRedirect(c echo.Context, slug string) error {
+	location := path.Dir(c.Request().URL.Path) + "/" + url.PathEscape(slug)
+	if query := c.Request().URL.RawQuery; query != utils.EmptyString {
+		location += "?" + query
+	}
+
+	return c.Redirect(http.StatusMovedPermanently, location)
+}
@@ -1,21 +1,29 @@
 package controller
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/i18n"
 	"github.com/Meystergod/online-store/internal/repository"
 	"github.com/Meystergod/online-store/internal/utils"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
 )
 
 type SubcategoryController struct {
 	subcategoryRepository repository.SubcategoryRepository
+	categoryRepository    repository.CategoryRepository
+	localizer             *i18n.Localizer
+	auditRecorder         *audit.Recorder
 }
 
-func NewSubcategoryController(subcategoryRepository repository.SubcategoryRepository) *SubcategoryController {
-	return &SubcategoryController{subcategoryRepository: subcategoryRepository}
+func NewSubcategoryController(subcategoryRepository repository.SubcategoryRepository, categoryRepository repository.CategoryRepository, localizer *i18n.Localizer, auditRecorder *audit.Recorder) *SubcategoryController {
+	return &SubcategoryController{subcategoryRepository: subcategoryRepository, categoryRepository: categoryRepository, localizer: localizer, auditRecorder: auditRecorder}
 }
 
 func (subcategoryController *SubcategoryController) CreateSubcategory(c echo.Context) error {
@@ -25,16 +33,31 @@ func (subcategoryController *SubcategoryController) CreateSubcategory(c echo.Con
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
-	_, err := subcategoryController.subcategoryRepository.GetSubcategoryByTitle(c.Request().Context(), payload.Title)
+	return subcategoryController.createSubcategory(c, &payload)
+}
+
+func (subcategoryController *SubcategoryController) createSubcategory(c echo.Context, payload *dto.CreateSubcategory) error {
+	_, err := subcategoryController.subcategoryRepository.GetSubcategoryByTitle(c.Request().Context(), payload.Title, utils.EmptyString)
 	if err == nil {
 		return utils.Negotiate(c, http.StatusConflict, "category with this title is exist")
 	}
 
-	createdSubcategoryID, err := subcategoryController.subcategoryRepository.CreateSubcategory(c.Request().Context(), payload.ToModel())
+	if payload.CategoryID != utils.EmptyString {
+		if _, err = subcategoryController.categoryRepository.GetCategory(c.Request().Context(), payload.CategoryID); err != nil {
+			return utils.Negotiate(c, http.StatusBadRequest, "category is not found")
+		}
+	}
+
+	subcategory := payload.ToModel()
+
+	createdSubcategoryID, err := subcategoryController.subcategoryRepository.CreateSubcategory(c.Request().Context(), subcategory)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	subcategory.ID = createdSubcategoryID
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, createdSubcategoryID, model.AuditOperationCreate, nil, subcategory)
+
 	return utils.Negotiate(c, http.StatusCreated, createdSubcategoryID)
 }
 
@@ -44,6 +67,11 @@ func (subcategoryController *SubcategoryController) GetAllSubcategories(c echo.C
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	chain := subcategoryController.localizer.Chain(c)
+	for i := range *subcategories {
+		(*subcategories)[i].Localize(chain)
+	}
+
 	return utils.Negotiate(c, http.StatusOK, subcategories)
 }
 
@@ -58,6 +86,8 @@ func (subcategoryController *SubcategoryController) GetSubcategory(c echo.Contex
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	category.Localize(subcategoryController.localizer.Chain(c))
+
 	return utils.Negotiate(c, http.StatusOK, category)
 }
 
@@ -67,11 +97,24 @@ func (subcategoryController *SubcategoryController) GetSubcategoryByTitle(c echo
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	subcategory, err := subcategoryController.subcategoryRepository.GetSubcategoryByTitle(c.Request().Context(), title)
+	chain := subcategoryController.localizer.Chain(c)
+
+	var subcategory *model.Subcategory
+	var err error
+
+	for _, locale := range subcategoryController.localizer.Lookup(chain) {
+		subcategory, err = subcategoryController.subcategoryRepository.GetSubcategoryByTitle(c.Request().Context(), title, locale)
+		if err == nil {
+			break
+		}
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	subcategory.Localize(chain)
+
 	return utils.Negotiate(c, http.StatusOK, subcategory)
 }
 
@@ -87,14 +130,21 @@ func (subcategoryController *SubcategoryController) UpdateSubcategory(c echo.Con
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
 	subcategory := payload.ToModel()
 	subcategory.ID = id
 
-	err := subcategoryController.subcategoryRepository.UpdateSubcategory(c.Request().Context(), subcategory)
+	err = subcategoryController.subcategoryRepository.UpdateSubcategory(c.Request().Context(), subcategory)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, id, model.AuditOperationUpdate, before, subcategory)
+
 	return utils.Negotiate(c, http.StatusOK, subcategory)
 }
 
@@ -104,10 +154,288 @@ func (subcategoryController *SubcategoryController) DeleteSubcategory(c echo.Con
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	err := subcategoryController.subcategoryRepository.DeleteSubcategory(c.Request().Context(), id)
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = subcategoryController.subcategoryRepository.DeleteSubcategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (subcategoryController *SubcategoryController) GetDeletedSubcategories(c echo.Context) error {
+	subcategories, err := subcategoryController.subcategoryRepository.GetDeletedSubcategories(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, subcategories)
+}
+
+func (subcategoryController *SubcategoryController) RestoreSubcategory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := subcategoryController.subcategoryRepository.RestoreSubcategory(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorTitleExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	after, _ := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), id)
+
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, id, model.AuditOperationRestore, nil, after)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (subcategoryController *SubcategoryController) BulkCreateSubcategories(c echo.Context) error {
+	var payload dto.BulkCreateSubcategories
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, subcategoryController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.CreateSubcategory, model.Subcategory]{
+		entityType: utils.CollNameSubcategory,
+		operation:  model.AuditOperationCreate,
+		applied:    model.BulkStatusCreated,
+		title:      func(item *dto.CreateSubcategory) string { return item.Title },
+		exists: func(ctx context.Context, title string) bool {
+			_, err := subcategoryController.subcategoryRepository.GetSubcategoryByTitle(ctx, title, utils.EmptyString)
+			return err == nil
+		},
+		prepare: func(ctx context.Context, item *dto.CreateSubcategory) (*model.Subcategory, *model.Subcategory, error) {
+			if item.CategoryID != utils.EmptyString {
+				if _, err := subcategoryController.categoryRepository.GetCategory(ctx, item.CategoryID); err != nil {
+					return nil, nil, errors.New("category is not found")
+				}
+			}
+
+			return item.ToModel(), nil, nil
+		},
+		write:    subcategoryController.subcategoryRepository.BulkCreateSubcategories,
+		identify: func(subcategory *model.Subcategory, id string) { subcategory.ID = id },
+	})
+}
+
+func (subcategoryController *SubcategoryController) BulkUpdateSubcategories(c echo.Context) error {
+	var payload dto.BulkUpdateSubcategories
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, subcategoryController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.BulkUpdateSubcategory, model.Subcategory]{
+		entityType: utils.CollNameSubcategory,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		prepare: func(ctx context.Context, item *dto.BulkUpdateSubcategory) (*model.Subcategory, *model.Subcategory, error) {
+			before, _ := subcategoryController.subcategoryRepository.GetSubcategory(ctx, item.ID)
+
+			subcategory := item.ToModel()
+			subcategory.ID = item.ID
+
+			return subcategory, before, nil
+		},
+		write: subcategoryController.subcategoryRepository.BulkUpdateSubcategories,
+	})
+}
+
+func (subcategoryController *SubcategoryController) BulkDeleteSubcategories(c echo.Context) error {
+	var payload dto.BulkDelete
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, subcategoryController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Subcategory]{
+		entityType: utils.CollNameSubcategory,
+		operation:  model.AuditOperationDelete,
+		applied:    model.BulkStatusDeleted,
+		get:        subcategoryController.subcategoryRepository.GetSubcategory,
+		write:      subcategoryController.subcategoryRepository.BulkDeleteSubcategories,
+	})
+}
+
+func (subcategoryController *SubcategoryController) GetCategorySubcategories(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	if _, err := subcategoryController.categoryRepository.GetCategory(c.Request().Context(), id); err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	subcategories, err := subcategoryController.subcategoryRepository.GetSubcategoriesByCategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	chain := subcategoryController.localizer.Chain(c)
+	for i := range *subcategories {
+		(*subcategories)[i].Localize(chain)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, subcategories)
+}
+
+func (subcategoryController *SubcategoryController) CreateCategorySubcategory(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.CreateSubcategory
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	payload.CategoryID = id
+
+	return subcategoryController.createSubcategory(c, &payload)
+}
+
+func (subcategoryController *SubcategoryController) AttachSubcategory(c echo.Context) error {
+	id := c.Param("id")
+	subcategoryID := c.Param("subcategory_id")
+	if id == "" || subcategoryID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	if _, err := subcategoryController.categoryRepository.GetCategory(c.Request().Context(), id); err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), subcategoryID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	err = subcategoryController.subcategoryRepository.AssignSubcategory(c.Request().Context(), subcategoryID, id)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	after := *before
+	after.CategoryID = id
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, subcategoryID, model.AuditOperationUpdate, before, after)
+
+	return utils.Negotiate(c, http.StatusOK, after)
+}
+
+func (subcategoryController *SubcategoryController) DetachSubcategory(c echo.Context) error {
+	id := c.Param("id")
+	subcategoryID := c.Param("subcategory_id")
+	if id == "" || subcategoryID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), subcategoryID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if before.CategoryID != id {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorSubcategoryMembership.Error())
+	}
+
+	err = subcategoryController.subcategoryRepository.AssignSubcategory(c.Request().Context(), subcategoryID, utils.EmptyString)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	after := *before
+	after.CategoryID = utils.EmptyString
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, subcategoryID, model.AuditOperationUpdate, before, after)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (subcategoryController *SubcategoryController) SetSubcategoryTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, subcategoryController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	var payload dto.SetTranslation
+
+	if err = utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	existing, err := subcategoryController.subcategoryRepository.GetSubcategoryByTitle(c.Request().Context(), payload.Title, locale)
+	if err == nil && existing.ID != id {
+		return utils.Negotiate(c, http.StatusConflict, "subcategory with this title is exist")
+	}
+
+	translation := payload.ToModel()
+
+	err = subcategoryController.subcategoryRepository.SetSubcategoryTranslation(c.Request().Context(), id, locale, translation)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	subcategory := *before
+	subcategory.Translations = model.WithTranslation(before.Translations, locale, translation)
+
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, id, model.AuditOperationUpdate, before, subcategory)
+
+	return utils.Negotiate(c, http.StatusOK, subcategory)
+}
+
+func (subcategoryController *SubcategoryController) DeleteSubcategoryTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, subcategoryController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	before, err := subcategoryController.subcategoryRepository.GetSubcategory(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if _, ok := before.Translations[locale]; !ok {
+		return utils.Negotiate(c, http.StatusNotFound, "translation is not found")
+	}
+
+	err = subcategoryController.subcategoryRepository.SetSubcategoryTranslation(c.Request().Context(), id, locale, nil)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	subcategory := *before
+	subcategory.Translations = model.WithTranslation(before.Translations, locale, nil)
+
+	subcategoryController.auditRecorder.Record(c, utils.CollNameSubcategory, id, model.AuditOperationUpdate, before, subcategory)
+
 	return utils.Negotiate(c, http.StatusNoContent, nil)
 }
@@ -1,21 +1,34 @@
 package controller
 
 import (
+	"context"
 	"net/http"
+	"strconv"
 
+	"github.com/Meystergod/online-store/internal/audit"
 	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/i18n"
 	"github.com/Meystergod/online-store/internal/repository"
 	"github.com/Meystergod/online-store/internal/utils"
 
 	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
 )
 
 type TagController struct {
 	tagRepository repository.TagRepository
+	localizer     *i18n.Localizer
+	auditRecorder *audit.Recorder
 }
 
-func NewTagController(tagRepository repository.TagRepository) *TagController {
-	return &TagController{tagRepository: tagRepository}
+func NewTagController(tagRepository repository.TagRepository, localizer *i18n.Localizer, auditRecorder *audit.Recorder) *TagController {
+	return &TagController{tagRepository: tagRepository, localizer: localizer, auditRecorder: auditRecorder}
 }
 
 func (tagController *TagController) CreateTag(c echo.Context) error {
@@ -25,16 +38,21 @@ func (tagController *TagController) CreateTag(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
-	_, err := tagController.tagRepository.GetTagByTitle(c.Request().Context(), payload.Title)
+	_, err := tagController.tagRepository.GetTagByTitle(c.Request().Context(), payload.Title, utils.EmptyString)
 	if err == nil {
 		return utils.Negotiate(c, http.StatusConflict, "tag with this title is exist")
 	}
 
-	createdTagID, err := tagController.tagRepository.CreateTag(c.Request().Context(), payload.ToModel())
+	tag := payload.ToModel()
+
+	createdTagID, err := tagController.tagRepository.CreateTag(c.Request().Context(), tag)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	tag.ID = createdTagID
+	tagController.auditRecorder.Record(c, utils.CollNameTag, createdTagID, model.AuditOperationCreate, nil, tag)
+
 	return utils.Negotiate(c, http.StatusCreated, createdTagID)
 }
 
@@ -44,6 +62,11 @@ func (tagController *TagController) GetAllTags(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	chain := tagController.localizer.Chain(c)
+	for i := range *tags {
+		(*tags)[i].Localize(chain)
+	}
+
 	return utils.Negotiate(c, http.StatusOK, tags)
 }
 
@@ -58,6 +81,8 @@ func (tagController *TagController) GetTag(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	tag.Localize(tagController.localizer.Chain(c))
+
 	return utils.Negotiate(c, http.StatusOK, tag)
 }
 
@@ -73,14 +98,21 @@ func (tagController *TagController) UpdateTag(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
 	}
 
+	before, err := tagController.tagRepository.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
 	tag := payload.ToModel()
 	tag.ID = id
 
-	err := tagController.tagRepository.UpdateTag(c.Request().Context(), tag)
+	err = tagController.tagRepository.UpdateTag(c.Request().Context(), tag)
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationUpdate, before, tag)
+
 	return utils.Negotiate(c, http.StatusOK, tag)
 }
 
@@ -90,10 +122,254 @@ func (tagController *TagController) DeleteTag(c echo.Context) error {
 		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
 	}
 
-	err := tagController.tagRepository.DeleteTag(c.Request().Context(), id)
+	before, err := tagController.tagRepository.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = tagController.tagRepository.DeleteTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (tagController *TagController) GetDeletedTags(c echo.Context) error {
+	tags, err := tagController.tagRepository.GetDeletedTags(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, tags)
+}
+
+func (tagController *TagController) RestoreTag(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := tagController.tagRepository.RestoreTag(c.Request().Context(), id)
+	if errors.Is(err, utils.ErrorTitleExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
 	if err != nil {
 		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
 	}
 
+	after, _ := tagController.tagRepository.GetTag(c.Request().Context(), id)
+
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationRestore, nil, after)
+
 	return utils.Negotiate(c, http.StatusNoContent, nil)
 }
+
+func (tagController *TagController) BulkCreateTags(c echo.Context) error {
+	var payload dto.BulkCreateTags
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, tagController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.CreateTag, model.Tag]{
+		entityType: utils.CollNameTag,
+		operation:  model.AuditOperationCreate,
+		applied:    model.BulkStatusCreated,
+		title:      func(item *dto.CreateTag) string { return item.Title },
+		exists: func(ctx context.Context, title string) bool {
+			_, err := tagController.tagRepository.GetTagByTitle(ctx, title, utils.EmptyString)
+			return err == nil
+		},
+		prepare: func(ctx context.Context, item *dto.CreateTag) (*model.Tag, *model.Tag, error) {
+			return item.ToModel(), nil, nil
+		},
+		write:    tagController.tagRepository.BulkCreateTags,
+		identify: func(tag *model.Tag, id string) { tag.ID = id },
+	})
+}
+
+func (tagController *TagController) BulkUpdateTags(c echo.Context) error {
+	var payload dto.BulkUpdateTags
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkItems(c, tagController.auditRecorder, payload.Items, payload.Ordered, bulkItems[dto.BulkUpdateTag, model.Tag]{
+		entityType: utils.CollNameTag,
+		operation:  model.AuditOperationUpdate,
+		applied:    model.BulkStatusUpdated,
+		prepare: func(ctx context.Context, item *dto.BulkUpdateTag) (*model.Tag, *model.Tag, error) {
+			before, _ := tagController.tagRepository.GetTag(ctx, item.ID)
+
+			tag := item.ToModel()
+			tag.ID = item.ID
+
+			return tag, before, nil
+		},
+		write: tagController.tagRepository.BulkUpdateTags,
+	})
+}
+
+func (tagController *TagController) BulkDeleteTags(c echo.Context) error {
+	var payload dto.BulkDelete
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	return runBulkIDs(c, tagController.auditRecorder, payload.IDs, payload.Ordered, bulkIDs[model.Tag]{
+		entityType: utils.CollNameTag,
+		operation:  model.AuditOperationDelete,
+		applied:    model.BulkStatusDeleted,
+		get:        tagController.tagRepository.GetTag,
+		write:      tagController.tagRepository.BulkDeleteTags,
+	})
+}
+
+func (tagController *TagController) SetTagTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, tagController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	var payload dto.SetTranslation
+
+	if err = utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := tagController.tagRepository.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	existing, err := tagController.tagRepository.GetTagByTitle(c.Request().Context(), payload.Title, locale)
+	if err == nil && existing.ID != id {
+		return utils.Negotiate(c, http.StatusConflict, "tag with this title is exist")
+	}
+
+	translation := payload.ToModel()
+	translation.Description = utils.EmptyString
+
+	err = tagController.tagRepository.SetTagTranslation(c.Request().Context(), id, locale, translation)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	tag := *before
+	tag.Translations = model.WithTranslation(before.Translations, locale, translation)
+
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationUpdate, before, tag)
+
+	return utils.Negotiate(c, http.StatusOK, tag)
+}
+
+func (tagController *TagController) DeleteTagTranslation(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	locale, err := translationLocale(c, tagController.localizer)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	before, err := tagController.tagRepository.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if _, ok := before.Translations[locale]; !ok {
+		return utils.Negotiate(c, http.StatusNotFound, "translation is not found")
+	}
+
+	err = tagController.tagRepository.SetTagTranslation(c.Request().Context(), id, locale, nil)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	tag := *before
+	tag.Translations = model.WithTranslation(before.Translations, locale, nil)
+
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationUpdate, before, tag)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (tagController *TagController) SuggestTags(c echo.Context) error {
+	prefix := c.QueryParam("prefix")
+	if prefix == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	limit := defaultSuggestLimit
+
+	if value := c.QueryParam("limit"); value != utils.EmptyString {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > maxSuggestLimit {
+			return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+		}
+
+		limit = parsed
+	}
+
+	chain := tagController.localizer.Chain(c)
+
+	tags, err := tagController.tagRepository.SuggestTags(c.Request().Context(), prefix, tagController.localizer.Lookup(chain), limit)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	for i := range tags {
+		tags[i].Localize(chain)
+	}
+
+	return utils.Negotiate(c, http.StatusOK, tags)
+}
+
+func (tagController *TagController) MergeTag(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.MergeTag
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	if payload.TargetID == id {
+		return utils.Negotiate(c, http.StatusBadRequest, "tag cannot be merged into itself")
+	}
+
+	before, err := tagController.tagRepository.GetTag(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	target, err := tagController.tagRepository.GetTag(c.Request().Context(), payload.TargetID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, "target tag is not found")
+	}
+
+	merge, err := tagController.tagRepository.MergeTags(c.Request().Context(), id, target)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	tagController.auditRecorder.Record(c, utils.CollNameTag, id, model.AuditOperationDelete, before, merge)
+
+	return utils.Negotiate(c, http.StatusOK, merge)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WarehouseController struct {
+	warehouseRepository repository.WarehouseRepository
+	inventoryRepository repository.InventoryRepository
+	auditRecorder       *audit.Recorder
+}
+
+func NewWarehouseController(warehouseRepository repository.WarehouseRepository, inventoryRepository repository.InventoryRepository, auditRecorder *audit.Recorder) *WarehouseController {
+	return &WarehouseController{
+		warehouseRepository: warehouseRepository,
+		inventoryRepository: inventoryRepository,
+		auditRecorder:       auditRecorder,
+	}
+}
+
+func (warehouseController *WarehouseController) CreateWarehouse(c echo.Context) error {
+	var payload dto.CreateWarehouse
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	warehouse := payload.ToModel()
+
+	createdWarehouseID, err := warehouseController.warehouseRepository.CreateWarehouse(c.Request().Context(), warehouse)
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.Negotiate(c, http.StatusConflict, "warehouse with this code is exist")
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	warehouse.ID = createdWarehouseID
+	warehouseController.auditRecorder.Record(c, utils.CollNameWarehouse, createdWarehouseID, model.AuditOperationCreate, nil, warehouse)
+
+	return utils.Negotiate(c, http.StatusCreated, createdWarehouseID)
+}
+
+func (warehouseController *WarehouseController) GetAllWarehouses(c echo.Context) error {
+	warehouses, err := warehouseController.warehouseRepository.GetAllWarehouses(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, warehouses)
+}
+
+func (warehouseController *WarehouseController) GetWarehouse(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	warehouse, err := warehouseController.warehouseRepository.GetWarehouse(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, warehouse)
+}
+
+func (warehouseController *WarehouseController) UpdateWarehouse(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateWarehouse
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := warehouseController.warehouseRepository.GetWarehouse(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	warehouse := payload.ToModel()
+	warehouse.ID = id
+
+	err = warehouseController.warehouseRepository.UpdateWarehouse(c.Request().Context(), warehouse)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	warehouse.Code = before.Code
+	warehouse.CreatedAt = before.CreatedAt
+
+	warehouseController.auditRecorder.Record(c, utils.CollNameWarehouse, id, model.AuditOperationUpdate, before, warehouse)
+
+	return utils.Negotiate(c, http.StatusOK, warehouse)
+}
+
+func (warehouseController *WarehouseController) DeleteWarehouse(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := warehouseController.warehouseRepository.GetWarehouse(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	stocked, err := warehouseController.inventoryRepository.HasWarehouseStock(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if stocked {
+		return utils.Negotiate(c, http.StatusConflict, "warehouse still holds stock")
+	}
+
+	err = warehouseController.warehouseRepository.DeleteWarehouse(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	warehouseController.auditRecorder.Record(c, utils.CollNameWarehouse, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+	"github.com/Meystergod/online-store/internal/webhook"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookController struct {
+	webhookRepository         repository.WebhookRepository
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+	allowPrivateTargets       bool
+}
+
+func NewWebhookController(webhookRepository repository.WebhookRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository, allowPrivateTargets bool) *WebhookController {
+	return &WebhookController{
+		webhookRepository:         webhookRepository,
+		webhookDeliveryRepository: webhookDeliveryRepository,
+		allowPrivateTargets:       allowPrivateTargets,
+	}
+}
+
+func (webhookController *WebhookController) CreateWebhook(c echo.Context) error {
+	var payload dto.CreateWebhook
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	if err := webhook.ValidateTarget(c.Request().Context(), payload.URL, webhookController.allowPrivateTargets); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	createdWebhookID, err := webhookController.webhookRepository.CreateWebhook(c.Request().Context(), payload.ToModel())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusCreated, createdWebhookID)
+}
+
+func (webhookController *WebhookController) GetAllWebhooks(c echo.Context) error {
+	webhooks, err := webhookController.webhookRepository.GetAllWebhooks(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, webhooks)
+}
+
+func (webhookController *WebhookController) GetWebhook(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	subscription, err := webhookController.webhookRepository.GetWebhook(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, subscription)
+}
+
+func (webhookController *WebhookController) UpdateWebhook(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateWebhook
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	if err := webhook.ValidateTarget(c.Request().Context(), payload.URL, webhookController.allowPrivateTargets); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	subscription := payload.ToModel()
+	subscription.ID = id
+
+	err := webhookController.webhookRepository.UpdateWebhook(c.Request().Context(), subscription)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, subscription)
+}
+
+func (webhookController *WebhookController) DeleteWebhook(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := webhookController.webhookRepository.DeleteWebhook(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (webhookController *WebhookController) GetDeadLetters(c echo.Context) error {
+	deliveries, err := webhookController.webhookDeliveryRepository.GetDeadWebhookDeliveries(c.Request().Context())
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, deliveries)
+}
+
+func (webhookController *WebhookController) RetryDeadLetter(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := webhookController.webhookDeliveryRepository.RequeueWebhookDelivery(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusAccepted, nil)
+}
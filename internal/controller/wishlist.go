@@ -0,0 +1,323 @@
+package controller
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/Meystergod/online-store/internal/audit"
+	"github.com/Meystergod/online-store/internal/domain/dto"
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const shareTokenSize = 16
+
+type WishlistController struct {
+	wishlistRepository repository.WishlistRepository
+	productRepository  repository.ProductRepository
+	cartRepository     repository.CartRepository
+	auditRecorder      *audit.Recorder
+}
+
+func NewWishlistController(wishlistRepository repository.WishlistRepository, productRepository repository.ProductRepository, cartRepository repository.CartRepository, auditRecorder *audit.Recorder) *WishlistController {
+	return &WishlistController{
+		wishlistRepository: wishlistRepository,
+		productRepository:  productRepository,
+		cartRepository:     cartRepository,
+		auditRecorder:      auditRecorder,
+	}
+}
+
+func (wishlistController *WishlistController) CreateWishlist(c echo.Context) error {
+	var payload dto.CreateWishlist
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	wishlist := payload.ToModel()
+
+	createdWishlistID, err := wishlistController.wishlistRepository.CreateWishlist(c.Request().Context(), wishlist)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	wishlist.ID = createdWishlistID
+	wishlistController.auditRecorder.Record(c, utils.CollNameWishlist, createdWishlistID, model.AuditOperationCreate, nil, wishlist)
+
+	return utils.Negotiate(c, http.StatusCreated, createdWishlistID)
+}
+
+func (wishlistController *WishlistController) GetCustomerWishlists(c echo.Context) error {
+	customerID := c.QueryParam("customer")
+	if customerID == utils.EmptyString {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	wishlists, err := wishlistController.wishlistRepository.GetWishlistsByCustomer(c.Request().Context(), customerID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, wishlists)
+}
+
+func (wishlistController *WishlistController) GetWishlist(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	wishlist, err := wishlistController.wishlistRepository.GetWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if err = wishlistController.resolveItems(c, wishlist); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, wishlist)
+}
+
+func (wishlistController *WishlistController) GetSharedWishlist(c echo.Context) error {
+	token := c.Param("token")
+	if token == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	wishlist, err := wishlistController.wishlistRepository.GetWishlistByShareToken(c.Request().Context(), token)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err = wishlistController.resolveItems(c, wishlist); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	wishlist.CustomerID = utils.EmptyString
+
+	return utils.Negotiate(c, http.StatusOK, wishlist)
+}
+
+func (wishlistController *WishlistController) UpdateWishlist(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.UpdateWishlist
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	before, err := wishlistController.wishlistRepository.GetWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = wishlistController.wishlistRepository.RenameWishlist(c.Request().Context(), id, payload.Name)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	wishlist := *before
+	wishlist.Name = payload.Name
+
+	wishlistController.auditRecorder.Record(c, utils.CollNameWishlist, id, model.AuditOperationUpdate, before, wishlist)
+
+	return utils.Negotiate(c, http.StatusOK, wishlist)
+}
+
+func (wishlistController *WishlistController) DeleteWishlist(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	before, err := wishlistController.wishlistRepository.GetWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	err = wishlistController.wishlistRepository.DeleteWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	wishlistController.auditRecorder.Record(c, utils.CollNameWishlist, id, model.AuditOperationDelete, before, nil)
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (wishlistController *WishlistController) AddWishlistItem(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.AddWishlistItem
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	product, err := wishlistController.productRepository.GetProduct(c.Request().Context(), payload.ProductID)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, err.Error())
+	}
+
+	if !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorProductNotFound.Error())
+	}
+
+	item := payload.ToModel()
+
+	err = wishlistController.wishlistRepository.AddWishlistItem(c.Request().Context(), id, item)
+	if errors.Is(err, utils.ErrorWishlistItemExists) {
+		return utils.Negotiate(c, http.StatusConflict, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusCreated, item)
+}
+
+func (wishlistController *WishlistController) RemoveWishlistItem(c echo.Context) error {
+	id := c.Param("id")
+	productID := c.Param("product")
+	if id == "" || productID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := wishlistController.wishlistRepository.RemoveWishlistItem(c.Request().Context(), id, productID)
+	if errors.Is(err, utils.ErrorWishlistItemNotFound) {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (wishlistController *WishlistController) MoveWishlistItemToCart(c echo.Context) error {
+	id := c.Param("id")
+	productID := c.Param("product")
+	if id == "" || productID == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	var payload dto.MoveWishlistItem
+
+	if err := utils.BindAndValidate(c, &payload); err != nil {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorBindAndValidatePayload.Error())
+	}
+
+	wishlist, err := wishlistController.wishlistRepository.GetWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusNotFound, err.Error())
+	}
+
+	if !wishlist.HasItem(productID) {
+		return utils.Negotiate(c, http.StatusNotFound, utils.ErrorWishlistItemNotFound.Error())
+	}
+
+	product, err := wishlistController.productRepository.GetProduct(c.Request().Context(), productID)
+	if err != nil || !product.IsPublished() {
+		return utils.Negotiate(c, http.StatusConflict, utils.ErrorProductNotFound.Error())
+	}
+
+	sku := payload.SKU
+	if sku == utils.EmptyString {
+		sku = product.DefaultSKU()
+	}
+
+	if _, ok := product.AvailableSKU(sku); !ok {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorVariantNotFound.Error())
+	}
+
+	item, check, err := putCartItem(c.Request().Context(), wishlistController.cartRepository, wishlist.CustomerID, product, sku, payload.Quantity)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if check != nil {
+		return utils.Negotiate(c, http.StatusConflict, check)
+	}
+
+	err = wishlistController.wishlistRepository.RemoveWishlistItem(c.Request().Context(), id, productID)
+	if err != nil && !errors.Is(err, utils.ErrorWishlistItemNotFound) {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusOK, item)
+}
+
+func (wishlistController *WishlistController) ShareWishlist(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	wishlist, err := wishlistController.wishlistRepository.GetWishlist(c.Request().Context(), id)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	if wishlist.ShareToken != utils.EmptyString {
+		return utils.Negotiate(c, http.StatusOK, wishlist.ShareToken)
+	}
+
+	buffer := make([]byte, shareTokenSize)
+	if _, err = rand.Read(buffer); err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	token := hex.EncodeToString(buffer)
+
+	err = wishlistController.wishlistRepository.SetWishlistShareToken(c.Request().Context(), id, token)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusCreated, token)
+}
+
+func (wishlistController *WishlistController) UnshareWishlist(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return utils.Negotiate(c, http.StatusBadRequest, utils.ErrorGetUrlParams.Error())
+	}
+
+	err := wishlistController.wishlistRepository.SetWishlistShareToken(c.Request().Context(), id, utils.EmptyString)
+	if err != nil {
+		return utils.Negotiate(c, http.StatusInternalServerError, err.Error())
+	}
+
+	return utils.Negotiate(c, http.StatusNoContent, nil)
+}
+
+func (wishlistController *WishlistController) resolveItems(c echo.Context, wishlist *model.Wishlist) error {
+	if len(wishlist.Items) == 0 {
+		return nil
+	}
+
+	products, err := wishlistController.productRepository.GetProductsByIDs(c.Request().Context(), wishlist.ProductIDs())
+	if err != nil {
+		return err
+	}
+
+	wishlist.Resolve(*products)
+
+	return nil
+}
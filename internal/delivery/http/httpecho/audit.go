@@ -0,0 +1,14 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetAuditApiRoutes(e *echo.Echo, auditController *controller.AuditController) {
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.GET("/audit", auditController.GetAuditEntries)
+	}
+}
@@ -0,0 +1,72 @@
+package httpecho
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+)
+
+const bearerPrefix = "Bearer "
+
+func SetAuthMiddleware(e *echo.Echo, tokens map[string]string, trustProxy bool) {
+	e.IPExtractor = echo.ExtractIPDirect()
+	if trustProxy {
+		e.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
+
+	e.Use(authenticate(tokens))
+}
+
+func authenticate(tokens map[string]string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Request().Header.Get(echo.HeaderAuthorization)
+			if header == utils.EmptyString {
+				return next(c)
+			}
+
+			if !strings.HasPrefix(header, bearerPrefix) {
+				return utils.Negotiate(c, http.StatusUnauthorized, utils.ErrorUnauthorized.Error())
+			}
+
+			actor := lookupActor(tokens, strings.TrimPrefix(header, bearerPrefix))
+			if actor == utils.EmptyString {
+				return utils.Negotiate(c, http.StatusUnauthorized, utils.ErrorUnauthorized.Error())
+			}
+
+			c.Set(utils.ContextKeyActor, actor)
+
+			return next(c)
+		}
+	}
+}
+
+func RequireActor(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if _, ok := c.Get(utils.ContextKeyActor).(string); !ok {
+			return utils.Negotiate(c, http.StatusUnauthorized, utils.ErrorUnauthorized.Error())
+		}
+
+		return next(c)
+	}
+}
+
+func lookupActor(tokens map[string]string, token string) string {
+	found := utils.EmptyString
+
+	for actor, expected := range tokens {
+		if expected == utils.EmptyString {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(token)) == 1 {
+			found = actor
+		}
+	}
+
+	return found
+}
@@ -0,0 +1,18 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetCartApiRoutes(e *echo.Echo, cartController *controller.CartController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.GET("/cart/:customer", cartController.GetCart)
+		v1.DELETE("/cart/:customer", cartController.ClearCart)
+		v1.POST("/cart/:customer/checkout", cartController.Checkout)
+		v1.PUT("/cart/:customer/items/:sku", cartController.SetCartItem)
+		v1.DELETE("/cart/:customer/items/:sku", cartController.RemoveCartItem)
+	}
+}
@@ -9,10 +9,27 @@ import (
 func SetCategoryApiRoutes(e *echo.Echo, categoryController *controller.CategoryController) {
 	v1 := e.Group("/api/v1")
 	{
-		v1.POST("/category", categoryController.CreateCategory)
 		v1.GET("/categories", categoryController.GetAllCategories)
+		v1.GET("/categories/tree", categoryController.GetCategoryTree)
+		v1.GET("/category/slug/:slug", categoryController.GetCategoryBySlug)
 		v1.GET("/category/:id", categoryController.GetCategory)
-		v1.PUT("/category/:id", categoryController.UpdateCategory)
-		v1.DELETE("/category/:id", categoryController.DeleteCategory)
+		v1.GET("/category/:id/tree", categoryController.GetCategorySubtree)
+		v1.GET("/category/:id/breadcrumbs", categoryController.GetCategoryBreadcrumbs)
+		v1.GET("/category/:id/products", categoryController.GetCategoryProducts)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/category", categoryController.CreateCategory)
+		staff.POST("/categories/bulk", categoryController.BulkCreateCategories)
+		staff.PUT("/categories/bulk", categoryController.BulkUpdateCategories)
+		staff.DELETE("/categories/bulk", categoryController.BulkDeleteCategories)
+		staff.GET("/categories/trash", categoryController.GetDeletedCategories)
+		staff.PUT("/category/:id", categoryController.UpdateCategory)
+		staff.DELETE("/category/:id", categoryController.DeleteCategory)
+		staff.PUT("/category/:id/translations/:locale", categoryController.SetCategoryTranslation)
+		staff.DELETE("/category/:id/translations/:locale", categoryController.DeleteCategoryTranslation)
+		staff.POST("/category/:id/restore", categoryController.RestoreCategory)
+		staff.POST("/category/:id/move", categoryController.MoveCategory)
 	}
 }
@@ -0,0 +1,25 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetCouponApiRoutes(e *echo.Echo, couponController *controller.CouponController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.POST("/coupons/validate", couponController.ValidateCoupon)
+		v1.POST("/coupons/redeem", couponController.RedeemCoupon)
+		v1.POST("/coupons/release", couponController.ReleaseCoupon)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/coupon", couponController.CreateCoupon)
+		staff.GET("/coupons", couponController.GetAllCoupons)
+		staff.GET("/coupon/:id", couponController.GetCoupon)
+		staff.PUT("/coupon/:id", couponController.UpdateCoupon)
+		staff.DELETE("/coupon/:id", couponController.DeleteCoupon)
+	}
+}
@@ -0,0 +1,20 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetCurrencyApiRoutes(e *echo.Echo, currencyController *controller.CurrencyController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.GET("/currencies", currencyController.GetCurrencies)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.PUT("/currencies/rates", currencyController.SaveExchangeRates)
+		staff.DELETE("/currency/:currency/rate", currencyController.DeleteExchangeRate)
+	}
+}
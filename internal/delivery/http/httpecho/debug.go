@@ -0,0 +1,11 @@
+package httpecho
+
+import (
+	"expvar"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetDebugRoutes(e *echo.Echo) {
+	e.GET("/debug/vars", echo.WrapHandler(expvar.Handler()))
+}
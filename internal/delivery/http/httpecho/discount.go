@@ -9,10 +9,19 @@ import (
 func SetDiscountApiRoutes(e *echo.Echo, discountController *controller.DiscountController) {
 	v1 := e.Group("/api/v1")
 	{
-		v1.POST("/discount", discountController.CreateDiscount)
 		v1.GET("/discounts", discountController.GetAllDiscounts)
 		v1.GET("/discount/:id", discountController.GetDiscount)
-		v1.PUT("/discount/:id", discountController.UpdateDiscount)
-		v1.DELETE("/discount/:id", discountController.DeleteDiscount)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/discount", discountController.CreateDiscount)
+		staff.POST("/discounts/bulk", discountController.BulkCreateDiscounts)
+		staff.PUT("/discounts/bulk", discountController.BulkUpdateDiscounts)
+		staff.DELETE("/discounts/bulk", discountController.BulkDeleteDiscounts)
+		staff.GET("/discounts/trash", discountController.GetDeletedDiscounts)
+		staff.PUT("/discount/:id", discountController.UpdateDiscount)
+		staff.DELETE("/discount/:id", discountController.DeleteDiscount)
+		staff.POST("/discount/:id/restore", discountController.RestoreDiscount)
 	}
 }
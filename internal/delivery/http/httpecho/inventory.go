@@ -0,0 +1,25 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetInventoryApiRoutes(e *echo.Echo, inventoryController *controller.InventoryController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.GET("/product/:id/stock", inventoryController.GetStockLevel)
+		v1.GET("/product/:id/stock/movements", inventoryController.GetStockMovements)
+		v1.GET("/product/:id/stock/warehouses", inventoryController.GetWarehouseStocks)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/inventory/movements", inventoryController.PostStockMovement)
+		staff.POST("/inventory/transfers", inventoryController.TransferStock)
+		staff.POST("/inventory/reservations", inventoryController.ReserveStock)
+		staff.GET("/inventory/low-stock", inventoryController.GetLowStockProducts)
+		staff.GET("/inventory/alerts", inventoryController.GetStockAlerts)
+	}
+}
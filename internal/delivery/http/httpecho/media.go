@@ -0,0 +1,9 @@
+package httpecho
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func SetMediaRoutes(e *echo.Echo, prefix string, dir string) {
+	e.Static(prefix, dir)
+}
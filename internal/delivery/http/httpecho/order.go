@@ -0,0 +1,15 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetOrderApiRoutes(e *echo.Echo, orderController *controller.OrderController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.GET("/orders", orderController.GetCustomerOrders)
+		v1.GET("/order/:id", orderController.GetOrder)
+	}
+}
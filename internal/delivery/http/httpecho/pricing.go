@@ -0,0 +1,23 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetPricingApiRoutes(e *echo.Echo, pricingController *controller.PricingController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.POST("/pricing/quote", pricingController.Quote)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/pricing/rule", pricingController.CreatePricingRule)
+		staff.GET("/pricing/rules", pricingController.GetAllPricingRules)
+		staff.GET("/pricing/rule/:id", pricingController.GetPricingRule)
+		staff.PUT("/pricing/rule/:id", pricingController.UpdatePricingRule)
+		staff.DELETE("/pricing/rule/:id", pricingController.DeletePricingRule)
+	}
+}
@@ -9,10 +9,48 @@ import (
 func SetProductApiRoutes(e *echo.Echo, productController *controller.ProductController) {
 	v1 := e.Group("/api/v1")
 	{
-		v1.POST("/product", productController.CreateProduct)
 		v1.GET("/products", productController.GetAllProducts)
+		v1.GET("/products/facets", productController.GetProductFacets)
+		v1.GET("/product/sku/:sku", productController.GetProductBySKU)
+		v1.POST("/stock/check", productController.CheckStock)
+		v1.GET("/product/slug/:slug", productController.GetProductBySlug)
 		v1.GET("/product/:id", productController.GetProduct)
-		v1.PUT("/product/:id", productController.UpdateProduct)
-		v1.DELETE("/product/:id", productController.DeleteProduct)
+		v1.GET("/product/:id/breadcrumbs", productController.GetProductBreadcrumbs)
+		v1.GET("/product/:id/related", productController.GetRelatedProducts)
+		v1.GET("/product/:id/variants", productController.GetProductVariants)
+		v1.GET("/product/:id/variants/:sku", productController.GetProductVariant)
+		v1.GET("/product/:id/images", productController.GetProductImages)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/product", productController.CreateProduct)
+		staff.POST("/products/bulk", productController.BulkCreateProducts)
+		staff.PUT("/products/bulk", productController.BulkUpdateProducts)
+		staff.DELETE("/products/bulk", productController.BulkDeleteProducts)
+		staff.POST("/products/tags", productController.BulkAddProductTags)
+		staff.DELETE("/products/tags", productController.BulkRemoveProductTags)
+		staff.GET("/products/trash", productController.GetDeletedProducts)
+		staff.PUT("/product/:id", productController.UpdateProduct)
+		staff.DELETE("/product/:id", productController.DeleteProduct)
+		staff.PUT("/product/:id/translations/:locale", productController.SetProductTranslation)
+		staff.DELETE("/product/:id/translations/:locale", productController.DeleteProductTranslation)
+		staff.PUT("/product/:id/status", productController.SetProductStatus)
+		staff.POST("/product/:id/restore", productController.RestoreProduct)
+		staff.GET("/product/:id/related/override", productController.GetRelatedOverride)
+		staff.PUT("/product/:id/related/override", productController.SetRelatedOverride)
+		staff.DELETE("/product/:id/related/override", productController.DeleteRelatedOverride)
+		staff.POST("/product/:id/variants", productController.CreateProductVariant)
+		staff.PUT("/product/:id/variants/:sku", productController.UpdateProductVariant)
+		staff.DELETE("/product/:id/variants/:sku", productController.DeleteProductVariant)
+		staff.POST("/product/:id/images", productController.UploadProductImages)
+		staff.PUT("/product/:id/images/order", productController.ReorderProductImages)
+		staff.DELETE("/product/:id/images/:image_id", productController.DeleteProductImage)
+	}
+
+	admin := e.Group("/api/v1/admin", RequireActor)
+	{
+		admin.GET("/products", productController.GetAdminProducts)
+		admin.GET("/product/:id", productController.GetAdminProduct)
 	}
 }
@@ -0,0 +1,24 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetReviewApiRoutes(e *echo.Echo, reviewController *controller.ReviewController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.POST("/product/:id/reviews", reviewController.CreateReview)
+		v1.GET("/product/:id/reviews", reviewController.GetProductReviews)
+	}
+
+	admin := e.Group("/api/v1/admin", RequireActor)
+	{
+		admin.GET("/reviews", reviewController.GetAllReviews)
+		admin.GET("/review/:id", reviewController.GetReview)
+		admin.PUT("/review/:id", reviewController.UpdateReview)
+		admin.DELETE("/review/:id", reviewController.DeleteReview)
+		admin.POST("/review/:id/moderate", reviewController.ModerateReview)
+	}
+}
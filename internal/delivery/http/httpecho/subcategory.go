@@ -9,10 +9,25 @@ import (
 func SetSubcategoryApiRoutes(e *echo.Echo, subcategoryController *controller.SubcategoryController) {
 	v1 := e.Group("/api/v1")
 	{
-		v1.POST("/subcategory", subcategoryController.CreateSubcategory)
 		v1.GET("/subcategories", subcategoryController.GetAllSubcategories)
 		v1.GET("/subcategory/:id", subcategoryController.GetSubcategory)
-		v1.PUT("/subcategory/:id", subcategoryController.UpdateSubcategory)
-		v1.DELETE("/subcategory/:id", subcategoryController.DeleteSubcategory)
+		v1.GET("/category/:id/subcategories", subcategoryController.GetCategorySubcategories)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/subcategory", subcategoryController.CreateSubcategory)
+		staff.POST("/subcategories/bulk", subcategoryController.BulkCreateSubcategories)
+		staff.PUT("/subcategories/bulk", subcategoryController.BulkUpdateSubcategories)
+		staff.DELETE("/subcategories/bulk", subcategoryController.BulkDeleteSubcategories)
+		staff.GET("/subcategories/trash", subcategoryController.GetDeletedSubcategories)
+		staff.PUT("/subcategory/:id", subcategoryController.UpdateSubcategory)
+		staff.DELETE("/subcategory/:id", subcategoryController.DeleteSubcategory)
+		staff.PUT("/subcategory/:id/translations/:locale", subcategoryController.SetSubcategoryTranslation)
+		staff.DELETE("/subcategory/:id/translations/:locale", subcategoryController.DeleteSubcategoryTranslation)
+		staff.POST("/subcategory/:id/restore", subcategoryController.RestoreSubcategory)
+		staff.POST("/category/:id/subcategories", subcategoryController.CreateCategorySubcategory)
+		staff.PUT("/category/:id/subcategories/:subcategory_id", subcategoryController.AttachSubcategory)
+		staff.DELETE("/category/:id/subcategories/:subcategory_id", subcategoryController.DetachSubcategory)
 	}
 }
@@ -9,10 +9,23 @@ import (
 func SetTagApiRoutes(e *echo.Echo, tagController *controller.TagController) {
 	v1 := e.Group("/api/v1")
 	{
-		v1.POST("/tag", tagController.CreateTag)
 		v1.GET("/tags", tagController.GetAllTags)
+		v1.GET("/tags/suggest", tagController.SuggestTags)
 		v1.GET("/tag/:id", tagController.GetTag)
-		v1.PUT("/tag/:id", tagController.UpdateTag)
-		v1.DELETE("/tag/:id", tagController.DeleteTag)
+	}
+
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/tag", tagController.CreateTag)
+		staff.POST("/tags/bulk", tagController.BulkCreateTags)
+		staff.PUT("/tags/bulk", tagController.BulkUpdateTags)
+		staff.DELETE("/tags/bulk", tagController.BulkDeleteTags)
+		staff.GET("/tags/trash", tagController.GetDeletedTags)
+		staff.PUT("/tag/:id", tagController.UpdateTag)
+		staff.DELETE("/tag/:id", tagController.DeleteTag)
+		staff.PUT("/tag/:id/translations/:locale", tagController.SetTagTranslation)
+		staff.DELETE("/tag/:id/translations/:locale", tagController.DeleteTagTranslation)
+		staff.POST("/tag/:id/restore", tagController.RestoreTag)
+		staff.POST("/tag/:id/merge", tagController.MergeTag)
 	}
 }
@@ -0,0 +1,18 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetWarehouseApiRoutes(e *echo.Echo, warehouseController *controller.WarehouseController) {
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/warehouse", warehouseController.CreateWarehouse)
+		staff.GET("/warehouses", warehouseController.GetAllWarehouses)
+		staff.GET("/warehouse/:id", warehouseController.GetWarehouse)
+		staff.PUT("/warehouse/:id", warehouseController.UpdateWarehouse)
+		staff.DELETE("/warehouse/:id", warehouseController.DeleteWarehouse)
+	}
+}
@@ -0,0 +1,20 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetWebhookApiRoutes(e *echo.Echo, webhookController *controller.WebhookController) {
+	staff := e.Group("/api/v1", RequireActor)
+	{
+		staff.POST("/webhook", webhookController.CreateWebhook)
+		staff.GET("/webhooks", webhookController.GetAllWebhooks)
+		staff.GET("/webhooks/dead-letters", webhookController.GetDeadLetters)
+		staff.POST("/webhooks/dead-letters/:id/retry", webhookController.RetryDeadLetter)
+		staff.GET("/webhook/:id", webhookController.GetWebhook)
+		staff.PUT("/webhook/:id", webhookController.UpdateWebhook)
+		staff.DELETE("/webhook/:id", webhookController.DeleteWebhook)
+	}
+}
@@ -0,0 +1,24 @@
+package httpecho
+
+import (
+	"github.com/Meystergod/online-store/internal/controller"
+
+	"github.com/labstack/echo/v4"
+)
+
+func SetWishlistApiRoutes(e *echo.Echo, wishlistController *controller.WishlistController) {
+	v1 := e.Group("/api/v1")
+	{
+		v1.POST("/wishlist", wishlistController.CreateWishlist)
+		v1.GET("/wishlists", wishlistController.GetCustomerWishlists)
+		v1.GET("/wishlists/shared/:token", wishlistController.GetSharedWishlist)
+		v1.GET("/wishlist/:id", wishlistController.GetWishlist)
+		v1.PUT("/wishlist/:id", wishlistController.UpdateWishlist)
+		v1.DELETE("/wishlist/:id", wishlistController.DeleteWishlist)
+		v1.POST("/wishlist/:id/items", wishlistController.AddWishlistItem)
+		v1.DELETE("/wishlist/:id/items/:product", wishlistController.RemoveWishlistItem)
+		v1.POST("/wishlist/:id/items/:product/cart", wishlistController.MoveWishlistItemToCart)
+		v1.POST("/wishlist/:id/share", wishlistController.ShareWishlist)
+		v1.DELETE("/wishlist/:id/share", wishlistController.UnshareWishlist)
+	}
+}
@@ -0,0 +1,6 @@
+package dto
+
+type BulkDelete struct {
+	Ordered bool     `json:"ordered" bson:"ordered"`
+	IDs     []string `json:"uuids" bson:"uuids" validate:"required,min=1,max=1000"`
+}
@@ -0,0 +1,5 @@
+package dto
+
+type SetCartItem struct {
+	Quantity int `json:"quantity" bson:"quantity" validate:"required,min=1,max=1000"`
+}
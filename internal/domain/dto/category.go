@@ -5,25 +5,55 @@ import (
 )
 
 type CreateCategory struct {
-	Title       string `json:"title" bson:"title" validate:"required"`
-	Description string `json:"description" bson:"description" validate:"required"`
+	Title             string                      `json:"title" bson:"title" validate:"required"`
+	Description       string                      `json:"description" bson:"description" validate:"required"`
+	ParentID          string                      `json:"parent-id" bson:"parent-id"`
+	Attributes        []model.AttributeDefinition `json:"attributes" bson:"attributes" validate:"dive"`
+	LowStockThreshold *int                        `json:"low-stock-threshold" bson:"low-stock-threshold,omitempty" validate:"omitempty,min=0"`
 }
 
 type UpdateCategory struct {
-	Title       string `json:"title" bson:"title" validate:"required"`
-	Description string `json:"description" bson:"description" validate:"required"`
+	Title             string                      `json:"title" bson:"title" validate:"required"`
+	Description       string                      `json:"description" bson:"description" validate:"required"`
+	Attributes        []model.AttributeDefinition `json:"attributes" bson:"attributes" validate:"dive"`
+	LowStockThreshold *int                        `json:"low-stock-threshold" bson:"low-stock-threshold,omitempty" validate:"omitempty,min=0"`
+}
+
+type MoveCategory struct {
+	ParentID string `json:"parent-id" bson:"parent-id"`
+}
+
+type BulkCreateCategories struct {
+	Ordered bool             `json:"ordered" bson:"ordered"`
+	Items   []CreateCategory `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkUpdateCategory struct {
+	ID             string `json:"uuid" bson:"_id" validate:"required"`
+	UpdateCategory `bson:",inline"`
+}
+
+type BulkUpdateCategories struct {
+	Ordered bool                 `json:"ordered" bson:"ordered"`
+	Items   []BulkUpdateCategory `json:"items" bson:"items" validate:"required,min=1,max=1000"`
 }
 
 func (createCategory *CreateCategory) ToModel() *model.Category {
 	return &model.Category{
-		Title:       createCategory.Title,
-		Description: createCategory.Description,
+		Title:             createCategory.Title,
+		Description:       createCategory.Description,
+		ParentID:          createCategory.ParentID,
+		Subcategories:     []model.Subcategory{},
+		Attributes:        createCategory.Attributes,
+		LowStockThreshold: createCategory.LowStockThreshold,
 	}
 }
 
 func (updateCategory *UpdateCategory) ToModel() *model.Category {
 	return &model.Category{
-		Title:       updateCategory.Title,
-		Description: updateCategory.Description,
+		Title:             updateCategory.Title,
+		Description:       updateCategory.Description,
+		Attributes:        updateCategory.Attributes,
+		LowStockThreshold: updateCategory.LowStockThreshold,
 	}
 }
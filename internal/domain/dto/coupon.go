@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type CreateCoupon struct {
+	Code             string     `json:"code" bson:"code" validate:"required,max=64,excludesall= "`
+	DiscountID       string     `json:"discount-id" bson:"discount-id" validate:"required_without=Amount,excluded_with=Amount"`
+	Amount           float64    `json:"amount" bson:"amount" validate:"gte=0"`
+	MinOrderValue    float64    `json:"min-order-value" bson:"min-order-value" validate:"gte=0"`
+	UsageLimit       int        `json:"usage-limit" bson:"usage-limit" validate:"gte=0"`
+	PerCustomerLimit int        `json:"per-customer-limit" bson:"per-customer-limit" validate:"gte=0"`
+	ExpiresAt        *time.Time `json:"expires-at,omitempty" bson:"expires-at,omitempty"`
+	IsActive         bool       `json:"is-active" bson:"is-active"`
+}
+
+type UpdateCoupon struct {
+	DiscountID       string     `json:"discount-id" bson:"discount-id" validate:"required_without=Amount,excluded_with=Amount"`
+	Amount           float64    `json:"amount" bson:"amount" validate:"gte=0"`
+	MinOrderValue    float64    `json:"min-order-value" bson:"min-order-value" validate:"gte=0"`
+	UsageLimit       int        `json:"usage-limit" bson:"usage-limit" validate:"gte=0"`
+	PerCustomerLimit int        `json:"per-customer-limit" bson:"per-customer-limit" validate:"gte=0"`
+	ExpiresAt        *time.Time `json:"expires-at,omitempty" bson:"expires-at,omitempty"`
+	IsActive         bool       `json:"is-active" bson:"is-active"`
+}
+
+type RedeemCoupon struct {
+	Code       string  `json:"code" bson:"code" validate:"required"`
+	CustomerID string  `json:"customer-id" bson:"customer-id" validate:"required,excludesall=.$"`
+	OrderValue float64 `json:"order-value" bson:"order-value" validate:"required,gt=0"`
+}
+
+type ReleaseCoupon struct {
+	RedemptionID string `json:"redemption-id" bson:"redemption-id" validate:"required"`
+}
+
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+func (createCoupon *CreateCoupon) ToModel() *model.Coupon {
+	return &model.Coupon{
+		Code:             NormalizeCouponCode(createCoupon.Code),
+		DiscountID:       createCoupon.DiscountID,
+		Amount:           createCoupon.Amount,
+		MinOrderValue:    createCoupon.MinOrderValue,
+		UsageLimit:       createCoupon.UsageLimit,
+		PerCustomerLimit: createCoupon.PerCustomerLimit,
+		ExpiresAt:        createCoupon.ExpiresAt,
+		IsActive:         createCoupon.IsActive,
+	}
+}
+
+func (updateCoupon *UpdateCoupon) ToModel() *model.Coupon {
+	return &model.Coupon{
+		DiscountID:       updateCoupon.DiscountID,
+		Amount:           updateCoupon.Amount,
+		MinOrderValue:    updateCoupon.MinOrderValue,
+		UsageLimit:       updateCoupon.UsageLimit,
+		PerCustomerLimit: updateCoupon.PerCustomerLimit,
+		ExpiresAt:        updateCoupon.ExpiresAt,
+		IsActive:         updateCoupon.IsActive,
+	}
+}
@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/Meystergod/online-store/internal/domain/model"
+
+type SaveExchangeRates struct {
+	Rates []model.ExchangeRate `json:"rates" bson:"rates" validate:"required,min=1,max=100,dive"`
+}
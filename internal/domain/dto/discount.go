@@ -1,17 +1,40 @@
 package dto
 
-import "github.com/Meystergod/online-store/internal/domain/model"
+import (
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
 
 type CreateDiscount struct {
-	Title    string `json:"title" bson:"title" validate:"required"`
-	Percent  int    `json:"percent" bson:"percent" validate:"required"`
-	IsActive bool   `json:"is-active" bson:"is-active" validate:"required"`
+	Title    string     `json:"title" bson:"title" validate:"required"`
+	Percent  int        `json:"percent" bson:"percent" validate:"required,min=1,max=100"`
+	IsActive bool       `json:"is-active" bson:"is-active"`
+	StartsAt *time.Time `json:"starts-at,omitempty" bson:"starts-at,omitempty"`
+	EndsAt   *time.Time `json:"ends-at,omitempty" bson:"ends-at,omitempty"`
 }
 
 type UpdateDiscount struct {
-	Title    string `json:"title" bson:"title" validate:"required"`
-	Percent  int    `json:"percent" bson:"percent" validate:"required"`
-	IsActive bool   `json:"is-active" bson:"is-active" validate:"required"`
+	Title    string     `json:"title" bson:"title" validate:"required"`
+	Percent  int        `json:"percent" bson:"percent" validate:"required,min=1,max=100"`
+	IsActive bool       `json:"is-active" bson:"is-active"`
+	StartsAt *time.Time `json:"starts-at,omitempty" bson:"starts-at,omitempty"`
+	EndsAt   *time.Time `json:"ends-at,omitempty" bson:"ends-at,omitempty"`
+}
+
+type BulkCreateDiscounts struct {
+	Ordered bool             `json:"ordered" bson:"ordered"`
+	Items   []CreateDiscount `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkUpdateDiscount struct {
+	ID             string `json:"uuid" bson:"_id" validate:"required"`
+	UpdateDiscount `bson:",inline"`
+}
+
+type BulkUpdateDiscounts struct {
+	Ordered bool                 `json:"ordered" bson:"ordered"`
+	Items   []BulkUpdateDiscount `json:"items" bson:"items" validate:"required,min=1,max=1000"`
 }
 
 func (createDiscount *CreateDiscount) ToModel() *model.Discount {
@@ -19,6 +42,8 @@ func (createDiscount *CreateDiscount) ToModel() *model.Discount {
 		Title:    createDiscount.Title,
 		Percent:  createDiscount.Percent,
 		IsActive: createDiscount.IsActive,
+		StartsAt: createDiscount.StartsAt,
+		EndsAt:   createDiscount.EndsAt,
 	}
 }
 
@@ -27,5 +52,7 @@ func (updateDiscount *UpdateDiscount) ToModel() *model.Discount {
 		Title:    updateDiscount.Title,
 		Percent:  updateDiscount.Percent,
 		IsActive: updateDiscount.IsActive,
+		StartsAt: updateDiscount.StartsAt,
+		EndsAt:   updateDiscount.EndsAt,
 	}
 }
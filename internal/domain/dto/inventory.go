@@ -0,0 +1,27 @@
+package dto
+
+import (
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type PostStockMovement struct {
+	ProductID   string `json:"product-id" bson:"product-id" validate:"required"`
+	SKU         string `json:"sku" bson:"sku" validate:"max=64"`
+	Type        string `json:"type" bson:"type" validate:"required,oneof=receipt sale return adjustment reservation"`
+	Quantity    int    `json:"quantity" bson:"quantity" validate:"required"`
+	Reason      string `json:"reason" bson:"reason" validate:"required,max=500"`
+	OrderID     string `json:"order-id" bson:"order-id"`
+	WarehouseID string `json:"warehouse-id" bson:"warehouse-id" validate:"required"`
+}
+
+func (postStockMovement *PostStockMovement) ToModel() *model.StockMovement {
+	return &model.StockMovement{
+		ProductID:   postStockMovement.ProductID,
+		SKU:         postStockMovement.SKU,
+		Type:        postStockMovement.Type,
+		Quantity:    postStockMovement.Quantity,
+		Reason:      postStockMovement.Reason,
+		OrderID:     postStockMovement.OrderID,
+		WarehouseID: postStockMovement.WarehouseID,
+	}
+}
@@ -0,0 +1,15 @@
+package dto
+
+import "github.com/Meystergod/online-store/internal/domain/model"
+
+type SetTranslation struct {
+	Title       string `json:"title" bson:"title" validate:"required"`
+	Description string `json:"description" bson:"description"`
+}
+
+func (setTranslation *SetTranslation) ToModel() *model.Translation {
+	return &model.Translation{
+		Title:       setTranslation.Title,
+		Description: setTranslation.Description,
+	}
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type CreatePricingRule struct {
+	Title      string     `json:"title" bson:"title" validate:"required"`
+	TargetType string     `json:"target-type" bson:"target-type" validate:"required,oneof=product category subcategory tag"`
+	TargetID   string     `json:"target-id" bson:"target-id" validate:"required"`
+	Percent    int        `json:"percent" bson:"percent" validate:"required_without=Amount,excluded_with=Amount,max=100"`
+	Amount     float64    `json:"amount" bson:"amount" validate:"gte=0"`
+	Priority   int        `json:"priority" bson:"priority"`
+	Exclusive  bool       `json:"exclusive" bson:"exclusive"`
+	Final      bool       `json:"final" bson:"final"`
+	IsActive   bool       `json:"is-active" bson:"is-active"`
+	StartsAt   *time.Time `json:"starts-at,omitempty" bson:"starts-at,omitempty"`
+	EndsAt     *time.Time `json:"ends-at,omitempty" bson:"ends-at,omitempty"`
+}
+
+type UpdatePricingRule struct {
+	CreatePricingRule `bson:",inline"`
+}
+
+type PricingQuoteItem struct {
+	ProductID string `json:"product-id" bson:"product-id" validate:"required"`
+	Quantity  int    `json:"quantity" bson:"quantity" validate:"min=0"`
+}
+
+type PricingQuote struct {
+	Items []PricingQuoteItem `json:"items" bson:"items" validate:"required,min=1,max=100,dive"`
+}
+
+func (createPricingRule *CreatePricingRule) ToModel() *model.PricingRule {
+	return &model.PricingRule{
+		Title:      createPricingRule.Title,
+		TargetType: createPricingRule.TargetType,
+		TargetID:   createPricingRule.TargetID,
+		Percent:    createPricingRule.Percent,
+		Amount:     createPricingRule.Amount,
+		Priority:   createPricingRule.Priority,
+		Exclusive:  createPricingRule.Exclusive,
+		Final:      createPricingRule.Final,
+		IsActive:   createPricingRule.IsActive,
+		StartsAt:   createPricingRule.StartsAt,
+		EndsAt:     createPricingRule.EndsAt,
+	}
+}
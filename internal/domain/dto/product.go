@@ -1,47 +1,169 @@
 package dto
 
-import "github.com/Meystergod/online-store/internal/domain/model"
+import (
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
 
 type CreateProduct struct {
-	Title       string         `json:"title" bson:"title" validate:"required"`
-	Description string         `json:"description" bson:"description" validate:"required"`
-	Price       string         `json:"price" bson:"price" validate:"required"`
-	Quantity    int            `json:"quantity" bson:"quantity" validate:"required"`
-	Category    model.Category `json:"category" bson:"category,omitempty"`
-	Discount    model.Discount `json:"discount" bson:"discount,omitempty"`
-	Tags        []model.Tag    `json:"tags" bson:"description,omitempty"`
+	Title             string                 `json:"title" bson:"title" validate:"required"`
+	Description       string                 `json:"description" bson:"description" validate:"required"`
+	SKU               string                 `json:"sku" bson:"sku,omitempty" validate:"omitempty,max=64"`
+	Price             string                 `json:"price" bson:"price" validate:"required,price"`
+	Prices            map[string]string      `json:"prices" bson:"prices,omitempty" validate:"omitempty,dive,keys,len=3,uppercase,endkeys,required,price"`
+	Category          model.Category         `json:"category" bson:"category,omitempty"`
+	Subcategory       model.Subcategory      `json:"subcategory" bson:"subcategory,omitempty" validate:"-"`
+	Discount          model.Discount         `json:"discount" bson:"discount,omitempty"`
+	Tags              []model.Tag            `json:"tags" bson:"tags,omitempty"`
+	Options           []model.ProductOption  `json:"options" bson:"options,omitempty" validate:"dive"`
+	Variants          []model.Variant        `json:"variants" bson:"variants,omitempty" validate:"dive"`
+	Attributes        map[string]interface{} `json:"attributes" bson:"attributes,omitempty"`
+	LowStockThreshold *int                   `json:"low-stock-threshold" bson:"low-stock-threshold,omitempty" validate:"omitempty,min=0"`
+	Status            string                 `json:"status" bson:"status,omitempty" validate:"omitempty,oneof=draft published"`
+	PublishAt         *time.Time             `json:"publish-at,omitempty" bson:"publish-at,omitempty"`
+	UnpublishAt       *time.Time             `json:"unpublish-at,omitempty" bson:"unpublish-at,omitempty"`
 }
 
 type UpdateProduct struct {
-	Title       string         `json:"title" bson:"title" validate:"required"`
-	Description string         `json:"description" bson:"description" validate:"required"`
-	Price       string         `json:"price" bson:"price" validate:"required"`
-	Quantity    int            `json:"quantity" bson:"quantity" validate:"required"`
-	Category    model.Category `json:"category" bson:"category,omitempty"`
-	Discount    model.Discount `json:"discount" bson:"discount,omitempty"`
-	Tags        []model.Tag    `json:"tags" bson:"description,omitempty"`
+	Title             string                 `json:"title" bson:"title" validate:"required"`
+	Description       string                 `json:"description" bson:"description" validate:"required"`
+	SKU               string                 `json:"sku" bson:"sku,omitempty" validate:"omitempty,max=64"`
+	Price             string                 `json:"price" bson:"price" validate:"required,price"`
+	Prices            map[string]string      `json:"prices" bson:"prices,omitempty" validate:"omitempty,dive,keys,len=3,uppercase,endkeys,required,price"`
+	Category          model.Category         `json:"category" bson:"category,omitempty"`
+	Subcategory       model.Subcategory      `json:"subcategory" bson:"subcategory,omitempty" validate:"-"`
+	Discount          model.Discount         `json:"discount" bson:"discount,omitempty"`
+	Tags              []model.Tag            `json:"tags" bson:"tags,omitempty"`
+	Options           []model.ProductOption  `json:"options" bson:"options,omitempty" validate:"dive"`
+	Attributes        map[string]interface{} `json:"attributes" bson:"attributes,omitempty"`
+	LowStockThreshold *int                   `json:"low-stock-threshold" bson:"low-stock-threshold,omitempty" validate:"omitempty,min=0"`
+}
+
+type SetProductStatus struct {
+	Status      string     `json:"status" bson:"status" validate:"required,oneof=draft published archived"`
+	PublishAt   *time.Time `json:"publish-at,omitempty" bson:"publish-at,omitempty"`
+	UnpublishAt *time.Time `json:"unpublish-at,omitempty" bson:"unpublish-at,omitempty"`
+}
+
+type CreateVariant struct {
+	SKU        string            `json:"sku" bson:"sku" validate:"required,max=64"`
+	Price      string            `json:"price" bson:"price" validate:"omitempty,price"`
+	Attributes map[string]string `json:"attributes" bson:"attributes"`
+}
+
+type UpdateVariant struct {
+	Price      string            `json:"price" bson:"price" validate:"omitempty,price"`
+	Attributes map[string]string `json:"attributes" bson:"attributes"`
+}
+
+type ReorderImages struct {
+	IDs []string `json:"uuids" bson:"uuids" validate:"required,min=1,dive,required"`
+}
+
+type StockCheckItem struct {
+	SKU      string `json:"sku" bson:"sku" validate:"required"`
+	Quantity int    `json:"quantity" bson:"quantity" validate:"required,min=1"`
+}
+
+type StockCheck struct {
+	Items []StockCheckItem `json:"items" bson:"items" validate:"required,min=1,max=100,dive"`
+}
+
+type BulkCreateProducts struct {
+	Ordered bool            `json:"ordered" bson:"ordered"`
+	Items   []CreateProduct `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkUpdateProduct struct {
+	ID            string `json:"uuid" bson:"_id" validate:"required"`
+	UpdateProduct `bson:",inline"`
+}
+
+type BulkUpdateProducts struct {
+	Ordered bool                `json:"ordered" bson:"ordered"`
+	Items   []BulkUpdateProduct `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkProductTags struct {
+	Ordered bool     `json:"ordered" bson:"ordered"`
+	IDs     []string `json:"uuids" bson:"uuids" validate:"required,min=1,max=1000"`
+	TagIDs  []string `json:"tags" bson:"tags" validate:"required,min=1,max=100,dive,required"`
+}
+
+type SetRelatedOverride struct {
+	Pinned   []string `json:"pinned" bson:"pinned" validate:"max=20,unique,dive,required"`
+	Excluded []string `json:"excluded" bson:"excluded" validate:"max=200,dive,required"`
 }
 
 func (createDiscount *CreateProduct) ToModel() *model.Product {
+	status := createDiscount.Status
+	if status == "" {
+		status = model.ProductStatusDraft
+	}
+
+	var variants []model.Variant
+	for _, variant := range createDiscount.Variants {
+		variant.Quantity = 0
+		variant.Reserved = 0
+		variants = append(variants, variant)
+	}
+
 	return &model.Product{
-		Title:       createDiscount.Title,
-		Description: createDiscount.Description,
-		Price:       createDiscount.Price,
-		Quantity:    createDiscount.Quantity,
-		Category:    createDiscount.Category,
-		Discount:    createDiscount.Discount,
-		Tags:        createDiscount.Tags,
+		Title:             createDiscount.Title,
+		Description:       createDiscount.Description,
+		SKU:               createDiscount.SKU,
+		Price:             createDiscount.Price,
+		Prices:            createDiscount.Prices,
+		Category:          createDiscount.Category,
+		Subcategory:       createDiscount.Subcategory,
+		Discount:          createDiscount.Discount,
+		Tags:              createDiscount.Tags,
+		Options:           createDiscount.Options,
+		Variants:          variants,
+		Attributes:        createDiscount.Attributes,
+		LowStockThreshold: createDiscount.LowStockThreshold,
+		Status:            status,
+		PublishAt:         createDiscount.PublishAt,
+		UnpublishAt:       createDiscount.UnpublishAt,
 	}
 }
 
 func (updateDiscount *UpdateProduct) ToModel() *model.Product {
 	return &model.Product{
-		Title:       updateDiscount.Title,
-		Description: updateDiscount.Description,
-		Price:       updateDiscount.Price,
-		Quantity:    updateDiscount.Quantity,
-		Category:    updateDiscount.Category,
-		Discount:    updateDiscount.Discount,
-		Tags:        updateDiscount.Tags,
+		Title:             updateDiscount.Title,
+		Description:       updateDiscount.Description,
+		SKU:               updateDiscount.SKU,
+		Price:             updateDiscount.Price,
+		Prices:            updateDiscount.Prices,
+		Category:          updateDiscount.Category,
+		Subcategory:       updateDiscount.Subcategory,
+		Discount:          updateDiscount.Discount,
+		Tags:              updateDiscount.Tags,
+		Options:           updateDiscount.Options,
+		Attributes:        updateDiscount.Attributes,
+		LowStockThreshold: updateDiscount.LowStockThreshold,
+	}
+}
+
+func (createVariant *CreateVariant) ToModel() *model.Variant {
+	return &model.Variant{
+		SKU:        createVariant.SKU,
+		Price:      createVariant.Price,
+		Attributes: createVariant.Attributes,
+	}
+}
+
+func (updateVariant *UpdateVariant) ToModel() *model.Variant {
+	return &model.Variant{
+		Price:      updateVariant.Price,
+		Attributes: updateVariant.Attributes,
+	}
+}
+
+func (setRelatedOverride *SetRelatedOverride) ToModel() *model.RelatedOverride {
+	return &model.RelatedOverride{
+		Pinned:   setRelatedOverride.Pinned,
+		Excluded: setRelatedOverride.Excluded,
 	}
 }
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type CreateReview struct {
+	Rating  int    `json:"rating" bson:"rating" validate:"required,min=1,max=5"`
+	Title   string `json:"title" bson:"title" validate:"required,max=200"`
+	Body    string `json:"body" bson:"body" validate:"max=5000"`
+	Author  string `json:"author" bson:"author" validate:"required,max=100"`
+	OrderID string `json:"order-id" bson:"order-id"`
+}
+
+type UpdateReview struct {
+	Rating int    `json:"rating" bson:"rating" validate:"required,min=1,max=5"`
+	Title  string `json:"title" bson:"title" validate:"required,max=200"`
+	Body   string `json:"body" bson:"body" validate:"max=5000"`
+}
+
+type ModerateReview struct {
+	Status string `json:"status" bson:"status" validate:"required,oneof=approved rejected"`
+	Note   string `json:"note" bson:"note" validate:"max=500"`
+}
+
+func (createReview *CreateReview) ToModel() *model.Review {
+	return &model.Review{
+		Rating:  createReview.Rating,
+		Title:   strings.TrimSpace(createReview.Title),
+		Body:    strings.TrimSpace(createReview.Body),
+		Author:  strings.TrimSpace(createReview.Author),
+		OrderID: createReview.OrderID,
+		Status:  model.ReviewPending,
+	}
+}
+
+func (updateReview *UpdateReview) ToModel() *model.Review {
+	return &model.Review{
+		Rating: updateReview.Rating,
+		Title:  strings.TrimSpace(updateReview.Title),
+		Body:   strings.TrimSpace(updateReview.Body),
+		Status: model.ReviewPending,
+	}
+}
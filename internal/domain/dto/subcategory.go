@@ -7,6 +7,7 @@ import (
 type CreateSubcategory struct {
 	Title       string `json:"title" bson:"title" validate:"required"`
 	Description string `json:"description" bson:"description" validate:"required"`
+	CategoryID  string `json:"category-id" bson:"category-id"`
 }
 
 type UpdateSubcategory struct {
@@ -14,10 +15,26 @@ type UpdateSubcategory struct {
 	Description string `json:"description" bson:"description" validate:"required"`
 }
 
+type BulkCreateSubcategories struct {
+	Ordered bool                `json:"ordered" bson:"ordered"`
+	Items   []CreateSubcategory `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkUpdateSubcategory struct {
+	ID                string `json:"uuid" bson:"_id" validate:"required"`
+	UpdateSubcategory `bson:",inline"`
+}
+
+type BulkUpdateSubcategories struct {
+	Ordered bool                    `json:"ordered" bson:"ordered"`
+	Items   []BulkUpdateSubcategory `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
 func (createSubcategory *CreateSubcategory) ToModel() *model.Subcategory {
 	return &model.Subcategory{
 		Title:       createSubcategory.Title,
 		Description: createSubcategory.Description,
+		CategoryID:  createSubcategory.CategoryID,
 	}
 }
 
@@ -10,6 +10,25 @@ type UpdateTag struct {
 	Title string `json:"title" bson:"title" validate:"required"`
 }
 
+type MergeTag struct {
+	TargetID string `json:"target-id" bson:"target-id" validate:"required"`
+}
+
+type BulkCreateTags struct {
+	Ordered bool        `json:"ordered" bson:"ordered"`
+	Items   []CreateTag `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
+type BulkUpdateTag struct {
+	ID        string `json:"uuid" bson:"_id" validate:"required"`
+	UpdateTag `bson:",inline"`
+}
+
+type BulkUpdateTags struct {
+	Ordered bool            `json:"ordered" bson:"ordered"`
+	Items   []BulkUpdateTag `json:"items" bson:"items" validate:"required,min=1,max=1000"`
+}
+
 func (createTag *CreateTag) ToModel() *model.Tag {
 	return &model.Tag{
 		Title: createTag.Title,
@@ -0,0 +1,73 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type CreateWarehouse struct {
+	Code     string          `json:"code" bson:"code" validate:"required,max=32,excludesall= "`
+	Title    string          `json:"title" bson:"title" validate:"required"`
+	Address  string          `json:"address" bson:"address"`
+	Location *model.GeoPoint `json:"location" bson:"location,omitempty"`
+	Priority int             `json:"priority" bson:"priority" validate:"gte=0"`
+	IsActive bool            `json:"is-active" bson:"is-active"`
+}
+
+type UpdateWarehouse struct {
+	Title    string          `json:"title" bson:"title" validate:"required"`
+	Address  string          `json:"address" bson:"address"`
+	Location *model.GeoPoint `json:"location" bson:"location,omitempty"`
+	Priority int             `json:"priority" bson:"priority" validate:"gte=0"`
+	IsActive bool            `json:"is-active" bson:"is-active"`
+}
+
+type TransferStock struct {
+	ProductID         string `json:"product-id" bson:"product-id" validate:"required"`
+	WarehouseID       string `json:"warehouse-id" bson:"warehouse-id" validate:"required"`
+	TargetWarehouseID string `json:"target-warehouse-id" bson:"target-warehouse-id" validate:"required,nefield=WarehouseID"`
+	Quantity          int    `json:"quantity" bson:"quantity" validate:"required,min=1"`
+	Reason            string `json:"reason" bson:"reason" validate:"required,max=500"`
+}
+
+type ReserveStock struct {
+	ProductID string          `json:"product-id" bson:"product-id" validate:"required"`
+	SKU       string          `json:"sku" bson:"sku" validate:"max=64"`
+	OrderID   string          `json:"order-id" bson:"order-id" validate:"required"`
+	Quantity  int             `json:"quantity" bson:"quantity" validate:"required,min=1"`
+	Strategy  string          `json:"strategy" bson:"strategy" validate:"omitempty,oneof=nearest most-stock priority"`
+	Origin    *model.GeoPoint `json:"origin" bson:"origin,omitempty"`
+}
+
+func (createWarehouse *CreateWarehouse) ToModel() *model.Warehouse {
+	return &model.Warehouse{
+		Code:     strings.ToUpper(strings.TrimSpace(createWarehouse.Code)),
+		Title:    createWarehouse.Title,
+		Address:  createWarehouse.Address,
+		Location: createWarehouse.Location,
+		Priority: createWarehouse.Priority,
+		IsActive: createWarehouse.IsActive,
+	}
+}
+
+func (updateWarehouse *UpdateWarehouse) ToModel() *model.Warehouse {
+	return &model.Warehouse{
+		Title:    updateWarehouse.Title,
+		Address:  updateWarehouse.Address,
+		Location: updateWarehouse.Location,
+		Priority: updateWarehouse.Priority,
+		IsActive: updateWarehouse.IsActive,
+	}
+}
+
+func (transferStock *TransferStock) ToModel() *model.StockMovement {
+	return &model.StockMovement{
+		ProductID:         transferStock.ProductID,
+		Type:              model.StockMovementTransfer,
+		Quantity:          transferStock.Quantity,
+		Reason:            transferStock.Reason,
+		WarehouseID:       transferStock.WarehouseID,
+		TargetWarehouseID: transferStock.TargetWarehouseID,
+	}
+}
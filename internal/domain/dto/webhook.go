@@ -0,0 +1,35 @@
+package dto
+
+import "github.com/Meystergod/online-store/internal/domain/model"
+
+type CreateWebhook struct {
+	URL        string   `json:"url" bson:"url" validate:"required,url"`
+	Secret     string   `json:"secret" bson:"secret" validate:"required,min=16"`
+	EventTypes []string `json:"event-types" bson:"event-types"`
+	IsActive   bool     `json:"is-active" bson:"is-active"`
+}
+
+type UpdateWebhook struct {
+	URL        string   `json:"url" bson:"url" validate:"required,url"`
+	Secret     string   `json:"secret" bson:"secret" validate:"required,min=16"`
+	EventTypes []string `json:"event-types" bson:"event-types"`
+	IsActive   bool     `json:"is-active" bson:"is-active"`
+}
+
+func (createWebhook *CreateWebhook) ToModel() *model.Webhook {
+	return &model.Webhook{
+		URL:        createWebhook.URL,
+		Secret:     createWebhook.Secret,
+		EventTypes: createWebhook.EventTypes,
+		IsActive:   createWebhook.IsActive,
+	}
+}
+
+func (updateWebhook *UpdateWebhook) ToModel() *model.Webhook {
+	return &model.Webhook{
+		URL:        updateWebhook.URL,
+		Secret:     updateWebhook.Secret,
+		EventTypes: updateWebhook.EventTypes,
+		IsActive:   updateWebhook.IsActive,
+	}
+}
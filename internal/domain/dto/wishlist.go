@@ -0,0 +1,41 @@
+package dto
+
+import (
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type CreateWishlist struct {
+	CustomerID string `json:"customer-id" bson:"customer-id" validate:"required,excludesall=.$"`
+	Name       string `json:"name" bson:"name" validate:"required,max=100"`
+}
+
+type UpdateWishlist struct {
+	Name string `json:"name" bson:"name" validate:"required,max=100"`
+}
+
+type AddWishlistItem struct {
+	ProductID string `json:"product-id" bson:"product-id" validate:"required"`
+	Note      string `json:"note" bson:"note" validate:"max=500"`
+}
+
+type MoveWishlistItem struct {
+	SKU      string `json:"sku" bson:"sku" validate:"max=64"`
+	Quantity int    `json:"quantity" bson:"quantity" validate:"required,min=1,max=1000"`
+}
+
+func (createWishlist *CreateWishlist) ToModel() *model.Wishlist {
+	return &model.Wishlist{
+		CustomerID: createWishlist.CustomerID,
+		Name:       strings.TrimSpace(createWishlist.Name),
+		Items:      []model.WishlistItem{},
+	}
+}
+
+func (addWishlistItem *AddWishlistItem) ToModel() *model.WishlistItem {
+	return &model.WishlistItem{
+		ProductID: addWishlistItem.ProductID,
+		Note:      strings.TrimSpace(addWishlistItem.Note),
+	}
+}
@@ -0,0 +1,116 @@
+package model
+
+import "fmt"
+
+const (
+	AttributeTypeString  = "string"
+	AttributeTypeNumber  = "number"
+	AttributeTypeBoolean = "boolean"
+	AttributeTypeEnum    = "enum"
+)
+
+type AttributeDefinition struct {
+	Name     string   `json:"name" bson:"name" validate:"required,excludesall=.$"`
+	Type     string   `json:"type" bson:"type" validate:"required,oneof=string number boolean enum"`
+	Unit     string   `json:"unit,omitempty" bson:"unit,omitempty"`
+	Values   []string `json:"values,omitempty" bson:"values,omitempty" validate:"required_if=Type enum,dive,required"`
+	Required bool     `json:"required" bson:"required"`
+}
+
+type AttributeViolation struct {
+	ProductID string `json:"product-id"`
+	Error     string `json:"error"`
+}
+
+type AttributeFilter struct {
+	Name   string
+	Values []string
+	Min    *float64
+	Max    *float64
+}
+
+func (definition *AttributeDefinition) Check(value interface{}) error {
+	switch definition.Type {
+	case AttributeTypeNumber:
+		if _, ok := value.(float64); !ok {
+			return fmt.Errorf("attribute %q must be a number", definition.Name)
+		}
+	case AttributeTypeBoolean:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("attribute %q must be a boolean", definition.Name)
+		}
+	default:
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("attribute %q must be a string", definition.Name)
+		}
+
+		if len(definition.Values) > 0 && !definition.allows(text) {
+			return fmt.Errorf("value %q is not allowed for attribute %q", text, definition.Name)
+		}
+	}
+
+	return nil
+}
+
+func (definition *AttributeDefinition) allows(value string) bool {
+	for _, allowed := range definition.Values {
+		if allowed == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (category *Category) ValidateAttributes(attributes map[string]interface{}) error {
+	definitions := make(map[string]*AttributeDefinition, len(category.Attributes))
+	for i := range category.Attributes {
+		definitions[category.Attributes[i].Name] = &category.Attributes[i]
+	}
+
+	for name, value := range attributes {
+		definition, ok := definitions[name]
+		if !ok {
+			return fmt.Errorf("attribute %q is not defined for the category", name)
+		}
+
+		if err := definition.Check(value); err != nil {
+			return err
+		}
+	}
+
+	for _, definition := range category.Attributes {
+		if _, ok := attributes[definition.Name]; definition.Required && !ok {
+			return fmt.Errorf("attribute %q is required", definition.Name)
+		}
+	}
+
+	return nil
+}
+
+func (category *Category) AttributeViolations(products []Product) []AttributeViolation {
+	violations := make([]AttributeViolation, 0)
+
+	for _, product := range products {
+		if err := category.ValidateAttributes(product.Attributes); err != nil {
+			violations = append(violations, AttributeViolation{ProductID: product.ID, Error: err.Error()})
+		}
+	}
+
+	return violations
+}
+
+func ValidateAttributeDefinitions(definitions []AttributeDefinition) error {
+	seen := make(map[string]bool, len(definitions))
+
+	for _, definition := range definitions {
+		if seen[definition.Name] {
+			return fmt.Errorf("attribute %q is defined twice", definition.Name)
+		}
+
+		seen[definition.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+const (
+	AuditOperationCreate  = "create"
+	AuditOperationUpdate  = "update"
+	AuditOperationDelete  = "delete"
+	AuditOperationRestore = "restore"
+)
+
+type AuditEntry struct {
+	ID         string        `json:"uuid" bson:"_id,omitempty"`
+	Actor      string        `json:"actor" bson:"actor"`
+	EntityType string        `json:"entity-type" bson:"entity-type"`
+	EntityID   string        `json:"entity-id" bson:"entity-id"`
+	Operation  string        `json:"operation" bson:"operation"`
+	Changes    []AuditChange `json:"changes,omitempty" bson:"changes,omitempty"`
+	RemoteAddr string        `json:"remote-addr,omitempty" bson:"remote-addr,omitempty"`
+	RequestID  string        `json:"request-id,omitempty" bson:"request-id,omitempty"`
+	Timestamp  time.Time     `json:"timestamp" bson:"timestamp"`
+}
+
+type AuditChange struct {
+	Field  string      `json:"field" bson:"field"`
+	Before interface{} `json:"before" bson:"before"`
+	After  interface{} `json:"after" bson:"after"`
+}
+
+type AuditFilter struct {
+	EntityType string
+	EntityID   string
+	Actor      string
+	From       *time.Time
+	To         *time.Time
+	Limit      int64
+}
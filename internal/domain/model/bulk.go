@@ -0,0 +1,19 @@
+package model
+
+const (
+	BulkStatusCreated  = "created"
+	BulkStatusUpdated  = "updated"
+	BulkStatusDeleted  = "deleted"
+	BulkStatusConflict = "conflict"
+	BulkStatusInvalid  = "invalid"
+	BulkStatusNotFound = "not-found"
+	BulkStatusFailed   = "failed"
+	BulkStatusSkipped  = "skipped"
+)
+
+type BulkResult struct {
+	Index  int    `json:"index" bson:"index"`
+	ID     string `json:"uuid,omitempty" bson:"uuid,omitempty"`
+	Status string `json:"status" bson:"status"`
+	Error  string `json:"error,omitempty" bson:"error,omitempty"`
+}
@@ -0,0 +1,81 @@
+package model
+
+import "time"
+
+type Cart struct {
+	ID         string     `json:"uuid,omitempty" bson:"_id,omitempty"`
+	CustomerID string     `json:"customer-id" bson:"customer-id"`
+	Items      []CartItem `json:"items" bson:"items"`
+	Currency   string     `json:"currency,omitempty" bson:"-"`
+	Total      float64    `json:"total" bson:"-"`
+	CreatedAt  time.Time  `json:"created-at" bson:"created-at"`
+	UpdatedAt  time.Time  `json:"updated-at" bson:"updated-at"`
+}
+
+type CartItem struct {
+	SKU         string      `json:"sku" bson:"sku"`
+	ProductID   string      `json:"product-id" bson:"product-id"`
+	Quantity    int         `json:"quantity" bson:"quantity"`
+	AddedAt     time.Time   `json:"added-at" bson:"added-at"`
+	Product     *Product    `json:"product,omitempty" bson:"-"`
+	Pricing     *PriceQuote `json:"pricing,omitempty" bson:"-"`
+	Available   int         `json:"available" bson:"-"`
+	InStock     bool        `json:"in-stock" bson:"-"`
+	Unavailable bool        `json:"unavailable" bson:"-"`
+}
+
+func (cart *Cart) ProductIDs() []string {
+	ids := make([]string, 0, len(cart.Items))
+	for _, item := range cart.Items {
+		ids = append(ids, item.ProductID)
+	}
+
+	return ids
+}
+
+func (cart *Cart) Resolve(products []Product) {
+	byID := make(map[string]*Product, len(products))
+	for i := range products {
+		if products[i].IsPublished() {
+			byID[products[i].ID] = &products[i]
+		}
+	}
+
+	for i := range cart.Items {
+		item := &cart.Items[i]
+
+		item.Product = byID[item.ProductID]
+		if item.Product == nil {
+			item.Unavailable = true
+			continue
+		}
+
+		available, ok := item.Product.AvailableSKU(item.SKU)
+		item.Available = available
+		item.InStock = ok && available >= item.Quantity
+		item.Unavailable = !ok
+	}
+}
+
+func (cart *Cart) Priceable() ([]Product, []int, []int) {
+	products := make([]Product, 0, len(cart.Items))
+	quantities := make([]int, 0, len(cart.Items))
+	positions := make([]int, 0, len(cart.Items))
+
+	for i, item := range cart.Items {
+		if item.Unavailable {
+			continue
+		}
+
+		product := *item.Product
+		if variant, ok := product.Variant(item.SKU); ok {
+			product.Price = variant.EffectivePrice(product.Price)
+		}
+
+		products = append(products, product)
+		quantities = append(quantities, item.Quantity)
+		positions = append(positions, i)
+	}
+
+	return products, quantities, positions
+}
@@ -1,8 +1,76 @@
 package model
 
+import (
+	"strings"
+	"time"
+)
+
+const (
+	BreadcrumbCategory    = "category"
+	BreadcrumbSubcategory = "subcategory"
+)
+
 type Category struct {
-	ID            string        `json:"uuid" bson:"_id,omitempty"`
-	Title         string        `json:"title" bson:"title" validate:"required"`
-	Description   string        `json:"description" bson:"description" validate:"required"`
-	Subcategories []Subcategory `json:"subcategories" bson:"subcategories" validate:"required"`
+	ID                string                 `json:"uuid" bson:"_id,omitempty"`
+	Title             string                 `json:"title" bson:"title" validate:"required"`
+	Slug              string                 `json:"slug" bson:"slug,omitempty"`
+	SlugHistory       []string               `json:"slug-history,omitempty" bson:"slug-history,omitempty"`
+	Description       string                 `json:"description" bson:"description" validate:"required"`
+	ParentID          string                 `json:"parent-id,omitempty" bson:"parent-id,omitempty"`
+	Path              string                 `json:"path" bson:"path"`
+	Subcategories     []Subcategory          `json:"subcategories" bson:"subcategories" validate:"required"`
+	Attributes        []AttributeDefinition  `json:"attributes,omitempty" bson:"attributes,omitempty"`
+	LowStockThreshold *int                   `json:"low-stock-threshold,omitempty" bson:"low-stock-threshold,omitempty"`
+	Translations      map[string]Translation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Locale            string                 `json:"locale,omitempty" bson:"-"`
+	DeletedAt         *time.Time             `json:"deleted-at,omitempty" bson:"deleted-at,omitempty"`
+}
+
+type CategoryNode struct {
+	Category
+	Children []CategoryNode `json:"children"`
+}
+
+type Breadcrumb struct {
+	ID    string `json:"uuid"`
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+func (category *Category) ChildPath() string {
+	path := category.Path
+	if path == "" {
+		path = "/"
+	}
+
+	return path + category.ID + "/"
+}
+
+func (category *Category) AncestorIDs() []string {
+	return strings.FieldsFunc(category.Path, func(r rune) bool { return r == '/' })
+}
+
+func (category *Category) IsDescendantOf(uuid string) bool {
+	return strings.Contains(category.Path, "/"+uuid+"/")
+}
+
+func BuildCategoryTree(categories []Category, parentID string) []CategoryNode {
+	children := make(map[string][]Category, len(categories))
+	for _, category := range categories {
+		children[category.ParentID] = append(children[category.ParentID], category)
+	}
+
+	return buildCategoryNodes(children, parentID)
+}
+
+func buildCategoryNodes(children map[string][]Category, parentID string) []CategoryNode {
+	nodes := make([]CategoryNode, 0, len(children[parentID]))
+	for _, category := range children[parentID] {
+		nodes = append(nodes, CategoryNode{
+			Category: category,
+			Children: buildCategoryNodes(children, category.ID),
+		})
+	}
+
+	return nodes
 }
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	CouponRejectNotFound           = "not-found"
+	CouponRejectInactive           = "inactive"
+	CouponRejectExpired            = "expired"
+	CouponRejectDiscountInactive   = "discount-inactive"
+	CouponRejectMinOrderValue      = "min-order-value"
+	CouponRejectUsageLimit         = "usage-limit"
+	CouponRejectCustomerUsageLimit = "customer-usage-limit"
+)
+
+type Coupon struct {
+	ID               string     `json:"uuid" bson:"_id,omitempty"`
+	Code             string     `json:"code" bson:"code" validate:"required"`
+	DiscountID       string     `json:"discount-id,omitempty" bson:"discount-id,omitempty"`
+	Amount           float64    `json:"amount,omitempty" bson:"amount,omitempty"`
+	MinOrderValue    float64    `json:"min-order-value" bson:"min-order-value"`
+	UsageLimit       int        `json:"usage-limit" bson:"usage-limit"`
+	PerCustomerLimit int        `json:"per-customer-limit" bson:"per-customer-limit"`
+	Used             int        `json:"used" bson:"used"`
+	ExpiresAt        *time.Time `json:"expires-at,omitempty" bson:"expires-at,omitempty"`
+	IsActive         bool       `json:"is-active" bson:"is-active"`
+	CreatedAt        time.Time  `json:"created-at" bson:"created-at"`
+}
+
+type CouponRedemption struct {
+	ID         string    `json:"uuid" bson:"_id,omitempty"`
+	CouponID   string    `json:"coupon-id" bson:"coupon-id"`
+	Code       string    `json:"code" bson:"code"`
+	CustomerID string    `json:"customer-id" bson:"customer-id"`
+	Slot       *int      `json:"-" bson:"slot,omitempty"`
+	OrderValue float64   `json:"order-value" bson:"order-value"`
+	RedeemedAt time.Time `json:"redeemed-at" bson:"redeemed-at"`
+}
+
+type CouponCheck struct {
+	Code         string  `json:"code"`
+	RedemptionID string  `json:"redemption-id,omitempty"`
+	Valid        bool    `json:"valid"`
+	Reason       string  `json:"reason,omitempty"`
+	Discount     float64 `json:"discount"`
+	Total        float64 `json:"total"`
+}
+
+func (coupon *Coupon) Reject(customerRedemptions int, orderValue float64, now time.Time) string {
+	switch {
+	case !coupon.IsActive:
+		return CouponRejectInactive
+	case coupon.ExpiresAt != nil && !now.Before(*coupon.ExpiresAt):
+		return CouponRejectExpired
+	case orderValue < coupon.MinOrderValue:
+		return CouponRejectMinOrderValue
+	case coupon.UsageLimit > 0 && coupon.Used >= coupon.UsageLimit:
+		return CouponRejectUsageLimit
+	case coupon.PerCustomerLimit > 0 && customerRedemptions >= coupon.PerCustomerLimit:
+		return CouponRejectCustomerUsageLimit
+	}
+
+	return ""
+}
+
+func (coupon *Coupon) Apply(orderValue float64, discount *Discount) CouponCheck {
+	amount := coupon.Amount
+	if discount != nil {
+		amount = orderValue * float64(discount.Percent) / 100
+	}
+
+	amount = math.Round(math.Min(amount, orderValue)*100) / 100
+
+	return CouponCheck{
+		Code:     coupon.Code,
+		Valid:    true,
+		Discount: amount,
+		Total:    math.Round((orderValue-amount)*100) / 100,
+	}
+}
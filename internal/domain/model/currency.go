@@ -0,0 +1,41 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	RoundingHalfUp = "half-up"
+	RoundingUp     = "up"
+	RoundingDown   = "down"
+)
+
+const roundingEpsilon = 1e-9
+
+type ExchangeRate struct {
+	Currency  string    `json:"currency" bson:"_id" validate:"required,len=3,uppercase"`
+	Rate      float64   `json:"rate" bson:"rate" validate:"required,gt=0"`
+	Precision int       `json:"precision" bson:"precision" validate:"min=0,max=4"`
+	Rounding  string    `json:"rounding" bson:"rounding" validate:"omitempty,oneof=half-up up down"`
+	UpdatedAt time.Time `json:"updated-at" bson:"updated-at"`
+}
+
+type Currencies struct {
+	Base      string         `json:"base"`
+	Supported []string       `json:"supported"`
+	Rates     []ExchangeRate `json:"rates"`
+}
+
+func (rate *ExchangeRate) Round(value float64) float64 {
+	scale := math.Pow(10, float64(rate.Precision))
+
+	switch rate.Rounding {
+	case RoundingUp:
+		return math.Ceil(value*scale-roundingEpsilon) / scale
+	case RoundingDown:
+		return math.Floor(value*scale+roundingEpsilon) / scale
+	}
+
+	return math.Round(value*scale) / scale
+}
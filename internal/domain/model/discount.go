@@ -1,8 +1,34 @@
 package model
 
+import "time"
+
 type Discount struct {
-	ID       string `json:"uuid" bson:"_id,omitempty"`
-	Title    string `json:"title" bson:"title" validate:"required"`
-	Percent  int    `json:"percent" bson:"percent" validate:"required"`
-	IsActive bool   `json:"is-active" bson:"is-active" validate:"required"`
+	ID          string     `json:"uuid" bson:"_id,omitempty"`
+	Title       string     `json:"title" bson:"title" validate:"required"`
+	Percent     int        `json:"percent" bson:"percent" validate:"required,min=1,max=100"`
+	IsActive    bool       `json:"is-active" bson:"is-active"`
+	StartsAt    *time.Time `json:"starts-at,omitempty" bson:"starts-at,omitempty"`
+	EndsAt      *time.Time `json:"ends-at,omitempty" bson:"ends-at,omitempty"`
+	ActivatedAt *time.Time `json:"activated-at,omitempty" bson:"activated-at,omitempty"`
+	DeletedAt   *time.Time `json:"deleted-at,omitempty" bson:"deleted-at,omitempty"`
+}
+
+func (discount *Discount) ValidWindow() bool {
+	return discount.StartsAt == nil || discount.EndsAt == nil || discount.EndsAt.After(*discount.StartsAt)
+}
+
+func (discount *Discount) ActiveAt(now time.Time) bool {
+	if !discount.IsActive {
+		return false
+	}
+
+	if discount.StartsAt != nil && now.Before(*discount.StartsAt) {
+		return false
+	}
+
+	if discount.EndsAt != nil && !now.Before(*discount.EndsAt) {
+		return false
+	}
+
+	return true
 }
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	EventActionCreated      = "created"
+	EventActionUpdated      = "updated"
+	EventActionDeleted      = "deleted"
+	EventActionRestored     = "restored"
+	EventActionPurged       = "purged"
+	EventActionActivated    = "activated"
+	EventActionDeactivated  = "deactivated"
+	EventActionMoved        = "moved"
+	EventActionStockChanged = "stock-changed"
+	EventActionModerated    = "moderated"
+	EventActionMerged       = "merged"
+	EventActionPublished    = "published"
+	EventActionUnpublished  = "unpublished"
+	EventActionArchived     = "archived"
+	EventActionRedeemed     = "redeemed"
+	EventActionReleased     = "released"
+)
+
+type Event struct {
+	ID         string      `json:"uuid" bson:"_id,omitempty"`
+	Type       string      `json:"type" bson:"type"`
+	EntityType string      `json:"entity-type" bson:"entity-type"`
+	EntityID   string      `json:"entity-id" bson:"entity-id"`
+	Data       interface{} `json:"data,omitempty" bson:"data,omitempty"`
+	OccurredAt time.Time   `json:"occurred-at" bson:"occurred-at"`
+}
+
+func EventType(entityType string, action string) string {
+	return entityType + "." + action
+}
@@ -0,0 +1,27 @@
+package model
+
+var DefaultPriceBoundaries = []float64{0, 10, 25, 50, 100, 250, 500, 1000}
+
+type FacetBucket struct {
+	ID    string `json:"uuid" bson:"_id"`
+	Title string `json:"title" bson:"title"`
+	Count int64  `json:"count" bson:"count"`
+}
+
+type PriceBucket struct {
+	Min   float64  `json:"min"`
+	Max   *float64 `json:"max,omitempty"`
+	Count int64    `json:"count"`
+}
+
+type ProductFacets struct {
+	Total         int64         `json:"total"`
+	Offset        int64         `json:"offset"`
+	Limit         int64         `json:"limit"`
+	Products      []Product     `json:"products"`
+	Categories    []FacetBucket `json:"categories"`
+	Subcategories []FacetBucket `json:"subcategories"`
+	Tags          []FacetBucket `json:"tags"`
+	Discounts     []FacetBucket `json:"discounts"`
+	Prices        []PriceBucket `json:"prices"`
+}
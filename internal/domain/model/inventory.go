@@ -0,0 +1,112 @@
+package model
+
+import "time"
+
+const (
+	StockMovementReceipt     = "receipt"
+	StockMovementSale        = "sale"
+	StockMovementReturn      = "return"
+	StockMovementAdjustment  = "adjustment"
+	StockMovementReservation = "reservation"
+	StockMovementTransfer    = "transfer"
+	StockMovementOpening     = "opening"
+
+	StockAlertOpen     = "open"
+	StockAlertResolved = "resolved"
+)
+
+type StockMovement struct {
+	ID                string    `json:"uuid" bson:"_id,omitempty"`
+	ProductID         string    `json:"product-id" bson:"product-id"`
+	SKU               string    `json:"sku,omitempty" bson:"sku,omitempty"`
+	Type              string    `json:"type" bson:"type"`
+	Quantity          int       `json:"quantity" bson:"quantity"`
+	Reason            string    `json:"reason" bson:"reason"`
+	OrderID           string    `json:"order-id,omitempty" bson:"order-id,omitempty"`
+	WarehouseID       string    `json:"warehouse-id,omitempty" bson:"warehouse-id,omitempty"`
+	TargetWarehouseID string    `json:"target-warehouse-id,omitempty" bson:"target-warehouse-id,omitempty"`
+	Actor             string    `json:"actor,omitempty" bson:"actor,omitempty"`
+	CreatedAt         time.Time `json:"created-at" bson:"created-at"`
+}
+
+type StockLevel struct {
+	ProductID string `json:"product-id"`
+	OnHand    int    `json:"on-hand"`
+	Reserved  int    `json:"reserved"`
+	Available int    `json:"available"`
+}
+
+func (movement *StockMovement) Deltas() (int, int) {
+	switch movement.Type {
+	case StockMovementReceipt, StockMovementReturn:
+		return movement.Quantity, 0
+	case StockMovementSale:
+		if movement.OrderID != "" {
+			return -movement.Quantity, -movement.Quantity
+		}
+
+		return -movement.Quantity, 0
+	case StockMovementReservation:
+		return 0, movement.Quantity
+	case StockMovementTransfer:
+		return 0, 0
+	default:
+		return movement.Quantity, 0
+	}
+}
+
+func (movement *StockMovement) Valid() bool {
+	if movement.WarehouseID == "" {
+		return false
+	}
+
+	switch movement.Type {
+	case StockMovementReceipt, StockMovementSale, StockMovementReturn:
+		return movement.Quantity > 0
+	case StockMovementTransfer:
+		return movement.Quantity > 0 && movement.TargetWarehouseID != "" && movement.WarehouseID != movement.TargetWarehouseID
+	default:
+		return movement.Quantity != 0
+	}
+}
+
+func (product *Product) StockLevel() StockLevel {
+	return StockLevel{
+		ProductID: product.ID,
+		OnHand:    product.Quantity,
+		Reserved:  product.Reserved,
+		Available: product.Quantity - product.Reserved,
+	}
+}
+
+type StockAlert struct {
+	ID         string     `json:"uuid" bson:"_id,omitempty"`
+	ProductID  string     `json:"product-id" bson:"product-id"`
+	Title      string     `json:"title" bson:"title"`
+	Quantity   int        `json:"quantity" bson:"quantity"`
+	Threshold  int        `json:"threshold" bson:"threshold"`
+	Status     string     `json:"status" bson:"status"`
+	CreatedAt  time.Time  `json:"created-at" bson:"created-at"`
+	NotifiedAt *time.Time `json:"notified-at,omitempty" bson:"notified-at,omitempty"`
+	ResolvedAt *time.Time `json:"resolved-at,omitempty" bson:"resolved-at,omitempty"`
+}
+
+type LowStockItem struct {
+	ProductID string `json:"product-id" bson:"_id"`
+	Title     string `json:"title" bson:"title"`
+	Quantity  int    `json:"quantity" bson:"quantity"`
+	Reserved  int    `json:"reserved" bson:"reserved"`
+	Threshold int    `json:"threshold" bson:"threshold"`
+}
+
+func (product *Product) StockThreshold(category *Category) (int, bool) {
+	if product.LowStockThreshold != nil {
+		return *product.LowStockThreshold, true
+	}
+
+	if category != nil && category.LowStockThreshold != nil {
+		return *category.LowStockThreshold, true
+	}
+
+	return 0, false
+}
@@ -0,0 +1,83 @@
+package model
+
+type Translation struct {
+	Title       string `json:"title" bson:"title"`
+	Description string `json:"description,omitempty" bson:"description,omitempty"`
+}
+
+type LocaleChain struct {
+	Preferred []string
+	Default   string
+}
+
+func (chain LocaleChain) apply(title *string, description *string, translations map[string]Translation) string {
+	for _, locale := range chain.Preferred {
+		translation, ok := translations[locale]
+		if !ok || translation.Title == "" {
+			continue
+		}
+
+		*title = translation.Title
+		if description != nil && translation.Description != "" {
+			*description = translation.Description
+		}
+
+		return locale
+	}
+
+	return chain.Default
+}
+
+func (product *Product) Localize(chain LocaleChain) {
+	product.Locale = chain.apply(&product.Title, &product.Description, product.Translations)
+
+	product.Category.Localize(chain)
+	product.Subcategory.Localize(chain)
+
+	for i := range product.Tags {
+		product.Tags[i].Localize(chain)
+	}
+}
+
+func (category *Category) Localize(chain LocaleChain) {
+	if category.ID == "" {
+		return
+	}
+
+	category.Locale = chain.apply(&category.Title, &category.Description, category.Translations)
+
+	for i := range category.Subcategories {
+		category.Subcategories[i].Localize(chain)
+	}
+}
+
+func (subcategory *Subcategory) Localize(chain LocaleChain) {
+	if subcategory.ID == "" {
+		return
+	}
+
+	subcategory.Locale = chain.apply(&subcategory.Title, &subcategory.Description, subcategory.Translations)
+}
+
+func (tag *Tag) Localize(chain LocaleChain) {
+	if tag.ID == "" {
+		return
+	}
+
+	tag.Locale = chain.apply(&tag.Title, nil, tag.Translations)
+}
+
+func WithTranslation(translations map[string]Translation, locale string, translation *Translation) map[string]Translation {
+	updated := make(map[string]Translation, len(translations)+1)
+	for key, value := range translations {
+		updated[key] = value
+	}
+
+	if translation == nil {
+		delete(updated, locale)
+	} else {
+		updated[locale] = *translation
+	}
+
+	return updated
+}
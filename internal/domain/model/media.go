@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+type Thumbnail struct {
+	Size   int    `json:"size" bson:"size"`
+	Key    string `json:"-" bson:"key"`
+	URL    string `json:"url" bson:"url"`
+	Width  int    `json:"width" bson:"width"`
+	Height int    `json:"height" bson:"height"`
+}
+
+type ProductImage struct {
+	ID          string      `json:"uuid" bson:"_id"`
+	Key         string      `json:"-" bson:"key"`
+	URL         string      `json:"url" bson:"url"`
+	ContentType string      `json:"content-type" bson:"content-type"`
+	Size        int64       `json:"size" bson:"size"`
+	Width       int         `json:"width" bson:"width"`
+	Height      int         `json:"height" bson:"height"`
+	Position    int         `json:"position" bson:"position"`
+	Thumbnails  []Thumbnail `json:"thumbnails" bson:"thumbnails"`
+	CreatedAt   time.Time   `json:"created-at" bson:"created-at"`
+}
+
+func (image *ProductImage) Keys() []string {
+	keys := make([]string, 0, len(image.Thumbnails)+1)
+	keys = append(keys, image.Key)
+
+	for _, thumbnail := range image.Thumbnails {
+		keys = append(keys, thumbnail.Key)
+	}
+
+	return keys
+}
@@ -0,0 +1,61 @@
+package model
+
+import "time"
+
+const OrderStatusPlaced = "placed"
+
+type Order struct {
+	ID         string      `json:"uuid" bson:"_id,omitempty"`
+	CustomerID string      `json:"customer-id" bson:"customer-id"`
+	Currency   string      `json:"currency" bson:"currency"`
+	Items      []OrderItem `json:"items" bson:"items"`
+	Total      float64     `json:"total" bson:"total"`
+	Status     string      `json:"status" bson:"status"`
+	CreatedAt  time.Time   `json:"created-at" bson:"created-at"`
+}
+
+type OrderItem struct {
+	SKU       string  `json:"sku" bson:"sku"`
+	ProductID string  `json:"product-id" bson:"product-id"`
+	Title     string  `json:"title" bson:"title"`
+	Quantity  int     `json:"quantity" bson:"quantity"`
+	UnitPrice float64 `json:"unit-price" bson:"unit-price"`
+	Total     float64 `json:"total" bson:"total"`
+}
+
+func (cart *Cart) Ready() bool {
+	if len(cart.Items) == 0 {
+		return false
+	}
+
+	for _, item := range cart.Items {
+		if item.Unavailable || !item.InStock || item.Pricing == nil || item.Pricing.Error != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cart *Cart) Order() *Order {
+	order := &Order{
+		CustomerID: cart.CustomerID,
+		Currency:   cart.Currency,
+		Items:      make([]OrderItem, 0, len(cart.Items)),
+		Total:      cart.Total,
+		Status:     OrderStatusPlaced,
+	}
+
+	for _, item := range cart.Items {
+		order.Items = append(order.Items, OrderItem{
+			SKU:       item.SKU,
+			ProductID: item.ProductID,
+			Title:     item.Product.Title,
+			Quantity:  item.Quantity,
+			UnitPrice: item.Pricing.FinalPrice,
+			Total:     item.Pricing.Total,
+		})
+	}
+
+	return order
+}
@@ -0,0 +1,24 @@
+package model
+
+import "time"
+
+const (
+	OutboxMessagePending   = "pending"
+	OutboxMessagePublished = "published"
+)
+
+type OutboxMessage struct {
+	ID            string     `json:"uuid" bson:"_id,omitempty"`
+	Event         Event      `json:"event" bson:"event"`
+	Status        string     `json:"status" bson:"status"`
+	Attempts      int        `json:"attempts" bson:"attempts"`
+	LastError     string     `json:"last-error,omitempty" bson:"last-error,omitempty"`
+	NextAttemptAt time.Time  `json:"next-attempt-at" bson:"next-attempt-at"`
+	CreatedAt     time.Time  `json:"created-at" bson:"created-at"`
+	PublishedAt   *time.Time `json:"published-at,omitempty" bson:"published-at,omitempty"`
+}
+
+type OutboxStats struct {
+	Pending       int64      `json:"pending" bson:"pending"`
+	OldestPending *time.Time `json:"oldest-pending,omitempty" bson:"oldest-pending,omitempty"`
+}
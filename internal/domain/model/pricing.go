@@ -0,0 +1,102 @@
+package model
+
+import "time"
+
+const (
+	PricingTargetProduct     = "product"
+	PricingTargetCategory    = "category"
+	PricingTargetSubcategory = "subcategory"
+	PricingTargetTag         = "tag"
+)
+
+const (
+	PricingSkipInactive  = "inactive"
+	PricingSkipExclusive = "exclusive"
+	PricingSkipStopped   = "stopped"
+)
+
+type PricingRule struct {
+	ID         string     `json:"uuid" bson:"_id,omitempty"`
+	Title      string     `json:"title" bson:"title" validate:"required"`
+	TargetType string     `json:"target-type" bson:"target-type" validate:"required,oneof=product category subcategory tag"`
+	TargetID   string     `json:"target-id" bson:"target-id" validate:"required"`
+	Percent    int        `json:"percent,omitempty" bson:"percent,omitempty" validate:"min=0,max=100"`
+	Amount     float64    `json:"amount,omitempty" bson:"amount,omitempty" validate:"gte=0"`
+	Priority   int        `json:"priority" bson:"priority"`
+	Exclusive  bool       `json:"exclusive" bson:"exclusive"`
+	Final      bool       `json:"final" bson:"final"`
+	IsActive   bool       `json:"is-active" bson:"is-active"`
+	StartsAt   *time.Time `json:"starts-at,omitempty" bson:"starts-at,omitempty"`
+	EndsAt     *time.Time `json:"ends-at,omitempty" bson:"ends-at,omitempty"`
+	CreatedAt  time.Time  `json:"created-at" bson:"created-at"`
+}
+
+type PricingTarget struct {
+	Type string
+	ID   string
+}
+
+type PriceAdjustment struct {
+	RuleID     string  `json:"rule-id,omitempty"`
+	Title      string  `json:"title"`
+	TargetType string  `json:"target-type"`
+	TargetID   string  `json:"target-id"`
+	Priority   int     `json:"priority"`
+	Applied    bool    `json:"applied"`
+	Skipped    string  `json:"skipped,omitempty"`
+	Amount     float64 `json:"amount"`
+	PriceAfter float64 `json:"price-after"`
+}
+
+type PriceQuote struct {
+	ProductID  string            `json:"product-id,omitempty"`
+	Currency   string            `json:"currency,omitempty"`
+	Quantity   int               `json:"quantity,omitempty"`
+	BasePrice  float64           `json:"base-price"`
+	FinalPrice float64           `json:"final-price"`
+	Total      float64           `json:"total,omitempty"`
+	Trace      []PriceAdjustment `json:"trace"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func (rule *PricingRule) ActiveAt(now time.Time) bool {
+	if !rule.IsActive {
+		return false
+	}
+
+	if rule.StartsAt != nil && now.Before(*rule.StartsAt) {
+		return false
+	}
+
+	if rule.EndsAt != nil && !now.Before(*rule.EndsAt) {
+		return false
+	}
+
+	return true
+}
+
+func (product *Product) PricingTargets() []PricingTarget {
+	targets := []PricingTarget{{Type: PricingTargetProduct, ID: product.ID}}
+
+	if product.Category.ID != "" {
+		targets = append(targets, PricingTarget{Type: PricingTargetCategory, ID: product.Category.ID})
+	}
+
+	if product.Subcategory.ID != "" {
+		targets = append(targets, PricingTarget{Type: PricingTargetSubcategory, ID: product.Subcategory.ID})
+	}
+
+	for _, tag := range product.Tags {
+		if tag.ID != "" {
+			targets = append(targets, PricingTarget{Type: PricingTargetTag, ID: tag.ID})
+		}
+	}
+
+	return targets
+}
+
+type OrderQuote struct {
+	Currency string       `json:"currency"`
+	Items    []PriceQuote `json:"items"`
+	Total    float64      `json:"total"`
+}
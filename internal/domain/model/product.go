@@ -1,13 +1,98 @@
 package model
 
+import "time"
+
 type Product struct {
-	ID          string      `json:"uuid" bson:"_id,omitempty"`
-	Title       string      `json:"title" bson:"title" validate:"required"`
-	Description string      `json:"description" bson:"description" validate:"required"`
-	Price       string      `json:"price" bson:"price" validate:"required"`
-	Quantity    int         `json:"quantity" bson:"quantity" validate:"required"`
-	Category    Category    `json:"category" bson:"category,omitempty"`
-	Subcategory Subcategory `json:"subcategory" bson:"subcategory,omitempty"`
-	Discount    Discount    `json:"discount" bson:"discount,omitempty"`
-	Tags        []Tag       `json:"tags" bson:"description,omitempty"`
+	ID                string                 `json:"uuid" bson:"_id,omitempty"`
+	Title             string                 `json:"title" bson:"title" validate:"required"`
+	Slug              string                 `json:"slug" bson:"slug,omitempty"`
+	SKU               string                 `json:"sku,omitempty" bson:"sku,omitempty"`
+	SlugHistory       []string               `json:"slug-history,omitempty" bson:"slug-history,omitempty"`
+	Description       string                 `json:"description" bson:"description" validate:"required"`
+	Price             string                 `json:"price" bson:"price" validate:"required"`
+	Prices            map[string]string      `json:"prices,omitempty" bson:"prices,omitempty"`
+	Quantity          int                    `json:"quantity" bson:"quantity" validate:"required"`
+	Reserved          int                    `json:"reserved" bson:"reserved"`
+	LowStockThreshold *int                   `json:"low-stock-threshold,omitempty" bson:"low-stock-threshold,omitempty"`
+	Category          Category               `json:"category" bson:"category,omitempty"`
+	Subcategory       Subcategory            `json:"subcategory" bson:"subcategory,omitempty"`
+	Discount          Discount               `json:"discount" bson:"discount,omitempty"`
+	Tags              []Tag                  `json:"tags" bson:"tags,omitempty"`
+	Options           []ProductOption        `json:"options,omitempty" bson:"options,omitempty"`
+	Variants          []Variant              `json:"variants,omitempty" bson:"variants,omitempty"`
+	Attributes        map[string]interface{} `json:"attributes,omitempty" bson:"attributes,omitempty"`
+	Images            []ProductImage         `json:"images,omitempty" bson:"images,omitempty"`
+	Rating            ProductRating          `json:"rating" bson:"rating"`
+	Status            string                 `json:"status" bson:"status,omitempty"`
+	PublishAt         *time.Time             `json:"publish-at,omitempty" bson:"publish-at,omitempty"`
+	UnpublishAt       *time.Time             `json:"unpublish-at,omitempty" bson:"unpublish-at,omitempty"`
+	PublishedAt       *time.Time             `json:"published-at,omitempty" bson:"published-at,omitempty"`
+	Translations      map[string]Translation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Locale            string                 `json:"locale,omitempty" bson:"-"`
+	DeletedAt         *time.Time             `json:"deleted-at,omitempty" bson:"deleted-at,omitempty"`
+	Pricing           *PriceQuote            `json:"pricing,omitempty" bson:"-"`
+	Availability      *Availability          `json:"availability,omitempty" bson:"-"`
+}
+
+const ProductSortRating = "rating"
+
+const (
+	ProductStatusDraft     = "draft"
+	ProductStatusPublished = "published"
+	ProductStatusArchived  = "archived"
+)
+
+var ProductStatuses = []string{ProductStatusDraft, ProductStatusPublished, ProductStatusArchived}
+
+var productStatusTransitions = map[string][]string{
+	ProductStatusDraft:     {ProductStatusPublished, ProductStatusArchived},
+	ProductStatusPublished: {ProductStatusDraft, ProductStatusArchived},
+	ProductStatusArchived:  {ProductStatusDraft},
+}
+
+type ProductFilter struct {
+	CategoryID    string
+	SubcategoryID string
+	TagID         string
+	DiscountID    string
+	MinPrice      *float64
+	MaxPrice      *float64
+	Attributes    []AttributeFilter
+	Statuses      []string
+	Sort          string
+	Offset        int64
+	Limit         int64
+}
+
+func (product *Product) PublicationStatus() string {
+	if product.Status == "" {
+		return ProductStatusPublished
+	}
+
+	return product.Status
+}
+
+func (product *Product) IsPublished() bool {
+	return product.PublicationStatus() == ProductStatusPublished
+}
+
+func (product *Product) CanTransit(status string) bool {
+	for _, allowed := range productStatusTransitions[product.PublicationStatus()] {
+		if allowed == status {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (product *Product) ValidSchedule() bool {
+	switch product.PublicationStatus() {
+	case ProductStatusDraft:
+		return product.UnpublishAt == nil || (product.PublishAt != nil && product.UnpublishAt.After(*product.PublishAt))
+	case ProductStatusPublished:
+		return product.PublishAt == nil
+	default:
+		return product.PublishAt == nil && product.UnpublishAt == nil
+	}
 }
@@ -0,0 +1,44 @@
+package model
+
+import "time"
+
+type CoPurchase struct {
+	ProductID string              `json:"product-id" bson:"_id"`
+	Related   []CoPurchaseProduct `json:"related" bson:"related"`
+	UpdatedAt time.Time           `json:"updated-at" bson:"updated-at"`
+}
+
+type CoPurchaseProduct struct {
+	ProductID string `json:"product-id" bson:"product-id"`
+	Orders    int64  `json:"orders" bson:"orders"`
+}
+
+type RelatedOverride struct {
+	ProductID string    `json:"product-id" bson:"_id"`
+	Pinned    []string  `json:"pinned" bson:"pinned"`
+	Excluded  []string  `json:"excluded" bson:"excluded"`
+	UpdatedAt time.Time `json:"updated-at" bson:"updated-at"`
+}
+
+type RelatedProducts struct {
+	Pinned         []Product `json:"pinned"`
+	BoughtTogether []Product `json:"bought-together"`
+	Similar        []Product `json:"similar"`
+}
+
+func (coPurchase *CoPurchase) ProductIDs() []string {
+	if coPurchase == nil {
+		return nil
+	}
+
+	ids := make([]string, 0, len(coPurchase.Related))
+	for _, related := range coPurchase.Related {
+		ids = append(ids, related.ProductID)
+	}
+
+	return ids
+}
+
+func (relatedProducts *RelatedProducts) All() [][]Product {
+	return [][]Product{relatedProducts.Pinned, relatedProducts.BoughtTogether, relatedProducts.Similar}
+}
@@ -0,0 +1,45 @@
+package model
+
+import "time"
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+type Review struct {
+	ID               string     `json:"uuid" bson:"_id,omitempty"`
+	ProductID        string     `json:"product-id" bson:"product-id"`
+	Rating           int        `json:"rating" bson:"rating"`
+	Title            string     `json:"title" bson:"title"`
+	Body             string     `json:"body" bson:"body"`
+	Author           string     `json:"author" bson:"author"`
+	OrderID          string     `json:"order-id,omitempty" bson:"order-id,omitempty"`
+	VerifiedPurchase bool       `json:"verified-purchase" bson:"verified-purchase"`
+	Status           string     `json:"status" bson:"status"`
+	ModerationNote   string     `json:"moderation-note,omitempty" bson:"moderation-note,omitempty"`
+	ModeratedBy      string     `json:"moderated-by,omitempty" bson:"moderated-by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated-at,omitempty" bson:"moderated-at,omitempty"`
+	CreatedAt        time.Time  `json:"created-at" bson:"created-at"`
+	UpdatedAt        time.Time  `json:"updated-at" bson:"updated-at"`
+}
+
+type ReviewFilter struct {
+	ProductID string
+	Status    string
+}
+
+type ProductRating struct {
+	Average float64 `json:"average" bson:"average"`
+	Count   int     `json:"count" bson:"count"`
+	Sum     int     `json:"-" bson:"sum"`
+}
+
+func (review *Review) Contribution() (int, int) {
+	if review == nil || review.Status != ReviewApproved {
+		return 0, 0
+	}
+
+	return review.Rating, 1
+}
@@ -1,7 +1,13 @@
 package model
 
+import "time"
+
 type Subcategory struct {
-	ID          string `json:"uuid" bson:"_id,omitempty"`
-	Title       string `json:"title" bson:"title" validate:"required"`
-	Description string `json:"description" bson:"description" validate:"required"`
+	ID           string                 `json:"uuid" bson:"_id,omitempty"`
+	Title        string                 `json:"title" bson:"title" validate:"required"`
+	Description  string                 `json:"description" bson:"description" validate:"required"`
+	CategoryID   string                 `json:"category-id,omitempty" bson:"category-id,omitempty"`
+	Translations map[string]Translation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Locale       string                 `json:"locale,omitempty" bson:"-"`
+	DeletedAt    *time.Time             `json:"deleted-at,omitempty" bson:"deleted-at,omitempty"`
 }
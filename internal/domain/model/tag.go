@@ -1,6 +1,50 @@
 package model
 
+import "time"
+
 type Tag struct {
-	ID    string `json:"uuid" bson:"_id,omitempty"`
-	Title string `json:"title" bson:"title" validate:"required"`
+	ID           string                 `json:"uuid" bson:"_id,omitempty"`
+	Title        string                 `json:"title" bson:"title" validate:"required"`
+	Translations map[string]Translation `json:"translations,omitempty" bson:"translations,omitempty"`
+	Locale       string                 `json:"locale,omitempty" bson:"-"`
+	DeletedAt    *time.Time             `json:"deleted-at,omitempty" bson:"deleted-at,omitempty"`
+	Usage        *int64                 `json:"usage,omitempty" bson:"-"`
+}
+
+type TagMerge struct {
+	SourceID string `json:"source-id" bson:"source-id"`
+	Target   Tag    `json:"target" bson:"target"`
+	Products int64  `json:"products" bson:"products"`
+}
+
+func (product *Product) AddTags(tags []Tag) {
+	present := make(map[string]bool, len(product.Tags))
+	for _, tag := range product.Tags {
+		present[tag.ID] = true
+	}
+
+	for _, tag := range tags {
+		if present[tag.ID] {
+			continue
+		}
+
+		present[tag.ID] = true
+		product.Tags = append(product.Tags, tag)
+	}
+}
+
+func (product *Product) RemoveTags(ids []string) {
+	removed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		removed[id] = true
+	}
+
+	tags := make([]Tag, 0, len(product.Tags))
+	for _, tag := range product.Tags {
+		if !removed[tag.ID] {
+			tags = append(tags, tag)
+		}
+	}
+
+	product.Tags = tags
 }
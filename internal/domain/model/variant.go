@@ -0,0 +1,171 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type ProductOption struct {
+	Name   string   `json:"name" bson:"name" validate:"required"`
+	Values []string `json:"values" bson:"values" validate:"required,min=1,dive,required"`
+}
+
+type Variant struct {
+	SKU        string            `json:"sku" bson:"sku" validate:"required,max=64"`
+	Price      string            `json:"price,omitempty" bson:"price,omitempty"`
+	Quantity   int               `json:"quantity" bson:"quantity" validate:"min=0"`
+	Reserved   int               `json:"reserved" bson:"reserved"`
+	Attributes map[string]string `json:"attributes,omitempty" bson:"attributes,omitempty"`
+}
+
+type StockCheck struct {
+	SKU       string `json:"sku"`
+	ProductID string `json:"product-id,omitempty"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+	InStock   bool   `json:"in-stock"`
+}
+
+func (variant *Variant) EffectivePrice(base string) string {
+	if variant.Price != "" {
+		return variant.Price
+	}
+
+	return base
+}
+
+func (variant *Variant) combination() string {
+	keys := make([]string, 0, len(variant.Attributes))
+	for key := range variant.Attributes {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+variant.Attributes[key])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func (product *Product) Variant(sku string) (*Variant, bool) {
+	for i := range product.Variants {
+		if product.Variants[i].SKU == sku {
+			return &product.Variants[i], true
+		}
+	}
+
+	return nil, false
+}
+
+func (product *Product) SKUs() []string {
+	skus := make([]string, 0, len(product.Variants)+1)
+	if product.SKU != "" {
+		skus = append(skus, product.SKU)
+	}
+
+	for i := range product.Variants {
+		skus = append(skus, product.Variants[i].SKU)
+	}
+
+	return skus
+}
+
+func (product *Product) DefaultSKU() string {
+	switch len(product.Variants) {
+	case 0:
+		return product.SKU
+	case 1:
+		return product.Variants[0].SKU
+	default:
+		return ""
+	}
+}
+
+func (product *Product) AvailableSKU(sku string) (int, bool) {
+	available := product.Quantity - product.Reserved
+
+	if variant, ok := product.Variant(sku); ok {
+		if free := variant.Quantity - variant.Reserved; free < available {
+			available = free
+		}
+	} else if sku == "" || sku != product.SKU || len(product.Variants) > 0 {
+		return 0, false
+	}
+
+	if available < 0 {
+		available = 0
+	}
+
+	return available, true
+}
+
+func (product *Product) ValidateVariant(variant *Variant, replacing string) error {
+	if product.SKU != "" {
+		return fmt.Errorf("product with sku %q cannot have variants", product.SKU)
+	}
+
+	allowed := make(map[string]map[string]bool, len(product.Options))
+	for _, option := range product.Options {
+		values := make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			values[value] = true
+		}
+
+		allowed[option.Name] = values
+	}
+
+	for name, value := range variant.Attributes {
+		values, ok := allowed[name]
+		if !ok {
+			return fmt.Errorf("option %q is not defined for the product", name)
+		}
+
+		if !values[value] {
+			return fmt.Errorf("value %q is not allowed for option %q", value, name)
+		}
+	}
+
+	for _, option := range product.Options {
+		if _, ok := variant.Attributes[option.Name]; !ok {
+			return fmt.Errorf("option %q is required", option.Name)
+		}
+	}
+
+	for i := range product.Variants {
+		existing := &product.Variants[i]
+		if existing.SKU == replacing {
+			continue
+		}
+
+		if existing.SKU == variant.SKU {
+			return fmt.Errorf("variant with sku %q is exist", variant.SKU)
+		}
+
+		if len(product.Options) > 0 && existing.combination() == variant.combination() {
+			return fmt.Errorf("variant with the same options is exist: %s", existing.SKU)
+		}
+	}
+
+	return nil
+}
+
+func (product *Product) ValidateVariants() error {
+	variants := product.Variants
+	product.Variants = nil
+
+	defer func() { product.Variants = variants }()
+
+	for i := range variants {
+		if err := product.ValidateVariant(&variants[i], ""); err != nil {
+			return err
+		}
+
+		product.Variants = append(product.Variants, variants[i])
+	}
+
+	return nil
+}
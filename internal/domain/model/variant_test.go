@@ -0,0 +1,58 @@
+package model
+
+import "testing"
+
+func TestAvailableSKU(t *testing.T) {
+	simple := &Product{SKU: "mug", Quantity: 10, Reserved: 3}
+	shirt := &Product{
+		Quantity: 10,
+		Reserved: 3,
+		Variants: []Variant{{SKU: "shirt-s", Quantity: 4}, {SKU: "shirt-m", Quantity: 20}},
+	}
+
+	cases := []struct {
+		name      string
+		product   *Product
+		sku       string
+		available int
+		ok        bool
+	}{
+		{"simple product sku", simple, "mug", 7, true},
+		{"unknown sku on simple product", simple, "cup", 0, false},
+		{"empty sku", &Product{Quantity: 5}, "", 0, false},
+		{"variant capped by its own quantity", shirt, "shirt-s", 4, true},
+		{"variant capped by free product stock", shirt, "shirt-m", 7, true},
+		{"unknown variant", shirt, "shirt-l", 0, false},
+		{"variant reserved units", &Product{Quantity: 10, Variants: []Variant{{SKU: "shirt-s", Quantity: 4, Reserved: 3}}}, "shirt-s", 1, true},
+		{"oversold product", &Product{SKU: "mug", Quantity: 1, Reserved: 2}, "mug", 0, true},
+	}
+
+	for _, c := range cases {
+		available, ok := c.product.AvailableSKU(c.sku)
+		if available != c.available || ok != c.ok {
+			t.Errorf("%s: AvailableSKU(%q) = %d, %v, want %d, %v", c.name, c.sku, available, ok, c.available, c.ok)
+		}
+	}
+}
+
+func TestValidateVariantRejectsSimpleProduct(t *testing.T) {
+	product := &Product{SKU: "mug"}
+
+	if err := product.ValidateVariant(&Variant{SKU: "mug-red"}, ""); err == nil {
+		t.Fatal("expected a product with its own sku to reject variants")
+	}
+}
+
+func TestDefaultSKU(t *testing.T) {
+	if got := (&Product{SKU: "mug"}).DefaultSKU(); got != "mug" {
+		t.Errorf("simple product DefaultSKU = %q, want mug", got)
+	}
+
+	if got := (&Product{Variants: []Variant{{SKU: "shirt-s"}}}).DefaultSKU(); got != "shirt-s" {
+		t.Errorf("single variant DefaultSKU = %q, want shirt-s", got)
+	}
+
+	if got := (&Product{Variants: []Variant{{SKU: "shirt-s"}, {SKU: "shirt-m"}}}).DefaultSKU(); got != "" {
+		t.Errorf("multi variant DefaultSKU = %q, want empty", got)
+	}
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	AllocationNearest   = "nearest"
+	AllocationMostStock = "most-stock"
+	AllocationPriority  = "priority"
+)
+
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude" bson:"latitude" validate:"min=-90,max=90"`
+	Longitude float64 `json:"longitude" bson:"longitude" validate:"min=-180,max=180"`
+}
+
+type Warehouse struct {
+	ID        string    `json:"uuid" bson:"_id,omitempty"`
+	Code      string    `json:"code" bson:"code"`
+	Title     string    `json:"title" bson:"title"`
+	Address   string    `json:"address" bson:"address"`
+	Location  *GeoPoint `json:"location,omitempty" bson:"location,omitempty"`
+	Priority  int       `json:"priority" bson:"priority"`
+	IsActive  bool      `json:"is-active" bson:"is-active"`
+	CreatedAt time.Time `json:"created-at" bson:"created-at"`
+}
+
+type WarehouseStock struct {
+	ProductID   string `json:"product-id" bson:"product-id"`
+	WarehouseID string `json:"warehouse-id" bson:"warehouse-id"`
+	Quantity    int    `json:"quantity" bson:"quantity"`
+	Reserved    int    `json:"reserved" bson:"reserved"`
+}
+
+type StockLine struct {
+	ProductID string
+	Quantity  int
+}
+
+type Allocation struct {
+	WarehouseID string `json:"warehouse-id"`
+	Quantity    int    `json:"quantity"`
+}
+
+type Reservation struct {
+	Level       *StockLevel  `json:"level"`
+	Allocations []Allocation `json:"allocations"`
+}
+
+type Availability struct {
+	OnHand     int              `json:"on-hand"`
+	Reserved   int              `json:"reserved"`
+	Available  int              `json:"available"`
+	Warehouses []WarehouseStock `json:"warehouses"`
+}
+
+func IsAllocationStrategy(strategy string) bool {
+	switch strategy {
+	case AllocationNearest, AllocationMostStock, AllocationPriority:
+		return true
+	default:
+		return false
+	}
+}
+
+func (stock *WarehouseStock) Available() int {
+	return stock.Quantity - stock.Reserved
+}
+
+func (point *GeoPoint) DistanceTo(other *GeoPoint) float64 {
+	const earthRadius = 6371.0
+
+	lat1, lat2 := point.Latitude*math.Pi/180, other.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (other.Longitude - point.Longitude) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+func NewAvailability(stocks []WarehouseStock) *Availability {
+	availability := &Availability{Warehouses: stocks}
+	if availability.Warehouses == nil {
+		availability.Warehouses = []WarehouseStock{}
+	}
+
+	for _, stock := range stocks {
+		availability.OnHand += stock.Quantity
+		availability.Reserved += stock.Reserved
+	}
+
+	availability.Available = availability.OnHand - availability.Reserved
+
+	return availability
+}
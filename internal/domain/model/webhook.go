@@ -0,0 +1,30 @@
+package model
+
+import "time"
+
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryDead      = "dead"
+)
+
+type Webhook struct {
+	ID         string    `json:"uuid" bson:"_id,omitempty"`
+	URL        string    `json:"url" bson:"url" validate:"required,url"`
+	Secret     string    `json:"-" bson:"secret" validate:"required"`
+	EventTypes []string  `json:"event-types" bson:"event-types"`
+	IsActive   bool      `json:"is-active" bson:"is-active"`
+	CreatedAt  time.Time `json:"created-at" bson:"created-at"`
+}
+
+type WebhookDelivery struct {
+	ID            string     `json:"uuid" bson:"_id,omitempty"`
+	WebhookID     string     `json:"webhook-id" bson:"webhook-id"`
+	Event         Event      `json:"event" bson:"event"`
+	Status        string     `json:"status" bson:"status"`
+	Attempts      int        `json:"attempts" bson:"attempts"`
+	LastError     string     `json:"last-error,omitempty" bson:"last-error,omitempty"`
+	NextAttemptAt time.Time  `json:"next-attempt-at" bson:"next-attempt-at"`
+	CreatedAt     time.Time  `json:"created-at" bson:"created-at"`
+	DeliveredAt   *time.Time `json:"delivered-at,omitempty" bson:"delivered-at,omitempty"`
+}
@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+type Wishlist struct {
+	ID         string         `json:"uuid" bson:"_id,omitempty"`
+	CustomerID string         `json:"customer-id" bson:"customer-id"`
+	Name       string         `json:"name" bson:"name"`
+	ShareToken string         `json:"share-token,omitempty" bson:"share-token,omitempty"`
+	Items      []WishlistItem `json:"items" bson:"items"`
+	CreatedAt  time.Time      `json:"created-at" bson:"created-at"`
+	UpdatedAt  time.Time      `json:"updated-at" bson:"updated-at"`
+}
+
+type WishlistItem struct {
+	ProductID   string    `json:"product-id" bson:"product-id"`
+	Note        string    `json:"note,omitempty" bson:"note,omitempty"`
+	AddedAt     time.Time `json:"added-at" bson:"added-at"`
+	Product     *Product  `json:"product,omitempty" bson:"-"`
+	Unavailable bool      `json:"unavailable" bson:"-"`
+}
+
+func (wishlist *Wishlist) ProductIDs() []string {
+	ids := make([]string, 0, len(wishlist.Items))
+	for _, item := range wishlist.Items {
+		ids = append(ids, item.ProductID)
+	}
+
+	return ids
+}
+
+func (wishlist *Wishlist) HasItem(productID string) bool {
+	for _, item := range wishlist.Items {
+		if item.ProductID == productID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (wishlist *Wishlist) Resolve(products []Product) {
+	byID := make(map[string]*Product, len(products))
+	for i := range products {
+		if products[i].IsPublished() {
+			byID[products[i].ID] = &products[i]
+		}
+	}
+
+	for i := range wishlist.Items {
+		wishlist.Items[i].Product = byID[wishlist.Items[i].ProductID]
+		wishlist.Items[i].Unavailable = wishlist.Items[i].Product == nil
+	}
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"context"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+
+	"github.com/rs/zerolog"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+type MultiPublisher []Publisher
+
+func (publishers MultiPublisher) Publish(ctx context.Context, event model.Event) error {
+	for _, publisher := range publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(ctx context.Context, event model.Event) error {
+	zerolog.Ctx(ctx).Info().
+		Str("event_id", event.ID).
+		Str("type", event.Type).
+		Str("entity_id", event.EntityID).
+		Msg("catalog event")
+
+	return nil
+}
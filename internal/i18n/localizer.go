@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	HeaderAcceptLanguage = "Accept-Language"
+	QueryParamLocale     = "locale"
+)
+
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+type Localizer struct {
+	defaultLocale string
+	fallbacks     []string
+}
+
+type weightedLocale struct {
+	locale string
+	weight float64
+}
+
+func NewLocalizer(defaultLocale string, fallbacks []string) *Localizer {
+	localizer := &Localizer{defaultLocale: strings.ToLower(defaultLocale)}
+
+	for _, fallback := range fallbacks {
+		if locale, err := localizer.Normalize(fallback); err == nil {
+			localizer.fallbacks = append(localizer.fallbacks, locale)
+		}
+	}
+
+	return localizer
+}
+
+func (localizer *Localizer) Default() string {
+	return localizer.defaultLocale
+}
+
+func (localizer *Localizer) Normalize(locale string) (string, error) {
+	locale = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+
+	if !localePattern.MatchString(locale) {
+		return utils.EmptyString, errors.Wrap(utils.ErrorInvalidLocale, locale)
+	}
+
+	return locale, nil
+}
+
+func (localizer *Localizer) Chain(c echo.Context) model.LocaleChain {
+	varyAcceptLanguage(c)
+
+	requested := parseAcceptLanguage(c.Request().Header.Get(HeaderAcceptLanguage))
+
+	if value := c.QueryParam(QueryParamLocale); value != utils.EmptyString {
+		requested = append([]string{value}, requested...)
+	}
+
+	chain := model.LocaleChain{Default: localizer.defaultLocale}
+	seen := map[string]bool{localizer.defaultLocale: true}
+
+	add := func(locale string) bool {
+		if locale == localizer.defaultLocale {
+			return false
+		}
+
+		if !seen[locale] {
+			seen[locale] = true
+			chain.Preferred = append(chain.Preferred, locale)
+		}
+
+		return true
+	}
+
+	for _, value := range requested {
+		locale, err := localizer.Normalize(value)
+		if err != nil {
+			continue
+		}
+
+		if !add(locale) {
+			return chain
+		}
+
+		if base, _, found := strings.Cut(locale, "-"); found && !add(base) {
+			return chain
+		}
+	}
+
+	for _, locale := range localizer.fallbacks {
+		if !add(locale) {
+			return chain
+		}
+	}
+
+	return chain
+}
+
+func (localizer *Localizer) Lookup(chain model.LocaleChain) []string {
+	locales := make([]string, 0, len(chain.Preferred)+1)
+	locales = append(locales, chain.Preferred...)
+
+	return append(locales, utils.EmptyString)
+}
+
+func varyAcceptLanguage(c echo.Context) {
+	header := c.Response().Header()
+
+	for _, value := range header.Values(echo.HeaderVary) {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), HeaderAcceptLanguage) {
+				return
+			}
+		}
+	}
+
+	header.Add(echo.HeaderVary, HeaderAcceptLanguage)
+}
+
+func parseAcceptLanguage(header string) []string {
+	if header == utils.EmptyString {
+		return nil
+	}
+
+	weighted := make([]weightedLocale, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == utils.EmptyString || tag == "*" {
+			continue
+		}
+
+		weight := 1.0
+
+		if value, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			weight = parsed
+		}
+
+		if weight <= 0 {
+			continue
+		}
+
+		weighted = append(weighted, weightedLocale{locale: tag, weight: weight})
+	}
+
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].weight > weighted[j].weight
+	})
+
+	locales := make([]string, 0, len(weighted))
+	for _, item := range weighted {
+		locales = append(locales, item.locale)
+	}
+
+	return locales
+}
@@ -0,0 +1,112 @@
+package inventory
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+)
+
+type candidate struct {
+	warehouse model.Warehouse
+	available int
+	distance  float64
+}
+
+func Allocate(strategy string, origin *model.GeoPoint, quantity int, warehouses []model.Warehouse, stocks []model.WarehouseStock) ([]model.Allocation, error) {
+	if !model.IsAllocationStrategy(strategy) {
+		return nil, utils.ErrorAllocationStrategy
+	}
+
+	available := make(map[string]int, len(stocks))
+	for _, stock := range stocks {
+		available[stock.WarehouseID] += stock.Available()
+	}
+
+	candidates := make([]candidate, 0, len(warehouses))
+
+	for _, warehouse := range warehouses {
+		if !warehouse.IsActive || available[warehouse.ID] <= 0 {
+			continue
+		}
+
+		distance := math.Inf(1)
+		if origin != nil && warehouse.Location != nil {
+			distance = origin.DistanceTo(warehouse.Location)
+		}
+
+		candidates = append(candidates, candidate{warehouse: warehouse, available: available[warehouse.ID], distance: distance})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		left, right := candidates[i], candidates[j]
+
+		switch {
+		case strategy == model.AllocationNearest && origin != nil && left.distance != right.distance:
+			return left.distance < right.distance
+		case strategy == model.AllocationMostStock && left.available != right.available:
+			return left.available > right.available
+		case left.warehouse.Priority != right.warehouse.Priority:
+			return left.warehouse.Priority < right.warehouse.Priority
+		}
+
+		return left.warehouse.Code < right.warehouse.Code
+	})
+
+	allocations := make([]model.Allocation, 0, len(candidates))
+	remaining := quantity
+
+	for _, candidate := range candidates {
+		if remaining == 0 {
+			break
+		}
+
+		taken := candidate.available
+		if taken > remaining {
+			taken = remaining
+		}
+
+		allocations = append(allocations, model.Allocation{WarehouseID: candidate.warehouse.ID, Quantity: taken})
+		remaining -= taken
+	}
+
+	if remaining > 0 {
+		return nil, utils.ErrorInsufficientStock
+	}
+
+	return allocations, nil
+}
+
+func AllocateLines(strategy string, origin *model.GeoPoint, lines []model.StockLine, warehouses []model.Warehouse, stocks []model.WarehouseStock) ([][]model.Allocation, error) {
+	remaining := make([]model.WarehouseStock, len(stocks))
+	copy(remaining, stocks)
+
+	allocated := make([][]model.Allocation, 0, len(lines))
+
+	for _, line := range lines {
+		productStocks := make([]model.WarehouseStock, 0, len(remaining))
+		for _, stock := range remaining {
+			if stock.ProductID == line.ProductID {
+				productStocks = append(productStocks, stock)
+			}
+		}
+
+		allocations, err := Allocate(strategy, origin, line.Quantity, warehouses, productStocks)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, allocation := range allocations {
+			for i := range remaining {
+				if remaining[i].ProductID == line.ProductID && remaining[i].WarehouseID == allocation.WarehouseID {
+					remaining[i].Reserved += allocation.Quantity
+				}
+			}
+		}
+
+		allocated = append(allocated, allocations)
+	}
+
+	return allocated, nil
+}
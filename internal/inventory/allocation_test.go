@@ -0,0 +1,58 @@
+package inventory
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+)
+
+func TestAllocateLinesSharesStockBetweenLines(t *testing.T) {
+	warehouses := []model.Warehouse{
+		{ID: "north", Code: "N", Priority: 1, IsActive: true},
+		{ID: "south", Code: "S", Priority: 2, IsActive: true},
+	}
+
+	stocks := []model.WarehouseStock{
+		{ProductID: "shirt", WarehouseID: "north", Quantity: 3},
+		{ProductID: "shirt", WarehouseID: "south", Quantity: 5, Reserved: 1},
+		{ProductID: "mug", WarehouseID: "south", Quantity: 2},
+	}
+
+	lines := []model.StockLine{
+		{ProductID: "shirt", Quantity: 2},
+		{ProductID: "shirt", Quantity: 3},
+		{ProductID: "mug", Quantity: 2},
+	}
+
+	allocated, err := AllocateLines(model.AllocationPriority, nil, lines, warehouses, stocks)
+	if err != nil {
+		t.Fatalf("allocate: %v", err)
+	}
+
+	want := [][]model.Allocation{
+		{{WarehouseID: "north", Quantity: 2}},
+		{{WarehouseID: "north", Quantity: 1}, {WarehouseID: "south", Quantity: 2}},
+		{{WarehouseID: "south", Quantity: 2}},
+	}
+
+	if !reflect.DeepEqual(allocated, want) {
+		t.Fatalf("allocated = %+v, want %+v", allocated, want)
+	}
+
+	if stocks[0].Reserved != 0 || stocks[1].Reserved != 1 {
+		t.Error("AllocateLines must not change the caller's stock rows")
+	}
+}
+
+func TestAllocateLinesRefusesOversell(t *testing.T) {
+	warehouses := []model.Warehouse{{ID: "north", Code: "N", IsActive: true}}
+	stocks := []model.WarehouseStock{{ProductID: "shirt", WarehouseID: "north", Quantity: 3}}
+	lines := []model.StockLine{{ProductID: "shirt", Quantity: 2}, {ProductID: "shirt", Quantity: 2}}
+
+	if _, err := AllocateLines(model.AllocationPriority, nil, lines, warehouses, stocks); !errors.Is(err, utils.ErrorInsufficientStock) {
+		t.Fatalf("allocate = %v, want ErrorInsufficientStock", err)
+	}
+}
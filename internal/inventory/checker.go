@@ -0,0 +1,131 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+var checkedEvents = map[string]bool{
+	model.EventType(utils.CollNameProduct, model.EventActionCreated):      true,
+	model.EventType(utils.CollNameProduct, model.EventActionUpdated):      true,
+	model.EventType(utils.CollNameProduct, model.EventActionStockChanged): true,
+	model.EventType(utils.CollNameProduct, model.EventActionDeleted):      true,
+}
+
+type CheckerDeps struct {
+	ProductRepository    repository.ProductRepository
+	CategoryRepository   repository.CategoryRepository
+	StockAlertRepository repository.StockAlertRepository
+	Notifier             Notifier
+}
+
+type Checker struct {
+	productRepository    repository.ProductRepository
+	categoryRepository   repository.CategoryRepository
+	stockAlertRepository repository.StockAlertRepository
+	notifier             Notifier
+}
+
+func NewChecker(deps *CheckerDeps) *Checker {
+	return &Checker{
+		productRepository:    deps.ProductRepository,
+		categoryRepository:   deps.CategoryRepository,
+		stockAlertRepository: deps.StockAlertRepository,
+		notifier:             deps.Notifier,
+	}
+}
+
+func (checker *Checker) Publish(ctx context.Context, event model.Event) error {
+	if !checkedEvents[event.Type] {
+		return nil
+	}
+
+	if err := checker.Check(ctx, event.EntityID); err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Str("product_id", event.EntityID).Str("event_id", event.ID).Msg("check low stock")
+	}
+
+	return nil
+}
+
+func (checker *Checker) Check(ctx context.Context, productID string) error {
+	now := time.Now().UTC()
+
+	product, err := checker.productRepository.GetProduct(ctx, productID)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		_, err = checker.stockAlertRepository.ResolveStockAlerts(ctx, productID, now)
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	var category *model.Category
+	if product.LowStockThreshold == nil && product.Category.ID != utils.EmptyString {
+		category, _ = checker.categoryRepository.GetCategory(ctx, product.Category.ID)
+	}
+
+	threshold, ok := product.StockThreshold(category)
+	if !ok || product.Quantity > threshold {
+		_, err = checker.stockAlertRepository.ResolveStockAlerts(ctx, productID, now)
+		return err
+	}
+
+	alert, err := checker.stockAlertRepository.OpenStockAlert(ctx, &model.StockAlert{
+		ProductID: productID,
+		Title:     product.Title,
+		Quantity:  product.Quantity,
+		Threshold: threshold,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return err
+	}
+
+	if alert.NotifiedAt != nil {
+		return nil
+	}
+
+	return checker.notify(ctx, alert)
+}
+
+func (checker *Checker) Renotify(ctx context.Context) (int, error) {
+	alerts, err := checker.stockAlertRepository.GetOpenStockAlerts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	notified := 0
+
+	for i := range *alerts {
+		alert := &(*alerts)[i]
+		if alert.NotifiedAt != nil {
+			continue
+		}
+
+		if err = checker.notify(ctx, alert); err != nil {
+			zerolog.Ctx(ctx).Error().Err(err).Str("alert_id", alert.ID).Str("product_id", alert.ProductID).Msg("renotify low stock")
+			continue
+		}
+
+		notified++
+	}
+
+	return notified, nil
+}
+
+func (checker *Checker) notify(ctx context.Context, alert *model.StockAlert) error {
+	if err := checker.notifier.Notify(ctx, alert); err != nil {
+		return errors.Wrap(err, "notify low stock")
+	}
+
+	return checker.stockAlertRepository.MarkStockAlertNotified(ctx, alert.ID, time.Now().UTC())
+}
@@ -0,0 +1,177 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+)
+
+type fakeProductRepository struct {
+	repository.ProductRepository
+	product *model.Product
+}
+
+func (repository *fakeProductRepository) GetProduct(ctx context.Context, uuid string) (*model.Product, error) {
+	return repository.product, nil
+}
+
+type fakeStockAlertRepository struct {
+	alert    *model.StockAlert
+	open     []model.StockAlert
+	notified []string
+	resolved int
+}
+
+func (repository *fakeStockAlertRepository) OpenStockAlert(ctx context.Context, alert *model.StockAlert) (*model.StockAlert, error) {
+	if repository.alert == nil {
+		alert.ID = "alert-1"
+		alert.Status = model.StockAlertOpen
+		repository.alert = alert
+	}
+
+	return repository.alert, nil
+}
+
+func (repository *fakeStockAlertRepository) MarkStockAlertNotified(ctx context.Context, uuid string, at time.Time) error {
+	repository.notified = append(repository.notified, uuid)
+
+	if repository.alert != nil {
+		repository.alert.NotifiedAt = &at
+	}
+
+	return nil
+}
+
+func (repository *fakeStockAlertRepository) ResolveStockAlerts(ctx context.Context, productID string, at time.Time) (int64, error) {
+	repository.resolved++
+	repository.alert = nil
+
+	return 1, nil
+}
+
+func (repository *fakeStockAlertRepository) GetOpenStockAlerts(ctx context.Context) (*[]model.StockAlert, error) {
+	return &repository.open, nil
+}
+
+type fakeNotifier struct {
+	err   error
+	calls int
+}
+
+func (notifier *fakeNotifier) Notify(ctx context.Context, alert *model.StockAlert) error {
+	notifier.calls++
+
+	return notifier.err
+}
+
+func stockEvent() model.Event {
+	return model.Event{
+		ID:       "event-1",
+		Type:     model.EventType(utils.CollNameProduct, model.EventActionStockChanged),
+		EntityID: "product-1",
+	}
+}
+
+func TestCheckerSwallowsNotifierErrors(t *testing.T) {
+	threshold := 5
+	alerts := &fakeStockAlertRepository{}
+	notifier := &fakeNotifier{err: errors.New("smtp is down")}
+
+	checker := NewChecker(&CheckerDeps{
+		ProductRepository:    &fakeProductRepository{product: &model.Product{ID: "product-1", Quantity: 2, LowStockThreshold: &threshold}},
+		StockAlertRepository: alerts,
+		Notifier:             notifier,
+	})
+
+	if err := checker.Publish(context.Background(), stockEvent()); err != nil {
+		t.Fatalf("publish returned %v, want nil so the relay does not retry the event", err)
+	}
+
+	if notifier.calls != 1 || alerts.alert == nil || alerts.alert.NotifiedAt != nil {
+		t.Fatalf("after failure: calls = %d, alert = %+v", notifier.calls, alerts.alert)
+	}
+
+	notifier.err = nil
+
+	if err := checker.Publish(context.Background(), stockEvent()); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	if notifier.calls != 2 || alerts.alert.NotifiedAt == nil {
+		t.Fatalf("after retry: calls = %d, notified = %v", notifier.calls, alerts.alert.NotifiedAt)
+	}
+
+	if err := checker.Publish(context.Background(), stockEvent()); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	if notifier.calls != 2 {
+		t.Errorf("notified %d times, want the open alert notified once", notifier.calls)
+	}
+}
+
+func TestCheckerResolvesAboveThreshold(t *testing.T) {
+	threshold := 5
+	alerts := &fakeStockAlertRepository{alert: &model.StockAlert{ID: "alert-1"}}
+	notifier := &fakeNotifier{}
+
+	checker := NewChecker(&CheckerDeps{
+		ProductRepository:    &fakeProductRepository{product: &model.Product{ID: "product-1", Quantity: 10, LowStockThreshold: &threshold}},
+		StockAlertRepository: alerts,
+		Notifier:             notifier,
+	})
+
+	if err := checker.Publish(context.Background(), stockEvent()); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+
+	if alerts.resolved != 1 || notifier.calls != 0 {
+		t.Errorf("resolved = %d, notifier calls = %d", alerts.resolved, notifier.calls)
+	}
+}
+
+func TestCheckerRenotifiesUnnotifiedAlerts(t *testing.T) {
+	notifiedAt := time.Now().UTC()
+	alerts := &fakeStockAlertRepository{open: []model.StockAlert{
+		{ID: "alert-1", ProductID: "product-1", Status: model.StockAlertOpen},
+		{ID: "alert-2", ProductID: "product-2", Status: model.StockAlertOpen, NotifiedAt: &notifiedAt},
+		{ID: "alert-3", ProductID: "product-3", Status: model.StockAlertOpen},
+	}}
+	notifier := &fakeNotifier{}
+
+	checker := NewChecker(&CheckerDeps{StockAlertRepository: alerts, Notifier: notifier})
+
+	notified, err := checker.Renotify(context.Background())
+	if err != nil {
+		t.Fatalf("renotify: %v", err)
+	}
+
+	if notified != 2 || notifier.calls != 2 {
+		t.Fatalf("notified = %d, notifier calls = %d, want 2 and 2", notified, notifier.calls)
+	}
+
+	if len(alerts.notified) != 2 || alerts.notified[0] != "alert-1" || alerts.notified[1] != "alert-3" {
+		t.Errorf("marked notified = %v, want [alert-1 alert-3]", alerts.notified)
+	}
+}
+
+func TestCheckerRenotifyKeepsFailedAlertsOpen(t *testing.T) {
+	alerts := &fakeStockAlertRepository{open: []model.StockAlert{{ID: "alert-1", Status: model.StockAlertOpen}}}
+	notifier := &fakeNotifier{err: errors.New("smtp is down")}
+
+	checker := NewChecker(&CheckerDeps{StockAlertRepository: alerts, Notifier: notifier})
+
+	notified, err := checker.Renotify(context.Background())
+	if err != nil {
+		t.Fatalf("renotify returned %v, want nil so the worker keeps running", err)
+	}
+
+	if notified != 0 || len(alerts.notified) != 0 {
+		t.Errorf("notified = %d, marked = %v, want nothing marked", notified, alerts.notified)
+	}
+}
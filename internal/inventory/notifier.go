@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+type Notifier interface {
+	Notify(ctx context.Context, alert *model.StockAlert) error
+}
+
+type MultiNotifier []Notifier
+
+func (notifiers MultiNotifier) Notify(ctx context.Context, alert *model.StockAlert) error {
+	for _, notifier := range notifiers {
+		if err := notifier.Notify(ctx, alert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(ctx context.Context, alert *model.StockAlert) error {
+	zerolog.Ctx(ctx).Warn().
+		Str("product_id", alert.ProductID).
+		Int("quantity", alert.Quantity).
+		Int("threshold", alert.Threshold).
+		Msg("low stock")
+
+	return nil
+}
+
+type WebhookNotifier struct {
+	client *http.Client
+	url    string
+}
+
+func NewWebhookNotifier(url string, timeout time.Duration) *WebhookNotifier {
+	return &WebhookNotifier{
+		client: &http.Client{Timeout: timeout},
+		url:    url,
+	}
+}
+
+func (webhookNotifier *WebhookNotifier) Notify(ctx context.Context, alert *model.StockAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorMarshal.Error())
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookNotifier.url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create alert request")
+	}
+
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+
+	response, err := webhookNotifier.client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "send alert request")
+	}
+
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("alert webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+type EmailNotifierDeps struct {
+	Address  string
+	From     string
+	To       []string
+	Username string
+	Password string
+}
+
+type EmailNotifier struct {
+	address string
+	from    string
+	to      []string
+	auth    smtp.Auth
+}
+
+func NewEmailNotifier(deps *EmailNotifierDeps) *EmailNotifier {
+	var auth smtp.Auth
+	if deps.Username != utils.EmptyString {
+		host := strings.Split(deps.Address, ":")[0]
+		auth = smtp.PlainAuth("", deps.Username, deps.Password, host)
+	}
+
+	return &EmailNotifier{
+		address: deps.Address,
+		from:    deps.From,
+		to:      deps.To,
+		auth:    auth,
+	}
+}
+
+func (emailNotifier *EmailNotifier) Notify(ctx context.Context, alert *model.StockAlert) error {
+	subject := fmt.Sprintf("Low stock: %s", alert.Title)
+	body := fmt.Sprintf("Product %s (%s) has %d items left, threshold is %d.", alert.Title, alert.ProductID, alert.Quantity, alert.Threshold)
+
+	message := strings.Join([]string{
+		"From: " + emailNotifier.from,
+		"To: " + strings.Join(emailNotifier.to, ", "),
+		"Subject: " + subject,
+		"Content-Type: text/plain; charset=utf-8",
+		"",
+		body,
+	}, "\r\n")
+
+	if err := smtp.SendMail(emailNotifier.address, emailNotifier.auth, emailNotifier.from, emailNotifier.to, []byte(message)); err != nil {
+		return errors.Wrap(err, "send alert email")
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+package inventory
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+type smtpSession struct {
+	from       string
+	recipients []string
+	data       string
+}
+
+func startSMTPServer(t *testing.T, reject string) (string, <-chan smtpSession) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	sessions := make(chan smtpSession, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var session smtpSession
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			command := strings.TrimRight(line, "\r\n")
+			verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0])
+
+			if reject != "" && strings.HasPrefix(strings.ToUpper(command), reject) {
+				reply("550 rejected")
+				continue
+			}
+
+			switch verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				session.from = command
+				reply("250 OK")
+			case "RCPT":
+				session.recipients = append(session.recipients, command)
+				reply("250 OK")
+			case "DATA":
+				reply("354 end data with <CR><LF>.<CR><LF>")
+
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+
+					if line == ".\r\n" {
+						break
+					}
+
+					data.WriteString(line)
+				}
+
+				session.data = data.String()
+				reply("250 OK")
+			case "QUIT":
+				reply("221 bye")
+				sessions <- session
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), sessions
+}
+
+func TestEmailNotifierSendsAlert(t *testing.T) {
+	address, sessions := startSMTPServer(t, "")
+
+	notifier := NewEmailNotifier(&EmailNotifierDeps{
+		Address: address,
+		From:    "store@example.com",
+		To:      []string{"ops@example.com", "buyer@example.com"},
+	})
+
+	alert := &model.StockAlert{ProductID: "product-1", Title: "Lamp", Quantity: 2, Threshold: 5}
+
+	if err := notifier.Notify(context.Background(), alert); err != nil {
+		t.Fatalf("notify: %v", err)
+	}
+
+	var session smtpSession
+
+	select {
+	case session = <-sessions:
+	case <-time.After(5 * time.Second):
+		t.Fatal("smtp session did not finish")
+	}
+
+	if !strings.Contains(session.from, "<store@example.com>") {
+		t.Errorf("mail from = %q", session.from)
+	}
+
+	if len(session.recipients) != 2 || !strings.Contains(session.recipients[1], "<buyer@example.com>") {
+		t.Errorf("recipients = %q", session.recipients)
+	}
+
+	for _, want := range []string{
+		"Subject: Low stock: Lamp\r\n",
+		"To: ops@example.com, buyer@example.com\r\n",
+		"Product Lamp (product-1) has 2 items left, threshold is 5.",
+	} {
+		if !strings.Contains(session.data, want) {
+			t.Errorf("message does not contain %q:\n%s", want, session.data)
+		}
+	}
+}
+
+func TestEmailNotifierReportsRejection(t *testing.T) {
+	address, _ := startSMTPServer(t, "RCPT")
+
+	notifier := NewEmailNotifier(&EmailNotifierDeps{
+		Address: address,
+		From:    "store@example.com",
+		To:      []string{"ops@example.com"},
+	})
+
+	err := notifier.Notify(context.Background(), &model.StockAlert{Title: "Lamp"})
+	if err == nil || !strings.Contains(err.Error(), "550") {
+		t.Fatalf("notify error = %v, want smtp 550", err)
+	}
+}
@@ -0,0 +1,195 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	s3Algorithm = "AWS4-HMAC-SHA256"
+	s3Service   = "s3"
+	s3Request   = "aws4_request"
+)
+
+type S3StoreDeps struct {
+	Endpoint  string
+	Bucket    string
+	Region    string
+	AccessKey string
+	SecretKey string
+	PublicURL string
+	Timeout   time.Duration
+}
+
+type S3Store struct {
+	client    *http.Client
+	endpoint  string
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+	publicURL string
+}
+
+func NewS3Store(deps *S3StoreDeps) *S3Store {
+	endpoint := strings.TrimSuffix(deps.Endpoint, "/")
+
+	publicURL := strings.TrimSuffix(deps.PublicURL, "/")
+	if publicURL == "" {
+		publicURL = endpoint + "/" + deps.Bucket
+	}
+
+	return &S3Store{
+		client:    &http.Client{Timeout: deps.Timeout},
+		endpoint:  endpoint,
+		bucket:    deps.Bucket,
+		region:    deps.Region,
+		accessKey: deps.AccessKey,
+		secretKey: deps.SecretKey,
+		publicURL: publicURL,
+	}
+}
+
+func (s3Store *S3Store) Put(ctx context.Context, key string, contentType string, body []byte) error {
+	return s3Store.do(ctx, http.MethodPut, key, contentType, body)
+}
+
+func (s3Store *S3Store) Delete(ctx context.Context, key string) error {
+	return s3Store.do(ctx, http.MethodDelete, key, "", nil)
+}
+
+func (s3Store *S3Store) URL(key string) string {
+	return s3Store.publicURL + "/" + key
+}
+
+func (s3Store *S3Store) do(ctx context.Context, method string, key string, contentType string, body []byte) error {
+	uri := "/" + s3Store.bucket + "/" + encodeKey(key)
+
+	request, err := http.NewRequestWithContext(ctx, method, s3Store.endpoint+uri, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create s3 request")
+	}
+
+	if contentType != "" {
+		request.Header.Set(echo.HeaderContentType, contentType)
+	}
+
+	s3Store.sign(request, uri, body, time.Now().UTC())
+
+	response, err := s3Store.client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "send s3 request")
+	}
+
+	defer response.Body.Close()
+
+	message, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("s3 responded with status %d: %s", response.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	return nil
+}
+
+func (s3Store *S3Store) sign(request *http.Request, uri string, body []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "host:" + request.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		uri,
+		canonicalQuery(request.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s3Store.region, s3Service, s3Request}, "/")
+	stringToSign := strings.Join([]string{s3Algorithm, amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s3Store.secretKey), date)
+	key = hmacSHA256(key, s3Store.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, s3Request)
+
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	request.Header.Set(echo.HeaderAuthorization, fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s3Store.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		for _, value := range values[key] {
+			pairs = append(pairs, encodeQuery(key)+"="+encodeQuery(value))
+		}
+	}
+
+	return strings.Join(pairs, "&")
+}
+
+func encodeQuery(value string) string {
+	return strings.ReplaceAll(encodeKey(value), "/", "%2F")
+}
+
+func encodeKey(key string) string {
+	var builder strings.Builder
+
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') || strings.IndexByte("-_.~/", c) >= 0 {
+			builder.WriteByte(c)
+			continue
+		}
+
+		fmt.Fprintf(&builder, "%%%02X", c)
+	}
+
+	return builder.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,208 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxPixels   = 40_000_000
+	jpegQuality = 85
+)
+
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+}
+
+type ServiceDeps struct {
+	Store          BlobStore
+	MaxSize        int64
+	ThumbnailSizes []int
+}
+
+type Service struct {
+	store          BlobStore
+	maxSize        int64
+	thumbnailSizes []int
+}
+
+func NewService(deps *ServiceDeps) *Service {
+	return &Service{
+		store:          deps.Store,
+		maxSize:        deps.MaxSize,
+		thumbnailSizes: deps.ThumbnailSizes,
+	}
+}
+
+func (service *Service) MaxSize() int64 {
+	return service.maxSize
+}
+
+func (service *Service) Upload(ctx context.Context, productID string, data []byte) (*model.ProductImage, error) {
+	if int64(len(data)) > service.maxSize {
+		return nil, utils.ErrorMediaSize
+	}
+
+	contentType := http.DetectContentType(data)
+
+	extension, ok := extensions[contentType]
+	if !ok {
+		return nil, utils.ErrorMediaType
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || config.Width*config.Height > maxPixels {
+		return nil, utils.ErrorMediaType
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, utils.ErrorMediaType
+	}
+
+	id := primitive.NewObjectID().Hex()
+	prefix := "products/" + productID + "/" + id + "/"
+
+	productImage := &model.ProductImage{
+		ID:          id,
+		Key:         prefix + "original." + extension,
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		Width:       config.Width,
+		Height:      config.Height,
+		Thumbnails:  make([]model.Thumbnail, 0, len(service.thumbnailSizes)),
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	productImage.URL = service.store.URL(productImage.Key)
+
+	if err = service.store.Put(ctx, productImage.Key, contentType, data); err != nil {
+		return nil, err
+	}
+
+	for _, size := range service.thumbnailSizes {
+		thumbnail, body, thumbnailType, err := makeThumbnail(source, size, contentType)
+		if err != nil {
+			_ = service.Remove(ctx, *productImage)
+			return nil, err
+		}
+
+		thumbnail.Key = prefix + strconv.Itoa(size) + "." + extensions[thumbnailType]
+		thumbnail.URL = service.store.URL(thumbnail.Key)
+
+		if err = service.store.Put(ctx, thumbnail.Key, thumbnailType, body); err != nil {
+			_ = service.Remove(ctx, *productImage)
+			return nil, err
+		}
+
+		productImage.Thumbnails = append(productImage.Thumbnails, *thumbnail)
+	}
+
+	return productImage, nil
+}
+
+func (service *Service) Remove(ctx context.Context, images ...model.ProductImage) error {
+	var failed error
+
+	for _, productImage := range images {
+		for _, key := range productImage.Keys() {
+			if err := service.store.Delete(ctx, key); err != nil {
+				failed = err
+			}
+		}
+	}
+
+	return failed
+}
+
+func makeThumbnail(source image.Image, size int, contentType string) (*model.Thumbnail, []byte, string, error) {
+	bounds := source.Bounds()
+	width, height := fit(bounds.Dx(), bounds.Dy(), size)
+
+	resized := resize(source, width, height)
+
+	var buffer bytes.Buffer
+
+	thumbnailType := "image/jpeg"
+	if contentType == "image/png" {
+		thumbnailType = "image/png"
+	}
+
+	var err error
+	if thumbnailType == "image/png" {
+		err = png.Encode(&buffer, resized)
+	} else {
+		err = jpeg.Encode(&buffer, resized, &jpeg.Options{Quality: jpegQuality})
+	}
+
+	if err != nil {
+		return nil, nil, "", errors.Wrap(err, "encode thumbnail")
+	}
+
+	return &model.Thumbnail{Size: size, Width: width, Height: height}, buffer.Bytes(), thumbnailType, nil
+}
+
+func fit(width int, height int, size int) (int, int) {
+	if width <= size && height <= size {
+		return width, height
+	}
+
+	if width >= height {
+		return size, maxInt(1, height*size/width)
+	}
+
+	return maxInt(1, width*size/height), size
+}
+
+func resize(source image.Image, width int, height int) *image.RGBA64 {
+	bounds := source.Bounds()
+	destination := image.NewRGBA64(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := maxInt(y0+1, bounds.Min.Y+(y+1)*bounds.Dy()/height)
+
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := maxInt(x0+1, bounds.Min.X+(x+1)*bounds.Dx()/width)
+
+			var r, g, b, a, n uint64
+
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := source.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+
+			destination.SetRGBA64(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+
+	return destination
+}
+
+func maxInt(a int, b int) int {
+	if a > b {
+		return a
+	}
+
+	return b
+}
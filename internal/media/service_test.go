@@ -0,0 +1,225 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/utils"
+)
+
+type memoryStore struct {
+	blobs map[string][]byte
+	types map[string]string
+	fail  string
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{blobs: map[string][]byte{}, types: map[string]string{}}
+}
+
+func (store *memoryStore) Put(ctx context.Context, key string, contentType string, body []byte) error {
+	if store.fail != "" && strings.HasSuffix(key, store.fail) {
+		return errors.New("put failed")
+	}
+
+	store.blobs[key] = body
+	store.types[key] = contentType
+
+	return nil
+}
+
+func (store *memoryStore) Delete(ctx context.Context, key string) error {
+	delete(store.blobs, key)
+
+	return nil
+}
+
+func (store *memoryStore) URL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func encodePNG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	source := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			source.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, source); err != nil {
+		t.Fatalf("encode png: %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width int, height int) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, image.NewRGBA(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("encode jpeg: %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		width, height, size int
+		wantW, wantH        int
+	}{
+		{100, 50, 200, 100, 50},
+		{400, 200, 100, 100, 50},
+		{200, 400, 100, 50, 100},
+		{300, 300, 150, 150, 150},
+		{1000, 1, 100, 100, 1},
+		{1, 1000, 100, 1, 100},
+	}
+
+	for _, tt := range tests {
+		width, height := fit(tt.width, tt.height, tt.size)
+		if width != tt.wantW || height != tt.wantH {
+			t.Errorf("fit(%d, %d, %d) = %dx%d, want %dx%d", tt.width, tt.height, tt.size, width, height, tt.wantW, tt.wantH)
+		}
+	}
+}
+
+func TestResizeAveragesPixels(t *testing.T) {
+	source := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	source.Set(0, 0, color.RGBA{R: 255, A: 255})
+	source.Set(1, 0, color.RGBA{R: 255, A: 255})
+	source.Set(0, 1, color.RGBA{A: 255})
+	source.Set(1, 1, color.RGBA{A: 255})
+
+	resized := resize(source, 1, 1)
+
+	if bounds := resized.Bounds(); bounds.Dx() != 1 || bounds.Dy() != 1 {
+		t.Fatalf("bounds = %v, want 1x1", bounds)
+	}
+
+	r, g, b, a := resized.At(0, 0).RGBA()
+	if r != 0xffff/2 || g != 0 || b != 0 || a != 0xffff {
+		t.Errorf("pixel = %d %d %d %d, want half red and opaque", r, g, b, a)
+	}
+}
+
+func TestUploadStoresOriginalAndThumbnails(t *testing.T) {
+	store := newMemoryStore()
+	service := NewService(&ServiceDeps{Store: store, MaxSize: 1 << 20, ThumbnailSizes: []int{16, 64}})
+
+	productImage, err := service.Upload(context.Background(), "product-1", encodePNG(t, 40, 20))
+	if err != nil {
+		t.Fatalf("upload: %v", err)
+	}
+
+	if productImage.ContentType != "image/png" || productImage.Width != 40 || productImage.Height != 20 {
+		t.Errorf("image = %s %dx%d, want image/png 40x20", productImage.ContentType, productImage.Width, productImage.Height)
+	}
+
+	if !strings.HasPrefix(productImage.Key, "products/product-1/") || !strings.HasSuffix(productImage.Key, "/original.png") {
+		t.Errorf("key = %q", productImage.Key)
+	}
+
+	if productImage.URL != store.URL(productImage.Key) {
+		t.Errorf("url = %q", productImage.URL)
+	}
+
+	if len(productImage.Thumbnails) != 2 {
+		t.Fatalf("thumbnails = %d, want 2", len(productImage.Thumbnails))
+	}
+
+	small, large := productImage.Thumbnails[0], productImage.Thumbnails[1]
+
+	if small.Width != 16 || small.Height != 8 {
+		t.Errorf("small thumbnail = %dx%d, want 16x8", small.Width, small.Height)
+	}
+
+	if large.Width != 40 || large.Height != 20 {
+		t.Errorf("large thumbnail = %dx%d, want original 40x20", large.Width, large.Height)
+	}
+
+	if len(store.blobs) != 3 {
+		t.Errorf("stored blobs = %d, want 3", len(store.blobs))
+	}
+
+	if store.types[small.Key] != "image/png" {
+		t.Errorf("thumbnail type = %q, want image/png", store.types[small.Key])
+	}
+
+	if err = service.Remove(context.Background(), *productImage); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+
+	if len(store.blobs) != 0 {
+		t.Errorf("stored blobs after remove = %d, want 0", len(store.blobs))
+	}
+}
+
+func TestUploadSniffsContent(t *testing.T) {
+	store := newMemoryStore()
+	service := NewService(&ServiceDeps{Store: store, MaxSize: 1 << 20, ThumbnailSizes: []int{8}})
+
+	productImage, err := service.Upload(context.Background(), "product-1", encodeJPEG(t, 10, 10))
+	if err != nil {
+		t.Fatalf("upload jpeg: %v", err)
+	}
+
+	if productImage.ContentType != "image/jpeg" || !strings.HasSuffix(productImage.Key, ".jpg") {
+		t.Errorf("jpeg image = %s %s", productImage.ContentType, productImage.Key)
+	}
+
+	rejected := map[string][]byte{
+		"text":      []byte("definitely not an image"),
+		"html":      []byte("<html><body>hi</body></html>"),
+		"truncated": encodePNG(t, 10, 10)[:40],
+	}
+
+	for name, data := range rejected {
+		if _, err = service.Upload(context.Background(), "product-1", data); !errors.Is(err, utils.ErrorMediaType) {
+			t.Errorf("%s: error = %v, want %v", name, err, utils.ErrorMediaType)
+		}
+	}
+}
+
+func TestUploadRejectsOversizedFiles(t *testing.T) {
+	data := encodePNG(t, 10, 10)
+
+	store := newMemoryStore()
+	service := NewService(&ServiceDeps{Store: store, MaxSize: int64(len(data) - 1)})
+
+	if _, err := service.Upload(context.Background(), "product-1", data); !errors.Is(err, utils.ErrorMediaSize) {
+		t.Fatalf("error = %v, want %v", err, utils.ErrorMediaSize)
+	}
+
+	service = NewService(&ServiceDeps{Store: store, MaxSize: int64(len(data))})
+
+	if _, err := service.Upload(context.Background(), "product-1", data); err != nil {
+		t.Fatalf("upload at the limit: %v", err)
+	}
+}
+
+func TestUploadRemovesBlobsWhenThumbnailFails(t *testing.T) {
+	store := newMemoryStore()
+	store.fail = "/16.png"
+
+	service := NewService(&ServiceDeps{Store: store, MaxSize: 1 << 20, ThumbnailSizes: []int{8, 16}})
+
+	if _, err := service.Upload(context.Background(), "product-1", encodePNG(t, 32, 32)); err == nil {
+		t.Fatal("upload succeeded, want error")
+	}
+
+	if len(store.blobs) != 0 {
+		t.Errorf("stored blobs = %d, want 0 after failed upload", len(store.blobs))
+	}
+}
@@ -0,0 +1,72 @@
+package media
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type BlobStore interface {
+	Put(ctx context.Context, key string, contentType string, body []byte) error
+	Delete(ctx context.Context, key string) error
+	URL(key string) string
+}
+
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+func NewLocalStore(dir string, baseURL string) *LocalStore {
+	return &LocalStore{
+		dir:     dir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+func (localStore *LocalStore) Put(ctx context.Context, key string, contentType string, body []byte) error {
+	name, err := localStore.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return errors.Wrap(err, "create blob directory")
+	}
+
+	if err = os.WriteFile(name, body, 0o644); err != nil {
+		return errors.Wrap(err, "write blob")
+	}
+
+	return nil
+}
+
+func (localStore *LocalStore) Delete(ctx context.Context, key string) error {
+	name, err := localStore.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Remove(name); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "delete blob")
+	}
+
+	return nil
+}
+
+func (localStore *LocalStore) URL(key string) string {
+	return localStore.baseURL + "/" + key
+}
+
+func (localStore *LocalStore) path(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" {
+		return "", errors.New("invalid blob key")
+	}
+
+	return filepath.Join(localStore.dir, filepath.FromSlash(cleaned)), nil
+}
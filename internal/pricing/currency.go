@@ -0,0 +1,183 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	basePrecision = 2
+	maxPrecision  = 4
+)
+
+func (engine *Engine) BaseCurrency() string {
+	return engine.baseCurrency
+}
+
+func (engine *Engine) Supports(currency string) bool {
+	for _, supported := range engine.supportedCurrencies {
+		if supported == currency {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (engine *Engine) Rate(ctx context.Context, currency string) (*model.ExchangeRate, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == utils.EmptyString {
+		currency = engine.baseCurrency
+	}
+
+	if !engine.Supports(currency) {
+		return nil, utils.ErrorUnsupportedCurrency
+	}
+
+	rate, err := engine.exchangeRateRepository.GetExchangeRate(ctx, currency)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		if currency == engine.baseCurrency {
+			return engine.baseRate(), nil
+		}
+
+		return nil, utils.ErrorExchangeRateMissing
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if currency == engine.baseCurrency {
+		rate.Rate = 1
+	}
+
+	return rate, nil
+}
+
+func (engine *Engine) Currencies(ctx context.Context) (*model.Currencies, error) {
+	rates, err := engine.exchangeRateRepository.GetExchangeRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.Currencies{
+		Base:      engine.baseCurrency,
+		Supported: engine.supportedCurrencies,
+		Rates:     *rates,
+	}, nil
+}
+
+func (engine *Engine) ValidateRates(rates []model.ExchangeRate) error {
+	for i := range rates {
+		rates[i].Currency = strings.ToUpper(rates[i].Currency)
+
+		if !engine.Supports(rates[i].Currency) {
+			return errors.Wrap(utils.ErrorUnsupportedCurrency, rates[i].Currency)
+		}
+
+		if rates[i].Rate <= 0 || rates[i].Precision < 0 || rates[i].Precision > maxPrecision {
+			return errors.Errorf("rate for %s must be positive with precision between 0 and %d", rates[i].Currency, maxPrecision)
+		}
+
+		if rates[i].Currency == engine.baseCurrency && rates[i].Rate != 1 {
+			return errors.Errorf("base currency %s must have rate 1", engine.baseCurrency)
+		}
+
+		if rates[i].Rounding == utils.EmptyString {
+			rates[i].Rounding = model.RoundingHalfUp
+		}
+	}
+
+	return nil
+}
+
+func (engine *Engine) ValidatePrices(product *model.Product) error {
+	for currency, price := range product.Prices {
+		if currency == engine.baseCurrency || !engine.Supports(currency) {
+			return errors.Wrap(utils.ErrorUnsupportedCurrency, currency)
+		}
+
+		value, err := ParsePrice(price)
+		if err != nil || value < 0 {
+			return errors.Errorf("price for %s must be a non-negative number", currency)
+		}
+	}
+
+	return nil
+}
+
+func (engine *Engine) baseRate() *model.ExchangeRate {
+	return &model.ExchangeRate{
+		Currency:  engine.baseCurrency,
+		Rate:      1,
+		Precision: basePrecision,
+		Rounding:  model.RoundingHalfUp,
+	}
+}
+
+func LoadExchangeRates(path string) ([]model.ExchangeRate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read exchange rates file")
+	}
+
+	var rates []model.ExchangeRate
+
+	if err = json.Unmarshal(data, &rates); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorUnmarshal.Error())
+	}
+
+	return rates, nil
+}
+
+func conversionFactor(product *model.Product, base float64, rate *model.ExchangeRate) (float64, error) {
+	override, ok := product.Prices[rate.Currency]
+	if !ok || base == 0 {
+		return rate.Rate, nil
+	}
+
+	price, err := ParsePrice(override)
+	if err != nil {
+		return 0, err
+	}
+
+	return price / base, nil
+}
+
+func convert(quote *model.PriceQuote, factor float64, rate *model.ExchangeRate) {
+	quote.Currency = rate.Currency
+	quote.BasePrice = rate.Round(quote.BasePrice * factor)
+	quote.FinalPrice = rate.Round(quote.FinalPrice * factor)
+
+	for i := range quote.Trace {
+		quote.Trace[i].Amount = rate.Round(quote.Trace[i].Amount * factor)
+		quote.Trace[i].PriceAfter = rate.Round(quote.Trace[i].PriceAfter * factor)
+	}
+
+	if quote.Quantity > 0 {
+		quote.Total = rate.Round(quote.FinalPrice * float64(quote.Quantity))
+	}
+}
+
+func normalizeCurrencies(base string, currencies []string) []string {
+	normalized := []string{strings.ToUpper(base)}
+
+	for _, currency := range currencies {
+		currency = strings.ToUpper(strings.TrimSpace(currency))
+		if currency == utils.EmptyString || currency == normalized[0] {
+			continue
+		}
+
+		normalized = append(normalized, currency)
+	}
+
+	return normalized
+}
@@ -0,0 +1,219 @@
+package pricing
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+)
+
+var targetRank = map[string]int{
+	model.PricingTargetProduct:     0,
+	model.PricingTargetSubcategory: 1,
+	model.PricingTargetCategory:    2,
+	model.PricingTargetTag:         3,
+}
+
+type EngineDeps struct {
+	PricingRuleRepository  repository.PricingRuleRepository
+	ExchangeRateRepository repository.ExchangeRateRepository
+	BaseCurrency           string
+	SupportedCurrencies    []string
+}
+
+type Engine struct {
+	pricingRuleRepository  repository.PricingRuleRepository
+	exchangeRateRepository repository.ExchangeRateRepository
+	baseCurrency           string
+	supportedCurrencies    []string
+}
+
+func NewEngine(deps *EngineDeps) *Engine {
+	return &Engine{
+		pricingRuleRepository:  deps.PricingRuleRepository,
+		exchangeRateRepository: deps.ExchangeRateRepository,
+		baseCurrency:           strings.ToUpper(deps.BaseCurrency),
+		supportedCurrencies:    normalizeCurrencies(deps.BaseCurrency, deps.SupportedCurrencies),
+	}
+}
+
+func (engine *Engine) QuoteProduct(ctx context.Context, product *model.Product, quantity int, rate *model.ExchangeRate) (*model.PriceQuote, error) {
+	quotes, err := engine.QuoteProducts(ctx, []model.Product{*product}, []int{quantity}, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &quotes[0], nil
+}
+
+func (engine *Engine) QuoteProducts(ctx context.Context, products []model.Product, quantities []int, rate *model.ExchangeRate) ([]model.PriceQuote, error) {
+	if rate == nil {
+		rate = engine.baseRate()
+	}
+
+	targets := make([]model.PricingTarget, 0, len(products))
+	for i := range products {
+		targets = append(targets, products[i].PricingTargets()...)
+	}
+
+	rules, err := engine.pricingRuleRepository.GetPricingRulesForTargets(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	byTarget := make(map[model.PricingTarget][]model.PricingRule, len(rules))
+	for _, rule := range rules {
+		target := model.PricingTarget{Type: rule.TargetType, ID: rule.TargetID}
+		byTarget[target] = append(byTarget[target], rule)
+	}
+
+	now := time.Now()
+	quotes := make([]model.PriceQuote, 0, len(products))
+
+	for i := range products {
+		base, err := ParsePrice(products[i].Price)
+		if err != nil {
+			quotes = append(quotes, unpriced(&products[i], rate, err))
+			continue
+		}
+
+		var applicable []model.PricingRule
+		for _, target := range products[i].PricingTargets() {
+			applicable = append(applicable, byTarget[target]...)
+		}
+
+		if discount := DiscountRule(&products[i]); discount != nil {
+			applicable = append(applicable, *discount)
+		}
+
+		quote := Evaluate(base, applicable, now)
+		quote.ProductID = products[i].ID
+
+		if i < len(quantities) && quantities[i] > 0 {
+			quote.Quantity = quantities[i]
+		}
+
+		factor, err := conversionFactor(&products[i], base, rate)
+		if err != nil {
+			quotes = append(quotes, unpriced(&products[i], rate, err))
+			continue
+		}
+
+		convert(&quote, factor, rate)
+
+		quotes = append(quotes, quote)
+	}
+
+	return quotes, nil
+}
+
+func Evaluate(base float64, rules []model.PricingRule, now time.Time) model.PriceQuote {
+	sorted := make([]model.PricingRule, len(rules))
+	copy(sorted, rules)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+
+		return targetRank[sorted[i].TargetType] < targetRank[sorted[j].TargetType]
+	})
+
+	quote := model.PriceQuote{
+		BasePrice: base,
+		Trace:     make([]model.PriceAdjustment, 0, len(sorted)),
+	}
+
+	price := base
+	applied := false
+	stopped := false
+
+	for _, rule := range sorted {
+		adjustment := model.PriceAdjustment{
+			RuleID:     rule.ID,
+			Title:      rule.Title,
+			TargetType: rule.TargetType,
+			TargetID:   rule.TargetID,
+			Priority:   rule.Priority,
+			PriceAfter: price,
+		}
+
+		switch {
+		case stopped:
+			adjustment.Skipped = model.PricingSkipStopped
+		case !rule.ActiveAt(now):
+			adjustment.Skipped = model.PricingSkipInactive
+		case rule.Exclusive && applied:
+			adjustment.Skipped = model.PricingSkipExclusive
+		default:
+			amount := rule.Amount
+			if rule.Percent > 0 {
+				amount = price * float64(rule.Percent) / 100
+			}
+
+			amount = round(math.Min(amount, price))
+			price = round(price - amount)
+
+			adjustment.Applied = true
+			adjustment.Amount = amount
+			adjustment.PriceAfter = price
+
+			applied = true
+			stopped = rule.Exclusive || rule.Final
+		}
+
+		quote.Trace = append(quote.Trace, adjustment)
+	}
+
+	quote.FinalPrice = price
+
+	return quote
+}
+
+func DiscountRule(product *model.Product) *model.PricingRule {
+	discount := product.Discount
+	if discount.Percent == 0 {
+		return nil
+	}
+
+	return &model.PricingRule{
+		ID:         discount.ID,
+		Title:      discount.Title,
+		TargetType: model.PricingTargetProduct,
+		TargetID:   product.ID,
+		Percent:    discount.Percent,
+		IsActive:   discount.IsActive,
+		StartsAt:   discount.StartsAt,
+		EndsAt:     discount.EndsAt,
+	}
+}
+
+func ParsePrice(price string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(strings.ReplaceAll(price, ",", ".")), 64)
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return value, nil
+}
+
+func unpriced(product *model.Product, rate *model.ExchangeRate, err error) model.PriceQuote {
+	return model.PriceQuote{
+		ProductID: product.ID,
+		Currency:  rate.Currency,
+		Trace:     []model.PriceAdjustment{},
+		Error:     errors.Wrap(err, utils.ErrorProductPrice.Error()).Error(),
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
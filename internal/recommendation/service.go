@@ -0,0 +1,117 @@
+package recommendation
+
+import (
+	"context"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+)
+
+type ServiceDeps struct {
+	RecommendationRepository repository.RecommendationRepository
+	ProductRepository        repository.ProductRepository
+	Limit                    int
+	CandidateLimit           int64
+}
+
+type Service struct {
+	recommendationRepository repository.RecommendationRepository
+	productRepository        repository.ProductRepository
+	limit                    int
+	candidateLimit           int64
+}
+
+func NewService(deps *ServiceDeps) *Service {
+	return &Service{
+		recommendationRepository: deps.RecommendationRepository,
+		productRepository:        deps.ProductRepository,
+		limit:                    deps.Limit,
+		candidateLimit:           deps.CandidateLimit,
+	}
+}
+
+func (service *Service) Related(ctx context.Context, product *model.Product, limit int) (*model.RelatedProducts, error) {
+	if limit <= 0 {
+		limit = service.limit
+	}
+
+	override, err := service.recommendationRepository.GetRelatedOverride(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	taken := map[string]bool{product.ID: true}
+	for _, id := range override.Excluded {
+		taken[id] = true
+	}
+
+	related := &model.RelatedProducts{}
+
+	related.Pinned, err = service.pick(ctx, override.Pinned, taken, len(override.Pinned))
+	if err != nil {
+		return nil, err
+	}
+
+	coPurchase, err := service.recommendationRepository.GetCoPurchase(ctx, product.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	related.BoughtTogether, err = service.pick(ctx, coPurchase.ProductIDs(), taken, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := service.recommendationRepository.GetSimilarCandidates(ctx, product, service.candidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	available := make([]model.Product, 0, len(candidates))
+	for _, candidate := range candidates {
+		if !taken[candidate.ID] {
+			available = append(available, candidate)
+		}
+	}
+
+	related.Similar = Similar(product, available, limit)
+
+	return related, nil
+}
+
+func (service *Service) pick(ctx context.Context, ids []string, taken map[string]bool, limit int) ([]model.Product, error) {
+	picked := make([]model.Product, 0, limit)
+
+	wanted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !taken[id] {
+			wanted = append(wanted, id)
+		}
+	}
+
+	if len(wanted) == 0 {
+		return picked, nil
+	}
+
+	products, err := service.productRepository.GetProductsByIDs(ctx, wanted)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]model.Product, len(*products))
+	for _, product := range *products {
+		byID[product.ID] = product
+	}
+
+	for _, id := range wanted {
+		product, ok := byID[id]
+		if !ok || !product.IsPublished() || taken[id] || len(picked) >= limit {
+			continue
+		}
+
+		taken[id] = true
+		picked = append(picked, product)
+	}
+
+	return picked, nil
+}
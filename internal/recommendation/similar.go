@@ -0,0 +1,84 @@
+package recommendation
+
+import (
+	"math"
+	"sort"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/pricing"
+)
+
+const (
+	tagWeight         = 2.0
+	subcategoryWeight = 3.0
+	categoryWeight    = 1.0
+	priceWeight       = 2.0
+)
+
+type scoredProduct struct {
+	product model.Product
+	score   float64
+}
+
+func Similar(product *model.Product, candidates []model.Product, limit int) []model.Product {
+	tags := make(map[string]bool, len(product.Tags))
+	for _, tag := range product.Tags {
+		tags[tag.ID] = true
+	}
+
+	price, _ := pricing.ParsePrice(product.Price)
+
+	scored := make([]scoredProduct, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		score := 0.0
+
+		for _, tag := range candidate.Tags {
+			if tags[tag.ID] {
+				score += tagWeight
+			}
+		}
+
+		if product.Subcategory.ID != "" && candidate.Subcategory.ID == product.Subcategory.ID {
+			score += subcategoryWeight
+		}
+
+		if product.Category.ID != "" && candidate.Category.ID == product.Category.ID {
+			score += categoryWeight
+		}
+
+		candidatePrice, err := pricing.ParsePrice(candidate.Price)
+		if err == nil {
+			score += priceWeight * priceProximity(price, candidatePrice)
+		}
+
+		scored = append(scored, scoredProduct{product: candidate, score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score > scored[j].score
+		}
+
+		return scored[i].product.ID < scored[j].product.ID
+	})
+
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	similar := make([]model.Product, 0, len(scored))
+	for _, item := range scored {
+		similar = append(similar, item.product)
+	}
+
+	return similar
+}
+
+func priceProximity(a float64, b float64) float64 {
+	if a <= 0 || b <= 0 {
+		return 0
+	}
+
+	return 1 - math.Abs(a-b)/math.Max(a, b)
+}
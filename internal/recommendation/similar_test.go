@@ -0,0 +1,75 @@
+package recommendation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+func productIDs(products []model.Product) []string {
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	return ids
+}
+
+func TestSimilarRanksByScore(t *testing.T) {
+	product := &model.Product{
+		ID:          "shirt",
+		Price:       "100",
+		Tags:        []model.Tag{{ID: "cotton"}, {ID: "summer"}},
+		Subcategory: model.Subcategory{ID: "shirts"},
+		Category:    model.Category{ID: "clothes"},
+	}
+
+	candidates := []model.Product{
+		{ID: "unrelated", Price: "free"},
+		{ID: "same-category", Price: "100", Category: model.Category{ID: "clothes"}},
+		{ID: "shared-tags", Price: "50", Tags: []model.Tag{{ID: "cotton"}, {ID: "summer"}}},
+		{ID: "same-subcategory", Price: "100", Subcategory: model.Subcategory{ID: "shirts"}},
+	}
+
+	want := []string{"same-subcategory", "shared-tags", "same-category", "unrelated"}
+	if got := productIDs(Similar(product, candidates, 10)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Similar = %v, want %v", got, want)
+	}
+}
+
+func TestSimilarBreaksTiesByID(t *testing.T) {
+	product := &model.Product{ID: "shirt", Price: "100", Category: model.Category{ID: "clothes"}}
+
+	candidates := []model.Product{
+		{ID: "c", Price: "100", Category: model.Category{ID: "clothes"}},
+		{ID: "a", Price: "100", Category: model.Category{ID: "clothes"}},
+		{ID: "b", Price: "100", Category: model.Category{ID: "clothes"}},
+	}
+
+	want := []string{"a", "b"}
+	if got := productIDs(Similar(product, candidates, 2)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Similar = %v, want %v", got, want)
+	}
+}
+
+func TestPriceProximity(t *testing.T) {
+	cases := []struct {
+		a    float64
+		b    float64
+		want float64
+	}{
+		{a: 100, b: 100, want: 1},
+		{a: 100, b: 50, want: 0.5},
+		{a: 50, b: 100, want: 0.5},
+		{a: 0, b: 100, want: 0},
+		{a: 100, b: -1, want: 0},
+	}
+
+	for _, c := range cases {
+		if got := priceProximity(c.a, c.b); math.Abs(got-c.want) > 1e-9 {
+			t.Errorf("priceProximity(%v, %v) = %v, want %v", c.a, c.b, got, c.want)
+		}
+	}
+}
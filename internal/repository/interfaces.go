@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/Meystergod/online-store/internal/domain/model"
 )
@@ -9,28 +10,74 @@ import (
 type ProductRepository interface {
 	CreateProduct(ctx context.Context, product *model.Product) (string, error)
 	GetProduct(ctx context.Context, uuid string) (*model.Product, error)
-	GetProductByTitle(ctx context.Context, title string) (*model.Product, error)
-	GetAllProducts(ctx context.Context) (*[]model.Product, error)
+	GetProductByTitle(ctx context.Context, title string, locale string) (*model.Product, error)
+	GetProductBySlug(ctx context.Context, slug string) (*model.Product, error)
+	GetAllProducts(ctx context.Context, filter model.ProductFilter) (*[]model.Product, error)
+	GetProductFacets(ctx context.Context, filter model.ProductFilter, boundaries []float64) (*model.ProductFacets, error)
 	UpdateProduct(ctx context.Context, product *model.Product) error
 	DeleteProduct(ctx context.Context, uuid string) error
+	RestoreProduct(ctx context.Context, uuid string) error
+	SetProductTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error
+	SetProductStatus(ctx context.Context, product *model.Product, from string) error
+	PublishScheduledProducts(ctx context.Context, now time.Time) (int64, error)
+	UnpublishScheduledProducts(ctx context.Context, now time.Time) (int64, error)
+	GetNextPublicationTransition(ctx context.Context, now time.Time) (*time.Time, error)
+	GetDeletedProducts(ctx context.Context) (*[]model.Product, error)
+	PurgeProducts(ctx context.Context, before time.Time) (int64, error)
+	GetPurgeableProductImages(ctx context.Context, before time.Time) (*[]model.Product, error)
+	BulkCreateProducts(ctx context.Context, products []model.Product, ordered bool) ([]model.BulkResult, error)
+	BulkUpdateProducts(ctx context.Context, products []model.Product, ordered bool) ([]model.BulkResult, error)
+	BulkDeleteProducts(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error)
+	BulkAddProductTags(ctx context.Context, uuids []string, tags []model.Tag, ordered bool) ([]model.BulkResult, error)
+	BulkRemoveProductTags(ctx context.Context, uuids []string, tagIDs []string, ordered bool) ([]model.BulkResult, error)
+	GetProductsByCategories(ctx context.Context, categoryIDs []string) (*[]model.Product, error)
+	GetCategoryProductAttributes(ctx context.Context, categoryID string) (*[]model.Product, error)
+	GetProductsByIDs(ctx context.Context, uuids []string) (*[]model.Product, error)
+	GetProductBySKU(ctx context.Context, sku string) (*model.Product, error)
+	AddProductVariant(ctx context.Context, uuid string, variant *model.Variant) error
+	UpdateProductVariant(ctx context.Context, uuid string, sku string, variant *model.Variant) error
+	DeleteProductVariant(ctx context.Context, uuid string, sku string) error
+	AddProductImages(ctx context.Context, uuid string, images []model.ProductImage) error
+	SetProductImages(ctx context.Context, uuid string, images []model.ProductImage) error
+	DeleteProductImage(ctx context.Context, uuid string, imageID string) error
 }
 
 type CategoryRepository interface {
 	CreateCategory(ctx context.Context, category *model.Category) (string, error)
 	GetCategory(ctx context.Context, uuid string) (*model.Category, error)
-	GetCategoryByTitle(ctx context.Context, title string) (*model.Category, error)
+	GetCategoryByTitle(ctx context.Context, title string, locale string) (*model.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error)
 	GetAllCategories(ctx context.Context) (*[]model.Category, error)
 	UpdateCategory(ctx context.Context, category *model.Category) error
 	DeleteCategory(ctx context.Context, uuid string) error
+	RestoreCategory(ctx context.Context, uuid string) error
+	SetCategoryTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error
+	GetDeletedCategories(ctx context.Context) (*[]model.Category, error)
+	PurgeCategories(ctx context.Context, before time.Time) (int64, error)
+	BulkCreateCategories(ctx context.Context, categories []model.Category, ordered bool) ([]model.BulkResult, error)
+	BulkUpdateCategories(ctx context.Context, categories []model.Category, ordered bool) ([]model.BulkResult, error)
+	BulkDeleteCategories(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error)
+	MoveCategory(ctx context.Context, uuid string, parentID string) (*model.Category, error)
+	GetCategoryDescendants(ctx context.Context, uuid string) (*[]model.Category, error)
+	GetCategoryAncestors(ctx context.Context, uuid string) (*[]model.Category, error)
 }
 
 type SubcategoryRepository interface {
 	CreateSubcategory(ctx context.Context, category *model.Subcategory) (string, error)
 	GetSubcategory(ctx context.Context, uuid string) (*model.Subcategory, error)
-	GetSubcategoryByTitle(ctx context.Context, title string) (*model.Subcategory, error)
+	GetSubcategoryByTitle(ctx context.Context, title string, locale string) (*model.Subcategory, error)
 	GetAllSubcategories(ctx context.Context) (*[]model.Subcategory, error)
 	UpdateSubcategory(ctx context.Context, subcategory *model.Subcategory) error
 	DeleteSubcategory(ctx context.Context, uuid string) error
+	RestoreSubcategory(ctx context.Context, uuid string) error
+	SetSubcategoryTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error
+	GetDeletedSubcategories(ctx context.Context) (*[]model.Subcategory, error)
+	PurgeSubcategories(ctx context.Context, before time.Time) (int64, error)
+	BulkCreateSubcategories(ctx context.Context, subcategories []model.Subcategory, ordered bool) ([]model.BulkResult, error)
+	BulkUpdateSubcategories(ctx context.Context, subcategories []model.Subcategory, ordered bool) ([]model.BulkResult, error)
+	BulkDeleteSubcategories(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error)
+	GetSubcategoriesByCategory(ctx context.Context, categoryID string) (*[]model.Subcategory, error)
+	AssignSubcategory(ctx context.Context, uuid string, categoryID string) error
 }
 
 type DiscountRepository interface {
@@ -40,13 +87,160 @@ type DiscountRepository interface {
 	GetAllDiscounts(ctx context.Context) (*[]model.Discount, error)
 	UpdateDiscount(ctx context.Context, discount *model.Discount) error
 	DeleteDiscount(ctx context.Context, uuid string) error
+	RestoreDiscount(ctx context.Context, uuid string) error
+	GetDeletedDiscounts(ctx context.Context) (*[]model.Discount, error)
+	PurgeDiscounts(ctx context.Context, before time.Time) (int64, error)
+	BulkCreateDiscounts(ctx context.Context, discounts []model.Discount, ordered bool) ([]model.BulkResult, error)
+	BulkUpdateDiscounts(ctx context.Context, discounts []model.Discount, ordered bool) ([]model.BulkResult, error)
+	BulkDeleteDiscounts(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error)
+	ActivateScheduledDiscounts(ctx context.Context, now time.Time) (int64, error)
+	ExpireDiscounts(ctx context.Context, now time.Time) (int64, error)
+	GetNextDiscountTransition(ctx context.Context, now time.Time) (*time.Time, error)
 }
 
 type TagRepository interface {
 	CreateTag(ctx context.Context, tag *model.Tag) (string, error)
 	GetTag(ctx context.Context, uuid string) (*model.Tag, error)
-	GetTagByTitle(ctx context.Context, title string) (*model.Tag, error)
+	GetTagByTitle(ctx context.Context, title string, locale string) (*model.Tag, error)
 	GetAllTags(ctx context.Context) (*[]model.Tag, error)
 	UpdateTag(ctx context.Context, tag *model.Tag) error
 	DeleteTag(ctx context.Context, uuid string) error
+	RestoreTag(ctx context.Context, uuid string) error
+	SetTagTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error
+	GetDeletedTags(ctx context.Context) (*[]model.Tag, error)
+	PurgeTags(ctx context.Context, before time.Time) (int64, error)
+	BulkCreateTags(ctx context.Context, tags []model.Tag, ordered bool) ([]model.BulkResult, error)
+	BulkUpdateTags(ctx context.Context, tags []model.Tag, ordered bool) ([]model.BulkResult, error)
+	BulkDeleteTags(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error)
+	SuggestTags(ctx context.Context, prefix string, locales []string, limit int) ([]model.Tag, error)
+	MergeTags(ctx context.Context, sourceID string, target *model.Tag) (*model.TagMerge, error)
+}
+
+type CouponRepository interface {
+	CreateCoupon(ctx context.Context, coupon *model.Coupon) (string, error)
+	GetCoupon(ctx context.Context, uuid string) (*model.Coupon, error)
+	GetCouponByCode(ctx context.Context, code string) (*model.Coupon, error)
+	GetAllCoupons(ctx context.Context) (*[]model.Coupon, error)
+	UpdateCoupon(ctx context.Context, coupon *model.Coupon) error
+	DeleteCoupon(ctx context.Context, uuid string) error
+	CountCouponRedemptions(ctx context.Context, code string, customerID string) (int, error)
+	RedeemCoupon(ctx context.Context, code string, customerID string, orderValue float64, now time.Time) (*model.Coupon, *model.CouponRedemption, error)
+	ReleaseCoupon(ctx context.Context, redemptionID string) (*model.CouponRedemption, error)
+}
+
+type PricingRuleRepository interface {
+	CreatePricingRule(ctx context.Context, rule *model.PricingRule) (string, error)
+	GetPricingRule(ctx context.Context, uuid string) (*model.PricingRule, error)
+	GetAllPricingRules(ctx context.Context) (*[]model.PricingRule, error)
+	GetPricingRulesForTargets(ctx context.Context, targets []model.PricingTarget) ([]model.PricingRule, error)
+	UpdatePricingRule(ctx context.Context, rule *model.PricingRule) error
+	DeletePricingRule(ctx context.Context, uuid string) error
+}
+
+type RecommendationRepository interface {
+	RefreshCoPurchases(ctx context.Context, since time.Time, depth int) (int64, error)
+	GetCoPurchase(ctx context.Context, productID string) (*model.CoPurchase, error)
+	GetSimilarCandidates(ctx context.Context, product *model.Product, limit int64) ([]model.Product, error)
+	GetRelatedOverride(ctx context.Context, productID string) (*model.RelatedOverride, error)
+	SaveRelatedOverride(ctx context.Context, override *model.RelatedOverride) error
+	DeleteRelatedOverride(ctx context.Context, productID string) error
+}
+
+type ExchangeRateRepository interface {
+	GetExchangeRate(ctx context.Context, currency string) (*model.ExchangeRate, error)
+	GetExchangeRates(ctx context.Context) (*[]model.ExchangeRate, error)
+	SaveExchangeRates(ctx context.Context, rates []model.ExchangeRate) error
+	DeleteExchangeRate(ctx context.Context, currency string) error
+}
+
+type InventoryRepository interface {
+	PostStockMovement(ctx context.Context, movement *model.StockMovement) (*model.StockLevel, error)
+	PostStockMovements(ctx context.Context, movements []*model.StockMovement) (*model.StockLevel, error)
+	GetStockLevel(ctx context.Context, productID string) (*model.StockLevel, error)
+	GetStockMovements(ctx context.Context, productID string, limit int64) (*[]model.StockMovement, error)
+	GetLowStockProducts(ctx context.Context) (*[]model.LowStockItem, error)
+	GetWarehouseStocks(ctx context.Context, productIDs []string) ([]model.WarehouseStock, error)
+	HasWarehouseStock(ctx context.Context, warehouseID string) (bool, error)
+	HasOrderSale(ctx context.Context, productID string, orderID string) (bool, error)
+}
+
+type WarehouseRepository interface {
+	CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) (string, error)
+	GetWarehouse(ctx context.Context, uuid string) (*model.Warehouse, error)
+	GetAllWarehouses(ctx context.Context) (*[]model.Warehouse, error)
+	UpdateWarehouse(ctx context.Context, warehouse *model.Warehouse) error
+	DeleteWarehouse(ctx context.Context, uuid string) error
+}
+
+type ReviewRepository interface {
+	CreateReview(ctx context.Context, review *model.Review) (string, error)
+	GetReview(ctx context.Context, uuid string) (*model.Review, error)
+	GetReviews(ctx context.Context, filter model.ReviewFilter) (*[]model.Review, error)
+	UpdateReview(ctx context.Context, review *model.Review) error
+	ModerateReview(ctx context.Context, review *model.Review) error
+	DeleteReview(ctx context.Context, uuid string) error
+}
+
+type WishlistRepository interface {
+	CreateWishlist(ctx context.Context, wishlist *model.Wishlist) (string, error)
+	GetWishlist(ctx context.Context, uuid string) (*model.Wishlist, error)
+	GetWishlistByShareToken(ctx context.Context, token string) (*model.Wishlist, error)
+	GetWishlistsByCustomer(ctx context.Context, customerID string) (*[]model.Wishlist, error)
+	RenameWishlist(ctx context.Context, uuid string, name string) error
+	SetWishlistShareToken(ctx context.Context, uuid string, token string) error
+	AddWishlistItem(ctx context.Context, uuid string, item *model.WishlistItem) error
+	RemoveWishlistItem(ctx context.Context, uuid string, productID string) error
+	DeleteWishlist(ctx context.Context, uuid string) error
+}
+
+type CartRepository interface {
+	GetCart(ctx context.Context, customerID string) (*model.Cart, error)
+	SetCartItem(ctx context.Context, customerID string, item *model.CartItem) error
+	RemoveCartItem(ctx context.Context, customerID string, sku string) error
+	ClearCart(ctx context.Context, customerID string) error
+}
+
+type OrderRepository interface {
+	CreateOrder(ctx context.Context, order *model.Order, cart *model.Cart, movements []*model.StockMovement) (string, error)
+	GetOrder(ctx context.Context, uuid string) (*model.Order, error)
+	GetOrdersByCustomer(ctx context.Context, customerID string) (*[]model.Order, error)
+}
+
+type StockAlertRepository interface {
+	OpenStockAlert(ctx context.Context, alert *model.StockAlert) (*model.StockAlert, error)
+	MarkStockAlertNotified(ctx context.Context, uuid string, at time.Time) error
+	ResolveStockAlerts(ctx context.Context, productID string, at time.Time) (int64, error)
+	GetOpenStockAlerts(ctx context.Context) (*[]model.StockAlert, error)
+}
+
+type AuditRepository interface {
+	CreateAuditEntries(ctx context.Context, entries []model.AuditEntry) error
+	GetAuditEntries(ctx context.Context, filter model.AuditFilter) (*[]model.AuditEntry, error)
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (string, error)
+	GetWebhook(ctx context.Context, uuid string) (*model.Webhook, error)
+	GetAllWebhooks(ctx context.Context) (*[]model.Webhook, error)
+	GetActiveWebhooks(ctx context.Context) (*[]model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) error
+	DeleteWebhook(ctx context.Context, uuid string) error
+}
+
+type WebhookDeliveryRepository interface {
+	CreateWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error
+	ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, uuid string, attempts int) error
+	RetryWebhookDeliveryAt(ctx context.Context, uuid string, attempts int, next time.Time, lastError string) error
+	MarkWebhookDeliveryDead(ctx context.Context, uuid string, attempts int, lastError string) error
+	GetDeadWebhookDeliveries(ctx context.Context) (*[]model.WebhookDelivery, error)
+	RequeueWebhookDelivery(ctx context.Context, uuid string) error
+}
+
+type OutboxRepository interface {
+	ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (*model.OutboxMessage, error)
+	MarkOutboxMessagePublished(ctx context.Context, uuid string, attempts int) error
+	RetryOutboxMessageAt(ctx context.Context, uuid string, attempts int, next time.Time, lastError string) error
+	GetOutboxStats(ctx context.Context) (*model.OutboxStats, error)
+	PurgePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error)
 }
@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type stockAlertRepository struct {
+	collection *mongo.Collection
+}
+
+func NewStockAlertRepository(storage *mongo.Database, collection string) repository.StockAlertRepository {
+	return &stockAlertRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (stockAlertRepository *stockAlertRepository) OpenStockAlert(ctx context.Context, alert *model.StockAlert) (*model.StockAlert, error) {
+	var opened *model.StockAlert
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"product-id": alert.ProductID, "status": model.StockAlertOpen}
+
+	update := bson.M{
+		"$setOnInsert": bson.M{
+			"title":      alert.Title,
+			"threshold":  alert.Threshold,
+			"created-at": alert.CreatedAt,
+		},
+		"$set": bson.M{"quantity": alert.Quantity},
+	}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	result := stockAlertRepository.collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if result.Err() != nil {
+		return opened, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&opened); err != nil {
+		return opened, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return opened, nil
+}
+
+func (stockAlertRepository *stockAlertRepository) MarkStockAlertNotified(ctx context.Context, uuid string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	update := bson.M{
+		"$set": bson.M{"notified-at": at},
+	}
+
+	if _, err = stockAlertRepository.collection.UpdateOne(ctx, bson.M{"_id": oid}, update); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (stockAlertRepository *stockAlertRepository) ResolveStockAlerts(ctx context.Context, productID string, at time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"product-id": productID, "status": model.StockAlertOpen}
+
+	update := bson.M{
+		"$set": bson.M{"status": model.StockAlertResolved, "resolved-at": at},
+	}
+
+	result, err := stockAlertRepository.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return result.ModifiedCount, nil
+}
+
+func (stockAlertRepository *stockAlertRepository) GetOpenStockAlerts(ctx context.Context) (*[]model.StockAlert, error) {
+	var alerts []model.StockAlert
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"status": model.StockAlertOpen}
+	opts := options.Find().SetSort(bson.M{"created-at": -1})
+
+	cursor, err := stockAlertRepository.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return &alerts, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &alerts); err != nil {
+		return &alerts, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &alerts, nil
+}
@@ -0,0 +1,98 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type auditRepository struct {
+	collection *mongo.Collection
+}
+
+func NewAuditRepository(storage *mongo.Database, collection string) repository.AuditRepository {
+	return &auditRepository{
+		collection: storage.Collection(collection, options.Collection().SetRegistry(documentRegistry())),
+	}
+}
+
+func (auditRepository *auditRepository) CreateAuditEntries(ctx context.Context, entries []model.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	documents := make([]interface{}, 0, len(entries))
+	for i := range entries {
+		documents = append(documents, entries[i])
+	}
+
+	_, err := auditRepository.collection.InsertMany(ctx, documents)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (auditRepository *auditRepository) GetAuditEntries(ctx context.Context, filter model.AuditFilter) (*[]model.AuditEntry, error) {
+	var entries []model.AuditEntry
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	query := bson.M{}
+
+	if filter.EntityType != utils.EmptyString {
+		query["entity-type"] = filter.EntityType
+	}
+
+	if filter.EntityID != utils.EmptyString {
+		query["entity-id"] = filter.EntityID
+	}
+
+	if filter.Actor != utils.EmptyString {
+		query["actor"] = filter.Actor
+	}
+
+	if filter.From != nil || filter.To != nil {
+		timestamp := bson.M{}
+
+		if filter.From != nil {
+			timestamp["$gte"] = *filter.From
+		}
+
+		if filter.To != nil {
+			timestamp["$lte"] = *filter.To
+		}
+
+		query["timestamp"] = timestamp
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}}).
+		SetLimit(filter.Limit)
+
+	cursor, err := auditRepository.collection.Find(ctx, query, opts)
+	if err != nil {
+		return &entries, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &entries); err != nil {
+		return &entries, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &entries, nil
+}
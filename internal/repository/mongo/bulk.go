@@ -0,0 +1,348 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var errBulkWriteFailed = errors.New("bulk write failed")
+
+type bulkHook func(ctx mongo.SessionContext, write func() ([]model.BulkResult, error)) ([]model.BulkResult, error)
+
+type bulkOperation struct {
+	writeModel mongo.WriteModel
+	event      model.Event
+	result     model.BulkResult
+}
+
+func toDocument(value interface{}) (bson.M, error) {
+	valueByte, err := bson.Marshal(value)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorMarshal.Error())
+	}
+
+	var object bson.M
+
+	if err = bson.Unmarshal(valueByte, &object); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorUnmarshal.Error())
+	}
+
+	return object, nil
+}
+
+func setOrUnset(object bson.M, optionalFields ...string) bson.M {
+	update := bson.M{"$set": object}
+
+	unset := bson.M{}
+	for _, field := range optionalFields {
+		if _, ok := object[field]; !ok {
+			unset[field] = utils.EmptyString
+		}
+	}
+
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+
+	return update
+}
+
+func existingIDs(ctx context.Context, collection *mongo.Collection, oids []primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	existing := make(map[primitive.ObjectID]bool, len(oids))
+
+	if len(oids) == 0 {
+		return existing, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": oids}, fieldDeletedAt: notDeleted}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	var documents []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	for _, document := range documents {
+		existing[document.ID] = true
+	}
+
+	return existing, nil
+}
+
+func bulkCreate(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, documents []interface{}, ordered bool, hook bulkHook) ([]model.BulkResult, error) {
+	operations := make([]bulkOperation, 0, len(documents))
+
+	for _, document := range documents {
+		object, err := toDocument(document)
+		if err != nil {
+			return nil, err
+		}
+
+		oid := primitive.NewObjectID()
+		object["_id"] = oid
+
+		operations = append(operations, bulkOperation{
+			writeModel: mongo.NewInsertOneModel().SetDocument(object),
+			event:      newEvent(entityType, model.EventActionCreated, oid.Hex(), object),
+			result:     model.BulkResult{ID: oid.Hex()},
+		})
+	}
+
+	return executeBulk(ctx, outbox, collection, operations, ordered, model.BulkStatusCreated, hook)
+}
+
+func bulkUpdate(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, ids []string, documents []interface{}, ordered bool, hook bulkHook, optionalFields ...string) ([]model.BulkResult, error) {
+	oids, operations := parseBulkIDs(ids)
+
+	existing, err := existingIDs(ctx, collection, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range operations {
+		if operations[i].result.Status != utils.EmptyString {
+			continue
+		}
+
+		oid, _ := primitive.ObjectIDFromHex(ids[i])
+		if !existing[oid] {
+			operations[i].result.Status = model.BulkStatusNotFound
+			operations[i].result.Error = "not found"
+			continue
+		}
+
+		object, err := toDocument(documents[i])
+		if err != nil {
+			return nil, err
+		}
+
+		delete(object, "_id")
+
+		operations[i].writeModel = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": oid, fieldDeletedAt: notDeleted}).
+			SetUpdate(setOrUnset(object, optionalFields...))
+		operations[i].event = newEvent(entityType, model.EventActionUpdated, ids[i], object)
+	}
+
+	return executeBulk(ctx, outbox, collection, operations, ordered, model.BulkStatusUpdated, hook)
+}
+
+func bulkDelete(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, ids []string, ordered bool, hook bulkHook) ([]model.BulkResult, error) {
+	oids, operations := parseBulkIDs(ids)
+	deletedAt := time.Now().UTC()
+
+	existing, err := existingIDs(ctx, collection, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range operations {
+		if operations[i].result.Status != utils.EmptyString {
+			continue
+		}
+
+		oid, _ := primitive.ObjectIDFromHex(ids[i])
+		if !existing[oid] {
+			operations[i].result.Status = model.BulkStatusNotFound
+			operations[i].result.Error = "not found"
+			continue
+		}
+
+		operations[i].writeModel = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": oid, fieldDeletedAt: notDeleted}).
+			SetUpdate(bson.M{"$set": bson.M{fieldDeletedAt: deletedAt}})
+		operations[i].event = newEvent(entityType, model.EventActionDeleted, ids[i], nil)
+	}
+
+	return executeBulk(ctx, outbox, collection, operations, ordered, model.BulkStatusDeleted, hook)
+}
+
+func bulkPatch(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, ids []string, update interface{}, data bson.M, ordered bool, hook bulkHook) ([]model.BulkResult, error) {
+	oids, operations := parseBulkIDs(ids)
+
+	existing, err := existingIDs(ctx, collection, oids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range operations {
+		if operations[i].result.Status != utils.EmptyString {
+			continue
+		}
+
+		oid, _ := primitive.ObjectIDFromHex(ids[i])
+		if !existing[oid] {
+			operations[i].result.Status = model.BulkStatusNotFound
+			operations[i].result.Error = "not found"
+			continue
+		}
+
+		operations[i].writeModel = mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": oid, fieldDeletedAt: notDeleted}).
+			SetUpdate(update)
+		operations[i].event = newEvent(entityType, model.EventActionUpdated, ids[i], data)
+	}
+
+	return executeBulk(ctx, outbox, collection, operations, ordered, model.BulkStatusUpdated, hook)
+}
+
+func parseBulkIDs(ids []string) ([]primitive.ObjectID, []bulkOperation) {
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	operations := make([]bulkOperation, len(ids))
+
+	for i, id := range ids {
+		operations[i].result.ID = id
+
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			operations[i].result.Status = model.BulkStatusInvalid
+			operations[i].result.Error = utils.ErrorConvert.Error()
+			continue
+		}
+
+		oids = append(oids, oid)
+	}
+
+	return oids, operations
+}
+
+func executeBulk(ctx context.Context, outbox *outbox, collection *mongo.Collection, operations []bulkOperation, ordered bool, successStatus string, hook bulkHook) ([]model.BulkResult, error) {
+	results := make([]model.BulkResult, len(operations))
+	for i, operation := range operations {
+		results[i] = operation.result
+		results[i].Index = i
+	}
+
+	positions := make([]int, 0, len(operations))
+	stopped := false
+
+	for i, operation := range operations {
+		if stopped {
+			results[i].Status = model.BulkStatusSkipped
+			results[i].Error = utils.EmptyString
+			continue
+		}
+
+		if operation.writeModel == nil {
+			stopped = ordered
+			continue
+		}
+
+		positions = append(positions, i)
+	}
+
+	for len(positions) > 0 {
+		var (
+			applied  []model.BulkResult
+			failures []mongo.BulkWriteError
+		)
+
+		err := outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			events := make([]model.Event, 0, len(positions))
+
+			write := func() ([]model.BulkResult, error) {
+				writeModels := make([]mongo.WriteModel, 0, len(positions))
+				for _, position := range positions {
+					writeModels = append(writeModels, operations[position].writeModel)
+				}
+
+				_, err := collection.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(ordered))
+				if err != nil {
+					var bulkErr mongo.BulkWriteException
+					if errors.As(err, &bulkErr) && len(bulkErr.WriteErrors) > 0 {
+						failures = bulkErr.WriteErrors
+						return nil, errBulkWriteFailed
+					}
+
+					return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+				}
+
+				written := append([]model.BulkResult(nil), results...)
+				events = events[:0]
+
+				for _, position := range positions {
+					written[position].Status = successStatus
+					events = append(events, operations[position].event)
+				}
+
+				return written, nil
+			}
+
+			var err error
+			if hook != nil {
+				applied, err = hook(ctx, write)
+			} else {
+				applied, err = write()
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			return events, nil
+		})
+		if errors.Is(err, errBulkWriteFailed) {
+			positions = dropFailedWrites(results, positions, failures, ordered)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return applied, nil
+	}
+
+	return results, nil
+}
+
+func dropFailedWrites(results []model.BulkResult, positions []int, failures []mongo.BulkWriteError, ordered bool) []int {
+	failed := make(map[int]mongo.BulkWriteError, len(failures))
+	for _, failure := range failures {
+		failed[failure.Index] = failure
+	}
+
+	remaining := make([]int, 0, len(positions))
+
+	for index, position := range positions {
+		failure, ok := failed[index]
+		if !ok {
+			remaining = append(remaining, position)
+			continue
+		}
+
+		results[position].Status = model.BulkStatusFailed
+		if mongo.IsDuplicateKeyError(failure.WriteError) {
+			results[position].Status = model.BulkStatusConflict
+		}
+
+		results[position].Error = failure.Message
+
+		if ordered {
+			for _, skipped := range positions[index+1:] {
+				results[skipped].Status = model.BulkStatusSkipped
+			}
+
+			break
+		}
+	}
+
+	return remaining
+}
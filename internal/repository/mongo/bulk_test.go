@@ -0,0 +1,52 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestDropFailedWritesUnordered(t *testing.T) {
+	results := make([]model.BulkResult, 4)
+	failures := []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 11000, Message: "duplicate"}},
+		{WriteError: mongo.WriteError{Index: 2, Code: 2, Message: "bad value"}},
+	}
+
+	remaining := dropFailedWrites(results, []int{0, 1, 3}, failures, false)
+
+	if want := []int{0}; !reflect.DeepEqual(remaining, want) {
+		t.Fatalf("remaining = %v, want %v", remaining, want)
+	}
+
+	if results[1].Status != model.BulkStatusConflict || results[1].Error != "duplicate" {
+		t.Errorf("duplicate write = %+v", results[1])
+	}
+
+	if results[3].Status != model.BulkStatusFailed || results[3].Error != "bad value" {
+		t.Errorf("failed write = %+v", results[3])
+	}
+}
+
+func TestDropFailedWritesOrdered(t *testing.T) {
+	results := make([]model.BulkResult, 3)
+	failures := []mongo.BulkWriteError{
+		{WriteError: mongo.WriteError{Index: 1, Code: 2, Message: "bad value"}},
+	}
+
+	remaining := dropFailedWrites(results, []int{0, 1, 2}, failures, true)
+
+	if want := []int{0}; !reflect.DeepEqual(remaining, want) {
+		t.Fatalf("remaining = %v, want %v", remaining, want)
+	}
+
+	if results[1].Status != model.BulkStatusFailed {
+		t.Errorf("failed write = %+v", results[1])
+	}
+
+	if results[2].Status != model.BulkStatusSkipped {
+		t.Errorf("write after the failure = %+v", results[2])
+	}
+}
@@ -0,0 +1,128 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type cartRepository struct {
+	collection *mongo.Collection
+}
+
+func NewCartRepository(storage *mongo.Database, collection string) repository.CartRepository {
+	return &cartRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (cartRepository *cartRepository) GetCart(ctx context.Context, customerID string) (*model.Cart, error) {
+	var cart *model.Cart
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	err := cartRepository.collection.FindOne(ctx, bson.M{"customer-id": customerID}).Decode(&cart)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return &model.Cart{CustomerID: customerID, Items: []model.CartItem{}}, nil
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return cart, nil
+}
+
+func (cartRepository *cartRepository) SetCartItem(ctx context.Context, customerID string, item *model.CartItem) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	for attempt := 0; attempt < 2; attempt++ {
+		filter := bson.M{"customer-id": customerID, "items.sku": item.SKU}
+
+		update := bson.M{
+			"$set": bson.M{"items.$.quantity": item.Quantity, "updated-at": now},
+		}
+
+		result, err := cartRepository.collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount > 0 {
+			return nil
+		}
+
+		item.AddedAt = now
+
+		filter = bson.M{"customer-id": customerID, "items.sku": bson.M{"$ne": item.SKU}}
+
+		update = bson.M{
+			"$push":        bson.M{"items": item},
+			"$set":         bson.M{"updated-at": now},
+			"$setOnInsert": bson.M{"created-at": now},
+		}
+
+		_, err = cartRepository.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true))
+		if mongo.IsDuplicateKeyError(err) {
+			continue
+		}
+
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		return nil
+	}
+
+	return errors.Wrap(errors.New("concurrent cart update"), utils.ErrorExecuteQuery.Error())
+}
+
+func (cartRepository *cartRepository) RemoveCartItem(ctx context.Context, customerID string, sku string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"customer-id": customerID, "items.sku": sku}
+
+	update := bson.M{
+		"$pull": bson.M{"items": bson.M{"sku": sku}},
+		"$set":  bson.M{"updated-at": time.Now().UTC()},
+	}
+
+	result, err := cartRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return utils.ErrorCartItemNotFound
+	}
+
+	return nil
+}
+
+func (cartRepository *cartRepository) ClearCart(ctx context.Context, customerID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	if _, err := cartRepository.collection.DeleteOne(ctx, bson.M{"customer-id": customerID}); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
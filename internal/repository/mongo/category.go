@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/Meystergod/online-store/internal/domain/model"
@@ -12,15 +13,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var categoryTreeFields = []string{"parent-id", "path", "subcategories"}
+
 type categoryRepository struct {
 	collection *mongo.Collection
+	outbox     *outbox
 }
 
 func NewCategoryRepository(storage *mongo.Database, collection string) repository.CategoryRepository {
 	return &categoryRepository{
 		collection: storage.Collection(collection),
+		outbox:     newOutbox(storage),
 	}
 }
 
@@ -36,7 +42,7 @@ func (categoryRepository *categoryRepository) GetCategory(ctx context.Context, u
 		return category, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	result := categoryRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -50,14 +56,14 @@ func (categoryRepository *categoryRepository) GetCategory(ctx context.Context, u
 	return category, nil
 }
 
-func (categoryRepository *categoryRepository) GetCategoryByTitle(ctx context.Context, title string) (*model.Category, error) {
+func (categoryRepository *categoryRepository) GetCategoryByTitle(ctx context.Context, title string, locale string) (*model.Category, error) {
 	var category *model.Category
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	filter := bson.M{"title": title}
+	filter := titleFilter(title, locale)
 
 	result := categoryRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -74,7 +80,7 @@ func (categoryRepository *categoryRepository) GetCategoryByTitle(ctx context.Con
 func (categoryRepository *categoryRepository) GetAllCategories(ctx context.Context) (*[]model.Category, error) {
 	var categories []model.Category
 
-	filter := bson.M{}
+	filter := bson.M{fieldDeletedAt: notDeleted}
 
 	cursor, err := categoryRepository.collection.Find(ctx, filter)
 	if err != nil {
@@ -93,17 +99,44 @@ func (categoryRepository *categoryRepository) CreateCategory(ctx context.Context
 
 	defer cancel()
 
-	result, err := categoryRepository.collection.InsertOne(ctx, category)
-	if err != nil {
-		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
-	}
+	var createdCategoryID string
+
+	err := retrySlugConflicts(func() error {
+		slug, err := newSlugger(categoryRepository.collection, utils.CollNameCategory).generate(ctx, category.Title, utils.EmptyString)
+		if err != nil {
+			return err
+		}
+
+		category.Slug = slug
+
+		return categoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			path, err := categoryRepository.childPath(ctx, category.ParentID)
+			if err != nil {
+				return nil, err
+			}
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+			category.Path = path
+
+			result, err := categoryRepository.collection.InsertOne(ctx, category)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			oid, ok := result.InsertedID.(primitive.ObjectID)
+			if !ok {
+				return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+			}
+
+			createdCategoryID = oid.Hex()
+
+			return []model.Event{newEvent(utils.CollNameCategory, model.EventActionCreated, createdCategoryID, category)}, nil
+		})
+	})
+	if err != nil {
+		return utils.EmptyString, err
 	}
 
-	return oid.Hex(), nil
+	return createdCategoryID, nil
 }
 
 func (categoryRepository *categoryRepository) UpdateCategory(ctx context.Context, category *model.Category) error {
@@ -116,57 +149,408 @@ func (categoryRepository *categoryRepository) UpdateCategory(ctx context.Context
 		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
-	categoryByte, err := bson.Marshal(category)
+	return retrySlugConflicts(func() error {
+		if err := categoryRepository.renameSlugs(ctx, category); err != nil {
+			return err
+		}
+
+		object, err := toDocument(category)
+		if err != nil {
+			return err
+		}
+
+		delete(object, "_id")
+		for _, field := range categoryTreeFields {
+			delete(object, field)
+		}
+
+		update := setOrUnset(object, "attributes", "low-stock-threshold", fieldSlugHistory)
+
+		return categoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			result, err := categoryRepository.collection.UpdateOne(ctx, filter, update)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			if result.MatchedCount == 0 {
+				return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+			}
+
+			return []model.Event{newEvent(utils.CollNameCategory, model.EventActionUpdated, category.ID, category)}, nil
+		})
+	})
+}
+
+func (categoryRepository *categoryRepository) DeleteCategory(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return softDelete(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, uuid)
+}
+
+func (categoryRepository *categoryRepository) RestoreCategory(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return restore(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, uuid, categoryRepository.requireActiveAncestors(uuid))
+}
+
+func (categoryRepository *categoryRepository) requireActiveAncestors(uuid string) trashHook {
+	return func(ctx mongo.SessionContext) error {
+		category, err := categoryRepository.findCategory(ctx, uuid)
+		if err != nil {
+			return err
+		}
+
+		ancestorIDs := category.AncestorIDs()
+		if len(ancestorIDs) == 0 {
+			return nil
+		}
+
+		oids := make([]primitive.ObjectID, 0, len(ancestorIDs))
+		for _, id := range ancestorIDs {
+			oid, err := primitive.ObjectIDFromHex(id)
+			if err != nil {
+				return errors.Wrap(err, utils.ErrorConvert.Error())
+			}
+
+			oids = append(oids, oid)
+		}
+
+		active, err := categoryRepository.collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$in": oids}, fieldDeletedAt: notDeleted})
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if int(active) != len(oids) {
+			return utils.ErrorCategoryParentDeleted
+		}
+
+		return nil
+	}
+}
+
+func (categoryRepository *categoryRepository) GetDeletedCategories(ctx context.Context) (*[]model.Category, error) {
+	var categories []model.Category
+
+	if err := findDeleted(ctx, categoryRepository.collection, &categories); err != nil {
+		return &categories, err
+	}
+
+	return &categories, nil
+}
+
+func (categoryRepository *categoryRepository) PurgeCategories(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, before)
+}
+
+func (categoryRepository *categoryRepository) BulkCreateCategories(ctx context.Context, categories []model.Category, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	paths := make(map[string]string)
+	slugger := newSlugger(categoryRepository.collection, utils.CollNameCategory)
+
+	documents := make([]interface{}, 0, len(categories))
+	for i := range categories {
+		path, ok := paths[categories[i].ParentID]
+		if !ok {
+			var err error
+
+			path, err = categoryRepository.childPath(ctx, categories[i].ParentID)
+			if err != nil {
+				return nil, err
+			}
+
+			paths[categories[i].ParentID] = path
+		}
+
+		slug, err := slugger.generate(ctx, categories[i].Title, utils.EmptyString)
+		if err != nil {
+			return nil, err
+		}
+
+		categories[i].Path = path
+		categories[i].Slug = slug
+		documents = append(documents, categories[i])
+	}
+
+	return bulkCreate(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, documents, ordered, nil)
+}
+
+func (categoryRepository *categoryRepository) BulkUpdateCategories(ctx context.Context, categories []model.Category, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	renamed := make([]*model.Category, 0, len(categories))
+	for i := range categories {
+		renamed = append(renamed, &categories[i])
+	}
+
+	if err := categoryRepository.renameSlugs(ctx, renamed...); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(categories))
+	documents := make([]interface{}, 0, len(categories))
+	for i := range categories {
+		object, err := toDocument(categories[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range categoryTreeFields {
+			delete(object, field)
+		}
+
+		ids = append(ids, categories[i].ID)
+		documents = append(documents, object)
+	}
+
+	return bulkUpdate(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, ids, documents, ordered, nil, "attributes", "low-stock-threshold", fieldSlugHistory)
+}
+
+func (categoryRepository *categoryRepository) BulkDeleteCategories(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	return bulkDelete(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, uuids, ordered, nil)
+}
+
+func (categoryRepository *categoryRepository) MoveCategory(ctx context.Context, uuid string, parentID string) (*model.Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorMarshal.Error())
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	var object bson.M
+	var moved *model.Category
+
+	err = categoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var category model.Category
+
+		err := categoryRepository.collection.FindOne(ctx, bson.M{"_id": oid, fieldDeletedAt: notDeleted}).Decode(&category)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		path := "/"
+		if parentID != utils.EmptyString {
+			parent, err := categoryRepository.findCategory(ctx, parentID)
+			if err != nil {
+				return nil, err
+			}
 
-	err = bson.Unmarshal(categoryByte, &object)
+			if parent.ID == uuid || parent.IsDescendantOf(uuid) {
+				return nil, utils.ErrorCategoryCycle
+			}
+
+			path = parent.ChildPath()
+		}
+
+		oldPrefix := category.ChildPath()
+
+		category.ParentID = parentID
+		category.Path = path
+
+		newPrefix := category.ChildPath()
+
+		update := bson.M{"$set": bson.M{"path": path}}
+		if parentID == utils.EmptyString {
+			update["$unset"] = bson.M{"parent-id": utils.EmptyString}
+		} else {
+			update["$set"].(bson.M)["parent-id"] = parentID
+		}
+
+		if _, err = categoryRepository.collection.UpdateOne(ctx, bson.M{"_id": oid}, update); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		descendants := bson.M{"path": bson.M{"$regex": "^" + regexp.QuoteMeta(oldPrefix)}}
+
+		rewrite := bson.A{
+			bson.M{"$set": bson.M{
+				"path": bson.M{"$concat": bson.A{
+					newPrefix,
+					bson.M{"$substrCP": bson.A{
+						"$path",
+						len(oldPrefix),
+						bson.M{"$subtract": bson.A{bson.M{"$strLenCP": "$path"}, len(oldPrefix)}},
+					}},
+				}},
+			}},
+		}
+
+		if _, err = categoryRepository.collection.UpdateMany(ctx, descendants, rewrite); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		moved = &category
+
+		return []model.Event{newEvent(utils.CollNameCategory, model.EventActionMoved, uuid, category)}, nil
+	})
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorUnmarshal.Error())
+		return nil, err
 	}
 
-	delete(object, "_id")
+	return moved, nil
+}
+
+func (categoryRepository *categoryRepository) GetCategoryDescendants(ctx context.Context, uuid string) (*[]model.Category, error) {
+	category, err := categoryRepository.GetCategory(ctx, uuid)
+	if err != nil {
+		return nil, err
+	}
 
-	update := bson.M{
-		"$set": object,
+	filter := bson.M{
+		"path":         bson.M{"$regex": "^" + regexp.QuoteMeta(category.ChildPath())},
+		fieldDeletedAt: notDeleted,
 	}
 
-	result, err := categoryRepository.collection.UpdateOne(ctx, filter, update)
+	return categoryRepository.findCategories(ctx, filter)
+}
+
+func (categoryRepository *categoryRepository) GetCategoryAncestors(ctx context.Context, uuid string) (*[]model.Category, error) {
+	category, err := categoryRepository.GetCategory(ctx, uuid)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return nil, err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	ancestorIDs := category.AncestorIDs()
+
+	oids := make([]primitive.ObjectID, 0, len(ancestorIDs))
+	for _, id := range ancestorIDs {
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+		}
+
+		oids = append(oids, oid)
 	}
 
-	return nil
+	found, err := categoryRepository.findCategories(ctx, bson.M{"_id": bson.M{"$in": oids}, fieldDeletedAt: notDeleted})
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]model.Category, len(*found))
+	for _, ancestor := range *found {
+		byID[ancestor.ID] = ancestor
+	}
+
+	ancestors := make([]model.Category, 0, len(ancestorIDs))
+	for _, id := range ancestorIDs {
+		if ancestor, ok := byID[id]; ok {
+			ancestors = append(ancestors, ancestor)
+		}
+	}
+
+	return &ancestors, nil
 }
 
-func (categoryRepository *categoryRepository) DeleteCategory(ctx context.Context, uuid string) error {
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+func (categoryRepository *categoryRepository) findCategories(ctx context.Context, filter bson.M) (*[]model.Category, error) {
+	var categories []model.Category
 
-	defer cancel()
+	cursor, err := categoryRepository.collection.Find(ctx, filter, options.Find().SetSort(bson.M{"path": 1}))
+	if err != nil {
+		return &categories, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &categories); err != nil {
+		return &categories, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &categories, nil
+}
+
+func (categoryRepository *categoryRepository) findCategory(ctx context.Context, uuid string) (*model.Category, error) {
+	var category *model.Category
 
 	oid, err := primitive.ObjectIDFromHex(uuid)
 	if err != nil {
-		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	err = categoryRepository.collection.FindOne(ctx, bson.M{"_id": oid, fieldDeletedAt: notDeleted}).Decode(&category)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return category, nil
+}
+
+func (categoryRepository *categoryRepository) childPath(ctx context.Context, parentID string) (string, error) {
+	if parentID == utils.EmptyString {
+		return "/", nil
+	}
+
+	parent, err := categoryRepository.findCategory(ctx, parentID)
+	if err != nil {
+		return utils.EmptyString, err
+	}
+
+	return parent.ChildPath(), nil
+}
+
+func (categoryRepository *categoryRepository) SetCategoryTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error {
+	return setTranslation(ctx, categoryRepository.outbox, categoryRepository.collection, utils.CollNameCategory, uuid, locale, translation)
+}
+
+func (categoryRepository *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*model.Category, error) {
+	var category *model.Category
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := slugFilter(slug)
+	filter[fieldDeletedAt] = notDeleted
+
+	result := categoryRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return category, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	if err := result.Decode(&category); err != nil {
+		return category, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return category, nil
+}
+
+func (categoryRepository *categoryRepository) renameSlugs(ctx context.Context, categories ...*model.Category) error {
+	slugger := newSlugger(categoryRepository.collection, utils.CollNameCategory)
 
-	result, err := categoryRepository.collection.DeleteOne(ctx, filter)
+	ids := make([]string, 0, len(categories))
+	for _, category := range categories {
+		ids = append(ids, category.ID)
+	}
+
+	states, err := slugger.states(ctx, ids)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return err
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	for _, category := range categories {
+		state, ok := states[category.ID]
+		if !ok {
+			continue
+		}
+
+		category.Slug, category.SlugHistory, err = slugger.rename(ctx, state, category.Title)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,401 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type couponRepository struct {
+	collection  *mongo.Collection
+	redemptions *mongo.Collection
+	outbox      *outbox
+}
+
+func NewCouponRepository(storage *mongo.Database, collection string) repository.CouponRepository {
+	return &couponRepository{
+		collection:  storage.Collection(collection),
+		redemptions: storage.Collection(utils.CollNameCouponRedemption),
+		outbox:      newOutbox(storage),
+	}
+}
+
+func (couponRepository *couponRepository) GetCoupon(ctx context.Context, uuid string) (*model.Coupon, error) {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return couponRepository.findCoupon(ctx, bson.M{"_id": oid})
+}
+
+func (couponRepository *couponRepository) GetCouponByCode(ctx context.Context, code string) (*model.Coupon, error) {
+	return couponRepository.findCoupon(ctx, bson.M{"code": code})
+}
+
+func (couponRepository *couponRepository) findCoupon(ctx context.Context, filter bson.M) (*model.Coupon, error) {
+	var coupon *model.Coupon
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result := couponRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return coupon, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&coupon); err != nil {
+		return coupon, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return coupon, nil
+}
+
+func (couponRepository *couponRepository) GetAllCoupons(ctx context.Context) (*[]model.Coupon, error) {
+	var coupons []model.Coupon
+
+	cursor, err := couponRepository.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return &coupons, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &coupons); err != nil {
+		return &coupons, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &coupons, nil
+}
+
+func (couponRepository *couponRepository) CreateCoupon(ctx context.Context, coupon *model.Coupon) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	coupon.Used = 0
+	coupon.CreatedAt = time.Now().UTC()
+
+	result, err := couponRepository.collection.InsertOne(ctx, coupon)
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func (couponRepository *couponRepository) UpdateCoupon(ctx context.Context, coupon *model.Coupon) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(coupon.ID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	object := bson.M{
+		"min-order-value":    coupon.MinOrderValue,
+		"usage-limit":        coupon.UsageLimit,
+		"per-customer-limit": coupon.PerCustomerLimit,
+		"is-active":          coupon.IsActive,
+	}
+
+	if coupon.DiscountID != utils.EmptyString {
+		object["discount-id"] = coupon.DiscountID
+	}
+
+	if coupon.Amount > 0 {
+		object["amount"] = coupon.Amount
+	}
+
+	if coupon.ExpiresAt != nil {
+		object["expires-at"] = coupon.ExpiresAt
+	}
+
+	update := setOrUnset(object, "discount-id", "amount", "expires-at")
+
+	result, err := couponRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (couponRepository *couponRepository) DeleteCoupon(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return couponRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var coupon model.Coupon
+
+		err := couponRepository.collection.FindOneAndDelete(ctx, bson.M{"_id": oid}).Decode(&coupon)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if _, err = couponRepository.redemptions.DeleteMany(ctx, bson.M{"code": coupon.Code}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		return nil, nil
+	})
+}
+
+func (couponRepository *couponRepository) CountCouponRedemptions(ctx context.Context, code string, customerID string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	count, err := couponRepository.redemptions.CountDocuments(ctx, bson.M{"code": code, "customer-id": customerID})
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return int(count), nil
+}
+
+func (couponRepository *couponRepository) RedeemCoupon(ctx context.Context, code string, customerID string, orderValue float64, now time.Time) (*model.Coupon, *model.CouponRedemption, error) {
+	var (
+		coupon     *model.Coupon
+		redemption *model.CouponRedemption
+	)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{
+		"code":            code,
+		"is-active":       true,
+		"min-order-value": bson.M{"$lte": orderValue},
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"expires-at": bson.M{"$exists": false}},
+				bson.M{"expires-at": bson.M{"$gt": now}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"usage-limit": 0},
+				bson.M{"$expr": bson.M{"$lt": bson.A{"$used", "$usage-limit"}}},
+			}},
+		},
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"used": 1},
+	}
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	err := couponRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		coupon = nil
+		redemption = nil
+
+		if err := couponRepository.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&coupon); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		slot, err := couponRepository.freeSlot(ctx, coupon, customerID)
+		if err != nil {
+			return nil, err
+		}
+
+		redemption = &model.CouponRedemption{
+			CouponID:   coupon.ID,
+			Code:       code,
+			CustomerID: customerID,
+			Slot:       slot,
+			OrderValue: orderValue,
+			RedeemedAt: now.UTC(),
+		}
+
+		result, err := couponRepository.redemptions.InsertOne(ctx, redemption)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, utils.ErrorCouponCustomerLimit
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		}
+
+		redemption.ID = oid.Hex()
+
+		return []model.Event{newEvent(utils.CollNameCoupon, model.EventActionRedeemed, coupon.ID, redemption)}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return coupon, redemption, nil
+}
+
+func (couponRepository *couponRepository) freeSlot(ctx context.Context, coupon *model.Coupon, customerID string) (*int, error) {
+	if coupon.PerCustomerLimit == 0 {
+		return nil, nil
+	}
+
+	opts := options.Find().SetProjection(bson.M{"slot": 1})
+
+	cursor, err := couponRepository.redemptions.Find(ctx, bson.M{"code": coupon.Code, "customer-id": customerID}, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	var redemptions []model.CouponRedemption
+
+	if err = cursor.All(ctx, &redemptions); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	taken := make(map[int]bool, len(redemptions))
+	for _, redemption := range redemptions {
+		if redemption.Slot != nil {
+			taken[*redemption.Slot] = true
+		}
+	}
+
+	for slot := 0; slot < coupon.PerCustomerLimit && len(redemptions) < coupon.PerCustomerLimit; slot++ {
+		if !taken[slot] {
+			return &slot, nil
+		}
+	}
+
+	return nil, utils.ErrorCouponCustomerLimit
+}
+
+func (couponRepository *couponRepository) ReleaseCoupon(ctx context.Context, redemptionID string) (*model.CouponRedemption, error) {
+	var redemption *model.CouponRedemption
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(redemptionID)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	err = couponRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		redemption = nil
+
+		err := couponRepository.redemptions.FindOneAndDelete(ctx, bson.M{"_id": oid}).Decode(&redemption)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, utils.ErrorCouponRedemptionNotFound
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		filter := bson.M{"code": redemption.Code, "used": bson.M{"$gt": 0}}
+
+		if _, err = couponRepository.collection.UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"used": -1}}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		return []model.Event{newEvent(utils.CollNameCoupon, model.EventActionReleased, redemption.CouponID, redemption)}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redemption, nil
+}
+
+func MigrateCouponUsages(ctx context.Context, storage *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+
+	defer cancel()
+
+	coupons := storage.Collection(utils.CollNameCoupon)
+	redemptions := storage.Collection(utils.CollNameCouponRedemption)
+
+	opts := options.Find().SetProjection(bson.M{"code": 1, "per-customer-limit": 1, "usages": 1, "created-at": 1})
+
+	cursor, err := coupons.Find(ctx, bson.M{"usages": bson.M{"$exists": true}}, opts)
+	if err != nil {
+		return errors.Wrap(err, "find coupons with usages")
+	}
+
+	var legacy []struct {
+		ID               primitive.ObjectID `bson:"_id"`
+		Code             string             `bson:"code"`
+		PerCustomerLimit int                `bson:"per-customer-limit"`
+		Usages           map[string]int     `bson:"usages"`
+		CreatedAt        time.Time          `bson:"created-at"`
+	}
+
+	if err = cursor.All(ctx, &legacy); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	for _, coupon := range legacy {
+		documents := make([]interface{}, 0, len(coupon.Usages))
+
+		for customerID, used := range coupon.Usages {
+			for i := 0; i < used; i++ {
+				redemption := model.CouponRedemption{
+					CouponID:   coupon.ID.Hex(),
+					Code:       coupon.Code,
+					CustomerID: customerID,
+					RedeemedAt: coupon.CreatedAt,
+				}
+
+				if i < coupon.PerCustomerLimit {
+					slot := i
+					redemption.Slot = &slot
+				}
+
+				documents = append(documents, redemption)
+			}
+		}
+
+		if len(documents) > 0 {
+			_, err = redemptions.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+			if err != nil && !mongo.IsDuplicateKeyError(err) {
+				return errors.Wrapf(err, "migrate coupon %s usages", coupon.Code)
+			}
+		}
+
+		if _, err = coupons.UpdateOne(ctx, bson.M{"_id": coupon.ID}, bson.M{"$unset": bson.M{"usages": utils.EmptyString}}); err != nil {
+			return errors.Wrapf(err, "unset coupon %s usages", coupon.Code)
+		}
+	}
+
+	return nil
+}
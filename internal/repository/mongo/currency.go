@@ -0,0 +1,110 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type exchangeRateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewExchangeRateRepository(storage *mongo.Database, collection string) repository.ExchangeRateRepository {
+	return &exchangeRateRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (exchangeRateRepository *exchangeRateRepository) GetExchangeRate(ctx context.Context, currency string) (*model.ExchangeRate, error) {
+	var rate *model.ExchangeRate
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result := exchangeRateRepository.collection.FindOne(ctx, bson.M{"_id": currency})
+	if result.Err() != nil {
+		return rate, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&rate); err != nil {
+		return rate, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return rate, nil
+}
+
+func (exchangeRateRepository *exchangeRateRepository) GetExchangeRates(ctx context.Context) (*[]model.ExchangeRate, error) {
+	var rates []model.ExchangeRate
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+
+	cursor, err := exchangeRateRepository.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return &rates, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &rates); err != nil {
+		return &rates, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &rates, nil
+}
+
+func (exchangeRateRepository *exchangeRateRepository) SaveExchangeRates(ctx context.Context, rates []model.ExchangeRate) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+	writes := make([]mongo.WriteModel, 0, len(rates))
+
+	for i := range rates {
+		rates[i].UpdatedAt = now
+
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"_id": rates[i].Currency}).
+			SetReplacement(rates[i]).
+			SetUpsert(true))
+	}
+
+	if len(writes) == 0 {
+		return nil
+	}
+
+	if _, err := exchangeRateRepository.collection.BulkWrite(ctx, writes); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (exchangeRateRepository *exchangeRateRepository) DeleteExchangeRate(ctx context.Context, currency string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result, err := exchangeRateRepository.collection.DeleteOne(ctx, bson.M{"_id": currency})
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
@@ -12,15 +12,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var discountScheduleFields = []string{"starts-at", "ends-at", "activated-at"}
+
 type discountRepository struct {
 	collection *mongo.Collection
+	outbox     *outbox
 }
 
 func NewDiscountRepository(storage *mongo.Database, collection string) repository.DiscountRepository {
 	return &discountRepository{
 		collection: storage.Collection(collection),
+		outbox:     newOutbox(storage),
 	}
 }
 
@@ -36,7 +41,7 @@ func (discountRepository *discountRepository) GetDiscount(ctx context.Context, u
 		return discount, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	result := discountRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -57,7 +62,7 @@ func (discountRepository *discountRepository) GetDiscountByTitle(ctx context.Con
 
 	defer cancel()
 
-	filter := bson.M{"title": title}
+	filter := bson.M{"title": title, fieldDeletedAt: notDeleted}
 
 	result := discountRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -74,7 +79,7 @@ func (discountRepository *discountRepository) GetDiscountByTitle(ctx context.Con
 func (discountRepository *discountRepository) GetAllDiscounts(ctx context.Context) (*[]model.Discount, error) {
 	var discounts []model.Discount
 
-	filter := bson.M{}
+	filter := bson.M{fieldDeletedAt: notDeleted}
 
 	cursor, err := discountRepository.collection.Find(ctx, filter)
 	if err != nil {
@@ -93,17 +98,30 @@ func (discountRepository *discountRepository) CreateDiscount(ctx context.Context
 
 	defer cancel()
 
-	result, err := discountRepository.collection.InsertOne(ctx, discount)
-	if err != nil {
-		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
-	}
+	scheduleDiscount(discount, time.Now().UTC())
+
+	var createdDiscountID string
+
+	err := discountRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := discountRepository.collection.InsertOne(ctx, discount)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		}
+
+		createdDiscountID = oid.Hex()
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		return []model.Event{newEvent(utils.CollNameDiscount, model.EventActionCreated, createdDiscountID, discount)}, nil
+	})
+	if err != nil {
+		return utils.EmptyString, err
 	}
 
-	return oid.Hex(), nil
+	return createdDiscountID, nil
 }
 
 func (discountRepository *discountRepository) UpdateDiscount(ctx context.Context, discount *model.Discount) error {
@@ -116,7 +134,9 @@ func (discountRepository *discountRepository) UpdateDiscount(ctx context.Context
 		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	scheduleDiscount(discount, time.Now().UTC())
 
 	discountByte, err := bson.Marshal(discount)
 	if err != nil {
@@ -132,42 +152,251 @@ func (discountRepository *discountRepository) UpdateDiscount(ctx context.Context
 
 	delete(object, "_id")
 
+	update := setOrUnset(object, discountScheduleFields...)
+
+	return discountRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var before model.Discount
+
+		err := discountRepository.collection.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		events := []model.Event{newEvent(utils.CollNameDiscount, model.EventActionUpdated, discount.ID, discount)}
+
+		switch {
+		case !before.IsActive && discount.IsActive:
+			events = append(events, newEvent(utils.CollNameDiscount, model.EventActionActivated, discount.ID, discount))
+		case before.IsActive && !discount.IsActive:
+			events = append(events, newEvent(utils.CollNameDiscount, model.EventActionDeactivated, discount.ID, discount))
+		}
+
+		return events, nil
+	})
+}
+
+func (discountRepository *discountRepository) DeleteDiscount(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return softDelete(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, uuid)
+}
+
+func (discountRepository *discountRepository) RestoreDiscount(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return restore(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, uuid)
+}
+
+func (discountRepository *discountRepository) GetDeletedDiscounts(ctx context.Context) (*[]model.Discount, error) {
+	var discounts []model.Discount
+
+	if err := findDeleted(ctx, discountRepository.collection, &discounts); err != nil {
+		return &discounts, err
+	}
+
+	return &discounts, nil
+}
+
+func (discountRepository *discountRepository) PurgeDiscounts(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, before)
+}
+
+func (discountRepository *discountRepository) BulkCreateDiscounts(ctx context.Context, discounts []model.Discount, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	documents := make([]interface{}, 0, len(discounts))
+	for i := range discounts {
+		scheduleDiscount(&discounts[i], now)
+		documents = append(documents, discounts[i])
+	}
+
+	return bulkCreate(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, documents, ordered, nil)
+}
+
+func (discountRepository *discountRepository) BulkUpdateDiscounts(ctx context.Context, discounts []model.Discount, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	ids := make([]string, 0, len(discounts))
+	documents := make([]interface{}, 0, len(discounts))
+	for i := range discounts {
+		scheduleDiscount(&discounts[i], now)
+		ids = append(ids, discounts[i].ID)
+		documents = append(documents, discounts[i])
+	}
+
+	return bulkUpdate(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, ids, documents, ordered, nil, discountScheduleFields...)
+}
+
+func (discountRepository *discountRepository) BulkDeleteDiscounts(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	return bulkDelete(ctx, discountRepository.outbox, discountRepository.collection, utils.CollNameDiscount, uuids, ordered, nil)
+}
+
+func (discountRepository *discountRepository) ActivateScheduledDiscounts(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		fieldDeletedAt: notDeleted,
+		"is-active":    false,
+		"activated-at": bson.M{"$exists": false},
+		"starts-at":    bson.M{"$lte": now},
+		"$or": bson.A{
+			bson.M{"ends-at": bson.M{"$exists": false}},
+			bson.M{"ends-at": bson.M{"$gt": now}},
+		},
+	}
+
 	update := bson.M{
-		"$set": object,
+		"$set": bson.M{"is-active": true, "activated-at": now},
 	}
 
-	result, err := discountRepository.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	return discountRepository.transitDiscounts(ctx, filter, update, model.EventActionActivated)
+}
+
+func (discountRepository *discountRepository) ExpireDiscounts(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		fieldDeletedAt: notDeleted,
+		"is-active":    true,
+		"ends-at":      bson.M{"$lte": now},
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	update := bson.M{
+		"$set": bson.M{"is-active": false},
 	}
 
-	return nil
+	return discountRepository.transitDiscounts(ctx, filter, update, model.EventActionDeactivated)
 }
 
-func (discountRepository *discountRepository) DeleteDiscount(ctx context.Context, uuid string) error {
+func (discountRepository *discountRepository) GetNextDiscountTransition(ctx context.Context, now time.Time) (*time.Time, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	oid, err := primitive.ObjectIDFromHex(uuid)
-	if err != nil {
-		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	starts := bson.M{
+		fieldDeletedAt: notDeleted,
+		"activated-at": bson.M{"$exists": false},
+		"starts-at":    bson.M{"$gt": now},
 	}
 
-	filter := bson.M{"_id": oid}
+	ends := bson.M{
+		fieldDeletedAt: notDeleted,
+		"is-active":    true,
+		"ends-at":      bson.M{"$gt": now},
+	}
 
-	result, err := discountRepository.collection.DeleteOne(ctx, filter)
-	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	var next *time.Time
+
+	for field, filter := range map[string]bson.M{"starts-at": starts, "ends-at": ends} {
+		var discount model.Discount
+
+		opts := options.FindOne().SetSort(bson.M{field: 1})
+
+		err := discountRepository.collection.FindOne(ctx, filter, opts).Decode(&discount)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		at := discount.StartsAt
+		if field == "ends-at" {
+			at = discount.EndsAt
+		}
+
+		if next == nil || at.Before(*next) {
+			next = at
+		}
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	return next, nil
+}
+
+func (discountRepository *discountRepository) transitDiscounts(ctx context.Context, filter bson.M, update bson.M, action string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	var transited int64
+
+	err := discountRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		transited = 0
+
+		cursor, err := discountRepository.collection.Find(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		var discounts []model.Discount
+
+		if err = cursor.All(ctx, &discounts); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		if len(discounts) == 0 {
+			return nil, nil
+		}
+
+		oids := make([]primitive.ObjectID, 0, len(discounts))
+		events := make([]model.Event, 0, len(discounts))
+
+		for i := range discounts {
+			oid, err := primitive.ObjectIDFromHex(discounts[i].ID)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+			}
+
+			oids = append(oids, oid)
+			events = append(events, newEvent(utils.CollNameDiscount, action, discounts[i].ID, discounts[i]))
+		}
+
+		transitFilter := bson.M{"$and": bson.A{filter, bson.M{"_id": bson.M{"$in": oids}}}}
+
+		result, err := discountRepository.collection.UpdateMany(ctx, transitFilter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		transited = result.ModifiedCount
+
+		return events, nil
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return nil
+	return transited, nil
+}
+
+func scheduleDiscount(discount *model.Discount, now time.Time) {
+	switch {
+	case discount.StartsAt != nil && now.Before(*discount.StartsAt):
+		discount.IsActive = false
+		discount.ActivatedAt = nil
+	case discount.EndsAt != nil && !now.Before(*discount.EndsAt):
+		discount.IsActive = false
+		discount.ActivatedAt = nil
+	case discount.IsActive:
+		discount.ActivatedAt = &now
+	default:
+		discount.ActivatedAt = nil
+	}
 }
@@ -0,0 +1,177 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func EnsureIndexes(ctx context.Context, storage *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	indexes := map[string][]mongo.IndexModel{
+		utils.CollNameAudit: {
+			{Keys: bson.D{{Key: "entity-type", Value: 1}, {Key: "entity-id", Value: 1}, {Key: "timestamp", Value: -1}}},
+			{Keys: bson.D{{Key: "actor", Value: 1}, {Key: "timestamp", Value: -1}}},
+		},
+		utils.CollNameCategory: {
+			{Keys: bson.D{{Key: "path", Value: 1}}},
+			{Keys: bson.D{{Key: "parent-id", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "slug", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"slug": bson.M{"$exists": true}}),
+			},
+			{Keys: bson.D{{Key: "slug-history", Value: 1}}},
+			{Keys: bson.D{{Key: "translations.$**", Value: 1}}},
+		},
+		utils.CollNameSubcategory: {
+			{Keys: bson.D{{Key: "category-id", Value: 1}}},
+			{Keys: bson.D{{Key: "translations.$**", Value: 1}}},
+		},
+		utils.CollNameTag: {
+			{Keys: bson.D{{Key: "title", Value: 1}}},
+			{Keys: bson.D{{Key: "translations.$**", Value: 1}}},
+		},
+		utils.CollNameProduct: {
+			{Keys: bson.D{{Key: "category._id", Value: 1}}},
+			{Keys: bson.D{{Key: "tags._id", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "slug", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"slug": bson.M{"$exists": true}}),
+			},
+			{Keys: bson.D{{Key: "slug-history", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "sku", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"sku": bson.M{"$exists": true}}),
+			},
+			{
+				Keys: bson.D{{Key: "variants.sku", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"variants.sku": bson.M{"$exists": true}}),
+			},
+			{Keys: bson.D{{Key: "attributes.$**", Value: 1}}},
+			{Keys: bson.D{{Key: "translations.$**", Value: 1}}},
+			{Keys: bson.D{{Key: "rating.average", Value: -1}, {Key: "rating.count", Value: -1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "publish-at", Value: 1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "unpublish-at", Value: 1}}},
+		},
+		utils.CollNameDiscount: {
+			{Keys: bson.D{{Key: "starts-at", Value: 1}}},
+			{Keys: bson.D{{Key: "ends-at", Value: 1}}},
+		},
+		utils.CollNameCart: {
+			{Keys: bson.D{{Key: "customer-id", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		utils.CollNameOrder: {
+			{Keys: bson.D{{Key: "customer-id", Value: 1}, {Key: "created-at", Value: -1}}},
+			{Keys: bson.D{{Key: "created-at", Value: 1}}},
+		},
+		utils.CollNameCoupon: {
+			{Keys: bson.D{{Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		utils.CollNameCouponRedemption: {
+			{
+				Keys: bson.D{{Key: "code", Value: 1}, {Key: "customer-id", Value: 1}, {Key: "slot", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"slot": bson.M{"$exists": true}}),
+			},
+			{Keys: bson.D{{Key: "code", Value: 1}, {Key: "customer-id", Value: 1}}},
+		},
+		utils.CollNamePricingRule: {
+			{Keys: bson.D{{Key: "target-type", Value: 1}, {Key: "target-id", Value: 1}}},
+		},
+		utils.CollNameStockMovement: {
+			{Keys: bson.D{{Key: "product-id", Value: 1}, {Key: "created-at", Value: -1}}},
+			{Keys: bson.D{{Key: "order-id", Value: 1}}},
+		},
+		utils.CollNameStockAlert: {
+			{
+				Keys: bson.D{{Key: "product-id", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"status": model.StockAlertOpen}),
+			},
+		},
+		utils.CollNameWarehouse: {
+			{Keys: bson.D{{Key: "code", Value: 1}}, Options: options.Index().SetUnique(true)},
+		},
+		utils.CollNameWarehouseStock: {
+			{Keys: bson.D{{Key: "product-id", Value: 1}, {Key: "warehouse-id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "warehouse-id", Value: 1}}},
+		},
+		utils.CollNameReview: {
+			{Keys: bson.D{{Key: "product-id", Value: 1}, {Key: "status", Value: 1}, {Key: "created-at", Value: -1}}},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created-at", Value: -1}}},
+		},
+		utils.CollNameWishlist: {
+			{Keys: bson.D{{Key: "customer-id", Value: 1}, {Key: "created-at", Value: 1}}},
+			{
+				Keys: bson.D{{Key: "share-token", Value: 1}},
+				Options: options.Index().
+					SetUnique(true).
+					SetPartialFilterExpression(bson.M{"share-token": bson.M{"$exists": true}}),
+			},
+		},
+		utils.CollNameCoPurchase: {
+			{Keys: bson.D{{Key: "updated-at", Value: 1}}},
+		},
+		utils.CollNameOutbox: {
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next-attempt-at", Value: 1}}},
+		},
+		utils.CollNameWebhookDelivery: {
+			{Keys: bson.D{{Key: "webhook-id", Value: 1}, {Key: "event._id", Value: 1}}, Options: options.Index().SetUnique(true)},
+			{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next-attempt-at", Value: 1}}},
+		},
+	}
+
+	if err := dropIndexes(ctx, storage, map[string][]string{
+		utils.CollNameOutbox:        {"dedup-key_1"},
+		utils.CollNameStockMovement: {"type_1_created-at_1"},
+	}); err != nil {
+		return err
+	}
+
+	for collection, models := range indexes {
+		if _, err := storage.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return errors.Wrapf(err, "create indexes on %s", collection)
+		}
+	}
+
+	return nil
+}
+
+func dropIndexes(ctx context.Context, storage *mongo.Database, indexes map[string][]string) error {
+	for collection, names := range indexes {
+		for _, name := range names {
+			_, err := storage.Collection(collection).Indexes().DropOne(ctx, name)
+
+			var serverError mongo.ServerError
+			if errors.As(err, &serverError) && (serverError.HasErrorCode(27) || serverError.HasErrorCode(26)) {
+				continue
+			}
+
+			if err != nil {
+				return errors.Wrapf(err, "drop index %s on %s", name, collection)
+			}
+		}
+	}
+
+	return nil
+}
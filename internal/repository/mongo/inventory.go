@@ -0,0 +1,391 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var stockProjection = bson.M{"quantity": 1, "reserved": 1}
+
+type inventoryRepository struct {
+	collection      *mongo.Collection
+	products        *mongo.Collection
+	warehouseStocks *mongo.Collection
+	outbox          *outbox
+}
+
+func NewInventoryRepository(storage *mongo.Database, collection string) repository.InventoryRepository {
+	return newInventoryRepository(storage, collection)
+}
+
+func newInventoryRepository(storage *mongo.Database, collection string) *inventoryRepository {
+	return &inventoryRepository{
+		collection:      storage.Collection(collection),
+		products:        storage.Collection(utils.CollNameProduct),
+		warehouseStocks: storage.Collection(utils.CollNameWarehouseStock),
+		outbox:          newOutbox(storage),
+	}
+}
+
+func (inventoryRepository *inventoryRepository) PostStockMovement(ctx context.Context, movement *model.StockMovement) (*model.StockLevel, error) {
+	return inventoryRepository.PostStockMovements(ctx, []*model.StockMovement{movement})
+}
+
+func (inventoryRepository *inventoryRepository) PostStockMovements(ctx context.Context, movements []*model.StockMovement) (*model.StockLevel, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	var level *model.StockLevel
+
+	err := inventoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		events := make([]model.Event, 0, len(movements))
+
+		for _, movement := range movements {
+			applied, err := inventoryRepository.applyMovement(ctx, movement)
+			if err != nil {
+				return nil, err
+			}
+
+			level = applied
+
+			data := bson.M{"movement": movement, "level": applied}
+			events = append(events, newEvent(utils.CollNameProduct, model.EventActionStockChanged, movement.ProductID, data))
+		}
+
+		return events, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return level, nil
+}
+
+func (inventoryRepository *inventoryRepository) applyMovement(ctx mongo.SessionContext, movement *model.StockMovement) (*model.StockLevel, error) {
+	oid, err := primitive.ObjectIDFromHex(movement.ProductID)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	onHand, reserved := movement.Deltas()
+
+	if movement.Type == model.StockMovementTransfer {
+		if err = inventoryRepository.updateWarehouseStock(ctx, movement.ProductID, movement.WarehouseID, -movement.Quantity, 0); err != nil {
+			return nil, err
+		}
+
+		if err = inventoryRepository.updateWarehouseStock(ctx, movement.ProductID, movement.TargetWarehouseID, movement.Quantity, 0); err != nil {
+			return nil, err
+		}
+	} else if err = inventoryRepository.updateWarehouseStock(ctx, movement.ProductID, movement.WarehouseID, onHand, reserved); err != nil {
+		return nil, err
+	}
+
+	filter, update := productStockUpdate(oid, movement.SKU, onHand, reserved)
+
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After).SetProjection(stockProjection)
+
+	var product model.Product
+
+	result := inventoryRepository.products.FindOneAndUpdate(ctx, filter, update, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		exists := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+		if movement.SKU != utils.EmptyString {
+			exists["variants.sku"] = movement.SKU
+		}
+
+		count, err := inventoryRepository.products.CountDocuments(ctx, exists)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if count == 0 {
+			if movement.SKU != utils.EmptyString {
+				return nil, utils.ErrorVariantNotFound
+			}
+
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		return nil, utils.ErrorInsufficientStock
+	}
+
+	if result.Err() != nil {
+		return nil, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&product); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	movement.ID = utils.EmptyString
+	movement.CreatedAt = time.Now().UTC()
+
+	inserted, err := inventoryRepository.collection.InsertOne(ctx, movement)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	movementOID, ok := inserted.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	movement.ID = movementOID.Hex()
+
+	level := product.StockLevel()
+	level.ProductID = movement.ProductID
+
+	return &level, nil
+}
+
+func (inventoryRepository *inventoryRepository) updateWarehouseStock(ctx mongo.SessionContext, productID string, warehouseID string, onHand int, reserved int) error {
+	filter := bson.M{"product-id": productID, "warehouse-id": warehouseID}
+
+	guard := stockGuard(onHand, reserved)
+	for key, value := range guard {
+		filter[key] = value
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"quantity": onHand, "reserved": reserved},
+	}
+
+	opts := options.Update().SetUpsert(len(guard) == 0)
+
+	result, err := inventoryRepository.warehouseStocks.UpdateOne(ctx, filter, update, opts)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 && result.UpsertedCount == 0 {
+		return utils.ErrorInsufficientStock
+	}
+
+	return nil
+}
+
+func productStockUpdate(oid primitive.ObjectID, sku string, onHand int, reserved int) (bson.M, bson.M) {
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+	for key, value := range stockGuard(onHand, reserved) {
+		filter[key] = value
+	}
+
+	increments := bson.M{"quantity": onHand, "reserved": reserved}
+
+	if sku != utils.EmptyString {
+		filter["variants.sku"] = sku
+		increments["variants.$.quantity"] = onHand
+		increments["variants.$.reserved"] = reserved
+
+		if guard := variantGuard(sku, onHand, reserved); guard != nil {
+			if productGuard, ok := filter["$expr"]; ok {
+				guard = bson.M{"$and": bson.A{productGuard, guard}}
+			}
+
+			filter["$expr"] = guard
+		}
+	}
+
+	return filter, bson.M{"$inc": increments}
+}
+
+func variantGuard(sku string, onHand int, reserved int) bson.M {
+	variantReserved := bson.M{"$ifNull": bson.A{"$$variant.reserved", 0}}
+	conditions := bson.A{bson.M{"$eq": bson.A{"$$variant.sku", sku}}}
+
+	if onHand < 0 || reserved > 0 {
+		available := bson.M{"$subtract": bson.A{"$$variant.quantity", variantReserved}}
+		conditions = append(conditions, bson.M{"$gte": bson.A{available, reserved - onHand}})
+	}
+
+	if reserved < 0 {
+		conditions = append(conditions, bson.M{"$gte": bson.A{variantReserved, -reserved}})
+	}
+
+	if len(conditions) == 1 {
+		return nil
+	}
+
+	return bson.M{"$anyElementTrue": bson.A{bson.M{"$map": bson.M{
+		"input": bson.M{"$ifNull": bson.A{"$variants", bson.A{}}},
+		"as":    "variant",
+		"in":    bson.M{"$and": conditions},
+	}}}}
+}
+
+func stockGuard(onHand int, reserved int) bson.M {
+	guard := bson.M{}
+
+	if onHand < 0 || reserved > 0 {
+		available := bson.M{"$subtract": bson.A{"$quantity", bson.M{"$ifNull": bson.A{"$reserved", 0}}}}
+		guard["$expr"] = bson.M{"$gte": bson.A{available, reserved - onHand}}
+	}
+
+	if reserved < 0 {
+		guard["reserved"] = bson.M{"$gte": -reserved}
+	}
+
+	return guard
+}
+
+func (inventoryRepository *inventoryRepository) GetWarehouseStocks(ctx context.Context, productIDs []string) ([]model.WarehouseStock, error) {
+	var stocks []model.WarehouseStock
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"product-id": bson.M{"$in": productIDs}}
+	opts := options.Find().SetSort(bson.D{{Key: "product-id", Value: 1}, {Key: "warehouse-id", Value: 1}})
+
+	cursor, err := inventoryRepository.warehouseStocks.Find(ctx, filter, opts)
+	if err != nil {
+		return stocks, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &stocks); err != nil {
+		return stocks, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return stocks, nil
+}
+
+func (inventoryRepository *inventoryRepository) HasWarehouseStock(ctx context.Context, warehouseID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{
+		"warehouse-id": warehouseID,
+		"$or":          bson.A{bson.M{"quantity": bson.M{"$ne": 0}}, bson.M{"reserved": bson.M{"$ne": 0}}},
+	}
+
+	count, err := inventoryRepository.warehouseStocks.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return count > 0, nil
+}
+
+func (inventoryRepository *inventoryRepository) HasOrderSale(ctx context.Context, productID string, orderID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"product-id": productID, "order-id": orderID, "type": model.StockMovementSale}
+
+	count, err := inventoryRepository.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return false, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return count > 0, nil
+}
+
+func (inventoryRepository *inventoryRepository) GetStockLevel(ctx context.Context, productID string) (*model.StockLevel, error) {
+	var product model.Product
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+	opts := options.FindOne().SetProjection(stockProjection)
+
+	result := inventoryRepository.products.FindOne(ctx, filter, opts)
+	if result.Err() != nil {
+		return nil, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&product); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	level := product.StockLevel()
+
+	return &level, nil
+}
+
+func (inventoryRepository *inventoryRepository) GetStockMovements(ctx context.Context, productID string, limit int64) (*[]model.StockMovement, error) {
+	var movements []model.StockMovement
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"product-id": productID}
+	opts := options.Find().SetSort(bson.M{"created-at": -1}).SetLimit(limit)
+
+	cursor, err := inventoryRepository.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return &movements, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &movements); err != nil {
+		return &movements, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &movements, nil
+}
+
+func (inventoryRepository *inventoryRepository) GetLowStockProducts(ctx context.Context) (*[]model.LowStockItem, error) {
+	var items []model.LowStockItem
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{fieldDeletedAt: notDeleted}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from": utils.CollNameCategory,
+			"let":  bson.M{"categoryID": "$category._id"},
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$eq": bson.A{bson.M{"$toString": "$_id"}, "$$categoryID"}}}},
+				bson.M{"$project": bson.M{"low-stock-threshold": 1}},
+			},
+			"as": "category-defaults",
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"threshold": bson.M{"$ifNull": bson.A{
+				"$low-stock-threshold",
+				bson.M{"$arrayElemAt": bson.A{"$category-defaults.low-stock-threshold", 0}},
+			}},
+		}}},
+		{{Key: "$match", Value: bson.M{
+			"threshold": bson.M{"$ne": nil},
+			"$expr":     bson.M{"$lte": bson.A{"$quantity", "$threshold"}},
+		}}},
+		{{Key: "$project", Value: bson.M{"title": 1, "quantity": 1, "reserved": 1, "threshold": 1}}},
+		{{Key: "$sort", Value: bson.D{{Key: "quantity", Value: 1}, {Key: "title", Value: 1}}}},
+	}
+
+	cursor, err := inventoryRepository.products.Aggregate(ctx, pipeline)
+	if err != nil {
+		return &items, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &items); err != nil {
+		return &items, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &items, nil
+}
@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestProductStockUpdateReservesSingleVariant(t *testing.T) {
+	oid := primitive.NewObjectID()
+
+	filter, update := productStockUpdate(oid, "shirt-s", 0, 2)
+
+	if filter["variants.sku"] != "shirt-s" {
+		t.Fatalf("filter does not select the variant: %v", filter)
+	}
+
+	increments := update["$inc"].(bson.M)
+	want := bson.M{"quantity": 0, "reserved": 2, "variants.$.quantity": 0, "variants.$.reserved": 2}
+	if !reflect.DeepEqual(increments, want) {
+		t.Fatalf("increments = %v, want %v", increments, want)
+	}
+
+	guard, ok := filter["$expr"].(bson.M)["$and"].(bson.A)
+	if !ok || len(guard) != 2 {
+		t.Fatalf("expected the product and the variant guard together, got %v", filter["$expr"])
+	}
+
+	if !reflect.DeepEqual(guard[1], variantGuard("shirt-s", 0, 2)) {
+		t.Fatalf("variant guard = %v", guard[1])
+	}
+}
+
+func TestVariantGuard(t *testing.T) {
+	if guard := variantGuard("shirt-s", 5, 0); guard != nil {
+		t.Fatalf("a receipt must not be guarded, got %v", guard)
+	}
+
+	conditions := func(guard bson.M) bson.A {
+		mapped := guard["$anyElementTrue"].(bson.A)[0].(bson.M)["$map"].(bson.M)
+		return mapped["in"].(bson.M)["$and"].(bson.A)
+	}
+
+	reserve := conditions(variantGuard("shirt-s", 0, 2))
+	available := bson.M{"$subtract": bson.A{"$$variant.quantity", bson.M{"$ifNull": bson.A{"$$variant.reserved", 0}}}}
+
+	if !reflect.DeepEqual(reserve[1], bson.M{"$gte": bson.A{available, 2}}) {
+		t.Fatalf("reservation must keep quantity - reserved >= 2, got %v", reserve[1])
+	}
+
+	sale := conditions(variantGuard("shirt-s", -3, -3))
+	if len(sale) != 3 {
+		t.Fatalf("an order sale must guard availability and reserved units, got %v", sale)
+	}
+
+	if !reflect.DeepEqual(sale[2], bson.M{"$gte": bson.A{bson.M{"$ifNull": bson.A{"$$variant.reserved", 0}}, 3}}) {
+		t.Fatalf("release must keep reserved >= 3, got %v", sale[2])
+	}
+}
@@ -0,0 +1,60 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const fieldTranslations = "translations"
+
+func titleFilter(title string, locale string) bson.M {
+	field := "title"
+	if locale != utils.EmptyString {
+		field = fieldTranslations + "." + locale + ".title"
+	}
+
+	return bson.M{field: title, fieldDeletedAt: notDeleted}
+}
+
+func setTranslation(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, uuid string, locale string, translation *model.Translation) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	field := fieldTranslations + "." + locale
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	update := bson.M{"$set": bson.M{field: translation}}
+	if translation == nil {
+		filter[field] = bson.M{"$exists": true}
+		update = bson.M{"$unset": bson.M{field: utils.EmptyString}}
+	}
+
+	return outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		data := bson.M{"locale": locale, "translation": translation}
+
+		return []model.Event{newEvent(entityType, model.EventActionUpdated, uuid, data)}, nil
+	})
+}
@@ -0,0 +1,204 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const openingStockReason = "opening balance"
+
+type stockTotals struct {
+	ProductID string `bson:"_id"`
+	Quantity  int    `bson:"quantity"`
+	Reserved  int    `bson:"reserved"`
+}
+
+func MigrateOpeningStock(ctx context.Context, storage *mongo.Database, warehouseCode string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+
+	defer cancel()
+
+	products := storage.Collection(utils.CollNameProduct)
+	stocks := storage.Collection(utils.CollNameWarehouseStock)
+	movements := storage.Collection(utils.CollNameStockMovement)
+
+	filter := bson.M{"$or": bson.A{bson.M{"quantity": bson.M{"$gt": 0}}, bson.M{"reserved": bson.M{"$gt": 0}}}}
+	opts := options.Find().SetProjection(stockProjection)
+
+	cursor, err := products.Find(ctx, filter, opts)
+	if err != nil {
+		return errors.Wrap(err, "find stocked products")
+	}
+
+	var stocked []struct {
+		ID       primitive.ObjectID `bson:"_id"`
+		Quantity int                `bson:"quantity"`
+		Reserved int                `bson:"reserved"`
+	}
+
+	if err = cursor.All(ctx, &stocked); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	if len(stocked) == 0 {
+		return nil
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":      "$product-id",
+			"quantity": bson.M{"$sum": "$quantity"},
+			"reserved": bson.M{"$sum": "$reserved"},
+		}}},
+	}
+
+	cursor, err = stocks.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(err, "sum warehouse stock")
+	}
+
+	var sums []stockTotals
+
+	if err = cursor.All(ctx, &sums); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	counters := make([]stockTotals, 0, len(stocked))
+	for _, product := range stocked {
+		counters = append(counters, stockTotals{ProductID: product.ID.Hex(), Quantity: product.Quantity, Reserved: product.Reserved})
+	}
+
+	openings := openingBalances(counters, sums)
+	if len(openings) == 0 {
+		return nil
+	}
+
+	warehouseID, err := defaultWarehouse(ctx, storage.Collection(utils.CollNameWarehouse), warehouseCode)
+	if err != nil {
+		return err
+	}
+
+	outbox := newOutbox(storage)
+	now := time.Now().UTC()
+
+	for _, opening := range openings {
+		opening := opening
+
+		err = outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			stockFilter := bson.M{"product-id": opening.ProductID, "warehouse-id": warehouseID}
+			update := bson.M{"$inc": bson.M{"quantity": opening.Quantity, "reserved": opening.Reserved}}
+
+			if _, err := stocks.UpdateOne(ctx, stockFilter, update, options.Update().SetUpsert(true)); err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			if _, err := movements.InsertMany(ctx, openingMovements(opening, warehouseID, now)); err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			return nil, nil
+		})
+		if err != nil {
+			return errors.Wrapf(err, "open stock of product %s", opening.ProductID)
+		}
+	}
+
+	return nil
+}
+
+func openingBalances(counters []stockTotals, rows []stockTotals) []stockTotals {
+	backed := make(map[string]stockTotals, len(rows))
+	for _, row := range rows {
+		backed[row.ProductID] = row
+	}
+
+	openings := make([]stockTotals, 0, len(counters))
+	for _, counter := range counters {
+		opening := stockTotals{
+			ProductID: counter.ProductID,
+			Quantity:  positive(counter.Quantity - backed[counter.ProductID].Quantity),
+			Reserved:  positive(counter.Reserved - backed[counter.ProductID].Reserved),
+		}
+
+		if opening.Quantity > 0 || opening.Reserved > 0 {
+			openings = append(openings, opening)
+		}
+	}
+
+	return openings
+}
+
+func openingMovements(opening stockTotals, warehouseID string, at time.Time) []interface{} {
+	movements := make([]interface{}, 0, 2)
+
+	if opening.Quantity > 0 {
+		movements = append(movements, model.StockMovement{
+			ProductID:   opening.ProductID,
+			Type:        model.StockMovementOpening,
+			Quantity:    opening.Quantity,
+			Reason:      openingStockReason,
+			WarehouseID: warehouseID,
+			CreatedAt:   at,
+		})
+	}
+
+	if opening.Reserved > 0 {
+		movements = append(movements, model.StockMovement{
+			ProductID:   opening.ProductID,
+			Type:        model.StockMovementReservation,
+			Quantity:    opening.Reserved,
+			Reason:      openingStockReason,
+			WarehouseID: warehouseID,
+			CreatedAt:   at,
+		})
+	}
+
+	return movements
+}
+
+func defaultWarehouse(ctx context.Context, warehouses *mongo.Collection, code string) (string, error) {
+	var warehouse model.Warehouse
+
+	err := warehouses.FindOne(ctx, bson.M{"code": code}).Decode(&warehouse)
+	if err == nil {
+		return warehouse.ID, nil
+	}
+
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return utils.EmptyString, errors.Wrap(err, "find default warehouse")
+	}
+
+	result, err := warehouses.InsertOne(ctx, model.Warehouse{
+		Code:      code,
+		Title:     "Default warehouse",
+		IsActive:  true,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, "create default warehouse")
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func positive(value int) int {
+	if value < 0 {
+		return 0
+	}
+
+	return value
+}
@@ -0,0 +1,56 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+)
+
+func TestOpeningBalances(t *testing.T) {
+	counters := []stockTotals{
+		{ProductID: "legacy", Quantity: 10, Reserved: 2},
+		{ProductID: "partial", Quantity: 10},
+		{ProductID: "migrated", Quantity: 5, Reserved: 1},
+		{ProductID: "drifted", Quantity: 1},
+	}
+
+	rows := []stockTotals{
+		{ProductID: "partial", Quantity: 4},
+		{ProductID: "migrated", Quantity: 5, Reserved: 1},
+		{ProductID: "drifted", Quantity: 3},
+	}
+
+	want := []stockTotals{
+		{ProductID: "legacy", Quantity: 10, Reserved: 2},
+		{ProductID: "partial", Quantity: 6},
+	}
+
+	if got := openingBalances(counters, rows); !reflect.DeepEqual(got, want) {
+		t.Fatalf("openingBalances = %+v, want %+v", got, want)
+	}
+}
+
+func TestOpeningMovements(t *testing.T) {
+	at := time.Now().UTC()
+
+	movements := openingMovements(stockTotals{ProductID: "legacy", Quantity: 10, Reserved: 2}, "warehouse-1", at)
+	if len(movements) != 2 {
+		t.Fatalf("expected an opening and a reservation entry, got %d", len(movements))
+	}
+
+	opening := movements[0].(model.StockMovement)
+	if opening.Type != model.StockMovementOpening || opening.Quantity != 10 || opening.WarehouseID != "warehouse-1" {
+		t.Errorf("opening entry = %+v", opening)
+	}
+
+	reservation := movements[1].(model.StockMovement)
+	if reservation.Type != model.StockMovementReservation || reservation.Quantity != 2 {
+		t.Errorf("reservation entry = %+v", reservation)
+	}
+
+	if movements = openingMovements(stockTotals{ProductID: "legacy", Quantity: 3}, "warehouse-1", at); len(movements) != 1 {
+		t.Errorf("expected only an opening entry without reserved units, got %d", len(movements))
+	}
+}
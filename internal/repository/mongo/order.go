@@ -0,0 +1,133 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type orderRepository struct {
+	collection *mongo.Collection
+	carts      *mongo.Collection
+	inventory  *inventoryRepository
+	outbox     *outbox
+}
+
+func NewOrderRepository(storage *mongo.Database, collection string) repository.OrderRepository {
+	return &orderRepository{
+		collection: storage.Collection(collection),
+		carts:      storage.Collection(utils.CollNameCart),
+		inventory:  newInventoryRepository(storage, utils.CollNameStockMovement),
+		outbox:     newOutbox(storage),
+	}
+}
+
+func (orderRepository *orderRepository) CreateOrder(ctx context.Context, order *model.Order, cart *model.Cart, movements []*model.StockMovement) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	var createdOrderID string
+
+	err := orderRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := orderRepository.carts.DeleteOne(ctx, bson.M{"customer-id": cart.CustomerID, "updated-at": cart.UpdatedAt})
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.DeletedCount == 0 {
+			return nil, utils.ErrorCartChanged
+		}
+
+		oid := primitive.NewObjectID()
+		createdOrderID = oid.Hex()
+		events := make([]model.Event, 0, len(movements)+1)
+
+		for _, movement := range movements {
+			movement.OrderID = createdOrderID
+
+			level, err := orderRepository.inventory.applyMovement(ctx, movement)
+			if err != nil {
+				return nil, err
+			}
+
+			data := bson.M{"movement": movement, "level": level}
+			events = append(events, newEvent(utils.CollNameProduct, model.EventActionStockChanged, movement.ProductID, data))
+		}
+
+		order.ID = createdOrderID
+		order.CreatedAt = time.Now().UTC()
+
+		document, err := toDocument(order)
+		if err != nil {
+			return nil, err
+		}
+
+		document["_id"] = oid
+
+		if _, err = orderRepository.collection.InsertOne(ctx, document); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		return append(events, newEvent(utils.CollNameOrder, model.EventActionCreated, createdOrderID, order)), nil
+	})
+	if err != nil {
+		return utils.EmptyString, err
+	}
+
+	return createdOrderID, nil
+}
+
+func (orderRepository *orderRepository) GetOrder(ctx context.Context, uuid string) (*model.Order, error) {
+	var order *model.Order
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return nil, utils.ErrorOrderNotFound
+	}
+
+	err = orderRepository.collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&order)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, utils.ErrorOrderNotFound
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return order, nil
+}
+
+func (orderRepository *orderRepository) GetOrdersByCustomer(ctx context.Context, customerID string) (*[]model.Order, error) {
+	var orders []model.Order
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created-at", Value: -1}})
+
+	cursor, err := orderRepository.collection.Find(ctx, bson.M{"customer-id": customerID}, opts)
+	if err != nil {
+		return &orders, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &orders); err != nil {
+		return &orders, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &orders, nil
+}
@@ -0,0 +1,225 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type outbox struct {
+	client     *mongo.Client
+	collection *mongo.Collection
+}
+
+func newOutbox(storage *mongo.Database) *outbox {
+	return &outbox{
+		client:     storage.Client(),
+		collection: storage.Collection(utils.CollNameOutbox),
+	}
+}
+
+func newEvent(entityType string, action string, entityID string, data interface{}) model.Event {
+	return model.Event{
+		Type:       model.EventType(entityType, action),
+		EntityType: entityType,
+		EntityID:   entityID,
+		Data:       data,
+		OccurredAt: time.Now().UTC(),
+	}
+}
+
+func (outbox *outbox) transact(ctx context.Context, fn func(ctx mongo.SessionContext) ([]model.Event, error)) error {
+	session, err := outbox.client.StartSession()
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(ctx mongo.SessionContext) (interface{}, error) {
+		events, err := fn(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, outbox.add(ctx, events)
+	})
+
+	return err
+}
+
+func (outbox *outbox) add(ctx context.Context, events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	documents := make([]interface{}, 0, len(events))
+
+	for _, event := range events {
+		event.ID = primitive.NewObjectID().Hex()
+
+		documents = append(documents, model.OutboxMessage{
+			Event:         event,
+			Status:        model.OutboxMessagePending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	_, err := outbox.collection.InsertMany(ctx, documents)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(storage *mongo.Database, collection string) repository.OutboxRepository {
+	return &outboxRepository{
+		collection: storage.Collection(collection, options.Collection().SetRegistry(documentRegistry())),
+	}
+}
+
+func (outboxRepository *outboxRepository) ClaimOutboxMessage(ctx context.Context, now time.Time, lease time.Duration) (*model.OutboxMessage, error) {
+	var message *model.OutboxMessage
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{
+		"status":          model.OutboxMessagePending,
+		"next-attempt-at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set": bson.M{"next-attempt-at": now.Add(lease)},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next-attempt-at", Value: 1}, {Key: "created-at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	result := outboxRepository.collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if result.Err() != nil {
+		return message, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&message); err != nil {
+		return message, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return message, nil
+}
+
+func (outboxRepository *outboxRepository) MarkOutboxMessagePublished(ctx context.Context, uuid string, attempts int) error {
+	publishedAt := time.Now().UTC()
+
+	return outboxRepository.updateMessage(ctx, uuid, bson.M{
+		"$set": bson.M{
+			"status":       model.OutboxMessagePublished,
+			"attempts":     attempts,
+			"published-at": publishedAt,
+		},
+		"$unset": bson.M{"last-error": utils.EmptyString},
+	})
+}
+
+func (outboxRepository *outboxRepository) RetryOutboxMessageAt(ctx context.Context, uuid string, attempts int, next time.Time, lastError string) error {
+	return outboxRepository.updateMessage(ctx, uuid, bson.M{
+		"$set": bson.M{
+			"attempts":        attempts,
+			"next-attempt-at": next,
+			"last-error":      lastError,
+		},
+	})
+}
+
+func (outboxRepository *outboxRepository) GetOutboxStats(ctx context.Context) (*model.OutboxStats, error) {
+	stats := &model.OutboxStats{}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{"status": model.OutboxMessagePending}
+
+	pending, err := outboxRepository.collection.CountDocuments(ctx, filter)
+	if err != nil {
+		return stats, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	stats.Pending = pending
+
+	if pending == 0 {
+		return stats, nil
+	}
+
+	var oldest model.OutboxMessage
+
+	opts := options.FindOne().SetSort(bson.M{"created-at": 1})
+
+	if err = outboxRepository.collection.FindOne(ctx, filter, opts).Decode(&oldest); err != nil {
+		return stats, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	stats.OldestPending = &oldest.CreatedAt
+
+	return stats, nil
+}
+
+func (outboxRepository *outboxRepository) PurgePublishedOutboxMessages(ctx context.Context, before time.Time) (int64, error) {
+	filter := bson.M{
+		"status":       model.OutboxMessagePublished,
+		"published-at": bson.M{"$lte": before},
+	}
+
+	result, err := outboxRepository.collection.DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return result.DeletedCount, nil
+}
+
+func (outboxRepository *outboxRepository) updateMessage(ctx context.Context, uuid string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	result, err := outboxRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
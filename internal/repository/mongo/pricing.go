@@ -0,0 +1,165 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type pricingRuleRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPricingRuleRepository(storage *mongo.Database, collection string) repository.PricingRuleRepository {
+	return &pricingRuleRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (pricingRuleRepository *pricingRuleRepository) GetPricingRule(ctx context.Context, uuid string) (*model.PricingRule, error) {
+	var rule *model.PricingRule
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return rule, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result := pricingRuleRepository.collection.FindOne(ctx, bson.M{"_id": oid})
+	if result.Err() != nil {
+		return rule, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&rule); err != nil {
+		return rule, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return rule, nil
+}
+
+func (pricingRuleRepository *pricingRuleRepository) GetAllPricingRules(ctx context.Context) (*[]model.PricingRule, error) {
+	return pricingRuleRepository.findPricingRules(ctx, bson.M{})
+}
+
+func (pricingRuleRepository *pricingRuleRepository) GetPricingRulesForTargets(ctx context.Context, targets []model.PricingTarget) ([]model.PricingRule, error) {
+	if len(targets) == 0 {
+		return nil, nil
+	}
+
+	ids := make(map[string][]string)
+	for _, target := range targets {
+		ids[target.Type] = append(ids[target.Type], target.ID)
+	}
+
+	conditions := make(bson.A, 0, len(ids))
+	for targetType, targetIDs := range ids {
+		conditions = append(conditions, bson.M{"target-type": targetType, "target-id": bson.M{"$in": targetIDs}})
+	}
+
+	rules, err := pricingRuleRepository.findPricingRules(ctx, bson.M{"is-active": true, "$or": conditions})
+	if err != nil {
+		return nil, err
+	}
+
+	return *rules, nil
+}
+
+func (pricingRuleRepository *pricingRuleRepository) findPricingRules(ctx context.Context, filter bson.M) (*[]model.PricingRule, error) {
+	var rules []model.PricingRule
+
+	cursor, err := pricingRuleRepository.collection.Find(ctx, filter)
+	if err != nil {
+		return &rules, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &rules); err != nil {
+		return &rules, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &rules, nil
+}
+
+func (pricingRuleRepository *pricingRuleRepository) CreatePricingRule(ctx context.Context, rule *model.PricingRule) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	rule.CreatedAt = time.Now().UTC()
+
+	result, err := pricingRuleRepository.collection.InsertOne(ctx, rule)
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func (pricingRuleRepository *pricingRuleRepository) UpdatePricingRule(ctx context.Context, rule *model.PricingRule) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(rule.ID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	object, err := toDocument(rule)
+	if err != nil {
+		return err
+	}
+
+	delete(object, "_id")
+	delete(object, "created-at")
+
+	update := setOrUnset(object, "percent", "amount", "starts-at", "ends-at")
+
+	result, err := pricingRuleRepository.collection.UpdateOne(ctx, bson.M{"_id": oid}, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (pricingRuleRepository *pricingRuleRepository) DeletePricingRule(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result, err := pricingRuleRepository.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
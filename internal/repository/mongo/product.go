@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/Meystergod/online-store/internal/domain/model"
@@ -12,15 +13,26 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+const fieldStatus = "status"
+
+var productManagedFields = []string{"quantity", "reserved", "rating", fieldStatus, "publish-at", "unpublish-at", "published-at"}
+
 type productRepository struct {
 	collection *mongo.Collection
+	reviews    *mongo.Collection
+	tags       *mongo.Collection
+	outbox     *outbox
 }
 
 func NewProductRepository(storage *mongo.Database, collection string) repository.ProductRepository {
 	return &productRepository{
 		collection: storage.Collection(collection),
+		reviews:    storage.Collection(utils.CollNameReview),
+		tags:       storage.Collection(utils.CollNameTag),
+		outbox:     newOutbox(storage),
 	}
 }
 
@@ -36,7 +48,7 @@ func (productRepository *productRepository) GetProduct(ctx context.Context, uuid
 		return product, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	result := productRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -47,17 +59,19 @@ func (productRepository *productRepository) GetProduct(ctx context.Context, uuid
 		return product, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
+	applyDiscountWindow(product, time.Now())
+
 	return product, nil
 }
 
-func (productRepository *productRepository) GetProductByTitle(ctx context.Context, title string) (*model.Product, error) {
+func (productRepository *productRepository) GetProductByTitle(ctx context.Context, title string, locale string) (*model.Product, error) {
 	var product *model.Product
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	filter := bson.M{"title": title}
+	filter := titleFilter(title, locale)
 
 	result := productRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -68,15 +82,18 @@ func (productRepository *productRepository) GetProductByTitle(ctx context.Contex
 		return product, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
+	applyDiscountWindow(product, time.Now())
+
 	return product, nil
 }
 
-func (productRepository *productRepository) GetAllProducts(ctx context.Context) (*[]model.Product, error) {
+func (productRepository *productRepository) GetAllProducts(ctx context.Context, productFilter model.ProductFilter) (*[]model.Product, error) {
 	var products []model.Product
 
-	filter := bson.M{}
+	filter := productQuery(productFilter)
+	opts := options.Find().SetSort(productSort(productFilter))
 
-	cursor, err := productRepository.collection.Find(ctx, filter)
+	cursor, err := productRepository.collection.Find(ctx, filter, opts)
 	if err != nil {
 		return &products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
@@ -85,6 +102,11 @@ func (productRepository *productRepository) GetAllProducts(ctx context.Context)
 		return &products, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
+	now := time.Now()
+	for i := range products {
+		applyDiscountWindow(&products[i], now)
+	}
+
 	return &products, nil
 }
 
@@ -93,17 +115,52 @@ func (productRepository *productRepository) CreateProduct(ctx context.Context, p
 
 	defer cancel()
 
-	result, err := productRepository.collection.InsertOne(ctx, product)
-	if err != nil {
-		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	var createdProductID string
+
+	schedulePublication(product, time.Now().UTC())
+
+	err := retrySlugConflicts(func() error {
+		slug, err := newSlugger(productRepository.collection, utils.CollNameProduct).generate(ctx, product.Title, utils.EmptyString)
+		if err != nil {
+			return err
+		}
+
+		product.Slug = slug
+
+		return productRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			result, err := productRepository.collection.InsertOne(ctx, product)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			oid, ok := result.InsertedID.(primitive.ObjectID)
+			if !ok {
+				return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+			}
+
+			createdProductID = oid.Hex()
+
+			if product.IsPublished() {
+				usage := tagUsage{}
+				usage.add(product.Tags, 1)
+
+				if err = usage.apply(ctx, productRepository.tags); err != nil {
+					return nil, err
+				}
+			}
+
+			return []model.Event{newEvent(utils.CollNameProduct, model.EventActionCreated, createdProductID, product)}, nil
+		})
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.EmptyString, utils.ErrorSKUExists
 	}
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	if err != nil {
+		return utils.EmptyString, err
 	}
 
-	return oid.Hex(), nil
+	return createdProductID, nil
 }
 
 func (productRepository *productRepository) UpdateProduct(ctx context.Context, product *model.Product) error {
@@ -116,57 +173,797 @@ func (productRepository *productRepository) UpdateProduct(ctx context.Context, p
 		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	err = retrySlugConflicts(func() error {
+		if err := productRepository.renameSlugs(ctx, product); err != nil {
+			return err
+		}
+
+		object, err := toDocument(product)
+		if err != nil {
+			return err
+		}
+
+		delete(object, "_id")
+		for _, field := range productManagedFields {
+			delete(object, field)
+		}
+
+		update := setOrUnset(object, "sku", "attributes", "low-stock-threshold", "prices", fieldSlugHistory)
+
+		return productRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+			before, err := productTags(ctx, productRepository.collection, countedProducts(filter))
+			if err != nil {
+				return nil, err
+			}
+
+			result, err := productRepository.collection.UpdateOne(ctx, filter, update)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+			}
+
+			if result.MatchedCount == 0 {
+				return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+			}
+
+			after, err := productTags(ctx, productRepository.collection, countedProducts(filter))
+			if err != nil {
+				return nil, err
+			}
+
+			usage := tagUsage{}
+			usage.diff([]string{product.ID}, before, after)
+
+			if err = usage.apply(ctx, productRepository.tags); err != nil {
+				return nil, err
+			}
+
+			return []model.Event{newEvent(utils.CollNameProduct, model.EventActionUpdated, product.ID, product)}, nil
+		})
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return utils.ErrorSKUExists
+	}
+
+	return err
+}
+
+func (productRepository *productRepository) DeleteProduct(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return softDelete(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuid, productRepository.countTags(uuid, -1))
+}
+
+func (productRepository *productRepository) RestoreProduct(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return restore(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuid, productRepository.countTags(uuid, 1))
+}
+
+func (productRepository *productRepository) countTags(uuid string, delta int64) trashHook {
+	return func(ctx mongo.SessionContext) error {
+		oid, err := primitive.ObjectIDFromHex(uuid)
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorConvert.Error())
+		}
+
+		tags, err := productTags(ctx, productRepository.collection, bson.M{"_id": oid, fieldStatus: statusCondition(model.ProductStatusPublished)})
+		if err != nil {
+			return err
+		}
+
+		usage := tagUsage{}
+		usage.add(tags[uuid], delta)
+
+		return usage.apply(ctx, productRepository.tags)
+	}
+}
+
+func (productRepository *productRepository) trackTagUsage(ids []string, applied string) bulkHook {
+	return func(ctx mongo.SessionContext, write func() ([]model.BulkResult, error)) ([]model.BulkResult, error) {
+		before, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(ids)))
+		if err != nil {
+			return nil, err
+		}
+
+		results, err := write()
+		if err != nil {
+			return nil, err
+		}
+
+		seen := make(map[string]bool, len(results))
+		changed := make([]string, 0, len(results))
+
+		for i := range results {
+			if results[i].Status == applied && !seen[results[i].ID] {
+				seen[results[i].ID] = true
+				changed = append(changed, results[i].ID)
+			}
+		}
+
+		if len(changed) == 0 {
+			return results, nil
+		}
+
+		after, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(changed)))
+		if err != nil {
+			return nil, err
+		}
+
+		usage := tagUsage{}
+		usage.diff(changed, before, after)
+
+		if err = usage.apply(ctx, productRepository.tags); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}
+}
+
+func (productRepository *productRepository) countCreatedTags(products []model.Product) bulkHook {
+	return func(ctx mongo.SessionContext, write func() ([]model.BulkResult, error)) ([]model.BulkResult, error) {
+		results, err := write()
+		if err != nil {
+			return nil, err
+		}
+
+		usage := tagUsage{}
+		for i := range results {
+			if results[i].Status == model.BulkStatusCreated && products[i].IsPublished() {
+				usage.add(products[i].Tags, 1)
+			}
+		}
+
+		if err = usage.apply(ctx, productRepository.tags); err != nil {
+			return nil, err
+		}
+
+		return results, nil
+	}
+}
+
+func (productRepository *productRepository) GetDeletedProducts(ctx context.Context) (*[]model.Product, error) {
+	var products []model.Product
+
+	if err := findDeleted(ctx, productRepository.collection, &products); err != nil {
+		return &products, err
+	}
+
+	return &products, nil
+}
+
+func (productRepository *productRepository) PurgeProducts(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, before, productRepository.purgeReviews)
+}
+
+func (productRepository *productRepository) purgeReviews(ctx mongo.SessionContext, ids []string) error {
+	if _, err := productRepository.reviews.DeleteMany(ctx, bson.M{"product-id": bson.M{"$in": ids}}); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (productRepository *productRepository) GetPurgeableProductImages(ctx context.Context, before time.Time) (*[]model.Product, error) {
+	var products []model.Product
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{fieldDeletedAt: bson.M{"$lte": before}, "images.0": bson.M{"$exists": true}}
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "images": 1})
+
+	cursor, err := productRepository.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return &products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &products); err != nil {
+		return &products, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &products, nil
+}
+
+func (productRepository *productRepository) BulkCreateProducts(ctx context.Context, products []model.Product, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	slugger := newSlugger(productRepository.collection, utils.CollNameProduct)
+	now := time.Now().UTC()
+
+	documents := make([]interface{}, 0, len(products))
+	for i := range products {
+		schedulePublication(&products[i], now)
+
+		slug, err := slugger.generate(ctx, products[i].Title, utils.EmptyString)
+		if err != nil {
+			return nil, err
+		}
+
+		products[i].Slug = slug
+		documents = append(documents, products[i])
+	}
+
+	return bulkCreate(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, documents, ordered, productRepository.countCreatedTags(products))
+}
+
+func (productRepository *productRepository) BulkUpdateProducts(ctx context.Context, products []model.Product, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	renamed := make([]*model.Product, 0, len(products))
+	for i := range products {
+		renamed = append(renamed, &products[i])
+	}
+
+	if err := productRepository.renameSlugs(ctx, renamed...); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(products))
+	documents := make([]interface{}, 0, len(products))
+	for i := range products {
+		object, err := toDocument(products[i])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, field := range productManagedFields {
+			delete(object, field)
+		}
+
+		ids = append(ids, products[i].ID)
+		documents = append(documents, object)
+	}
+
+	return bulkUpdate(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, ids, documents, ordered, productRepository.trackTagUsage(ids, model.BulkStatusUpdated), "sku", "attributes", "low-stock-threshold", "prices", fieldSlugHistory)
+}
+
+func (productRepository *productRepository) BulkDeleteProducts(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	return bulkDelete(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuids, ordered, productRepository.trackTagUsage(uuids, model.BulkStatusDeleted))
+}
+
+func (productRepository *productRepository) BulkAddProductTags(ctx context.Context, uuids []string, tags []model.Tag, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	current := bson.M{"$ifNull": bson.A{"$tags", bson.A{}}}
+	added := bson.M{"$filter": bson.M{
+		"input": bson.M{"$literal": tags},
+		"cond":  bson.M{"$not": bson.A{bson.M{"$in": bson.A{"$$this._id", bson.M{"$ifNull": bson.A{"$tags._id", bson.A{}}}}}}},
+	}}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"tags": bson.M{"$concatArrays": bson.A{current, added}}}}},
+	}
+
+	return bulkPatch(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuids, update, bson.M{"tags-added": tags}, ordered, productRepository.trackTagUsage(uuids, model.BulkStatusUpdated))
+}
+
+func (productRepository *productRepository) BulkRemoveProductTags(ctx context.Context, uuids []string, tagIDs []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	update := bson.M{"$pull": bson.M{"tags": bson.M{"_id": bson.M{"$in": tagIDs}}}}
+
+	return bulkPatch(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuids, update, bson.M{"tags-removed": tagIDs}, ordered, productRepository.trackTagUsage(uuids, model.BulkStatusUpdated))
+}
+
+func (productRepository *productRepository) GetProductsByCategories(ctx context.Context, categoryIDs []string) (*[]model.Product, error) {
+	var products []model.Product
 
-	productByte, err := bson.Marshal(product)
+	filter := bson.M{"category._id": bson.M{"$in": categoryIDs}, fieldDeletedAt: notDeleted, fieldStatus: statusCondition(model.ProductStatusPublished)}
+
+	cursor, err := productRepository.collection.Find(ctx, filter)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorMarshal.Error())
+		return &products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
 
-	var object bson.M
+	if err = cursor.All(ctx, &products); err != nil {
+		return &products, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	now := time.Now()
+	for i := range products {
+		applyDiscountWindow(&products[i], now)
+	}
+
+	return &products, nil
+}
+
+func (productRepository *productRepository) GetCategoryProductAttributes(ctx context.Context, categoryID string) (*[]model.Product, error) {
+	var products []model.Product
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 
-	err = bson.Unmarshal(productByte, &object)
+	defer cancel()
+
+	filter := bson.M{"category._id": categoryID, fieldDeletedAt: notDeleted}
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "attributes": 1})
+
+	cursor, err := productRepository.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorUnmarshal.Error())
+		return &products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &products); err != nil {
+		return &products, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
-	delete(object, "_id")
+	return &products, nil
+}
 
-	update := bson.M{
-		"$set": object,
+func (productRepository *productRepository) GetProductsByIDs(ctx context.Context, uuids []string) (*[]model.Product, error) {
+	var products []model.Product
+
+	oids := make([]primitive.ObjectID, 0, len(uuids))
+	for _, uuid := range uuids {
+		oid, err := primitive.ObjectIDFromHex(uuid)
+		if err != nil {
+			continue
+		}
+
+		oids = append(oids, oid)
 	}
 
-	result, err := productRepository.collection.UpdateOne(ctx, filter, update)
+	filter := bson.M{"_id": bson.M{"$in": oids}, fieldDeletedAt: notDeleted}
+
+	cursor, err := productRepository.collection.Find(ctx, filter)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return &products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	if err = cursor.All(ctx, &products); err != nil {
+		return &products, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
-	return nil
+	now := time.Now()
+	for i := range products {
+		applyDiscountWindow(&products[i], now)
+	}
+
+	return &products, nil
 }
 
-func (productRepository *productRepository) DeleteProduct(ctx context.Context, uuid string) error {
+func (productRepository *productRepository) GetProductFacets(ctx context.Context, productFilter model.ProductFilter, boundaries []float64) (*model.ProductFacets, error) {
+	facets := &model.ProductFacets{}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: productQuery(productFilter)}},
+		{{Key: "$facet", Value: bson.M{
+			"total": bson.A{bson.M{"$count": "count"}},
+			"products": bson.A{
+				bson.M{"$sort": productSort(productFilter)},
+				bson.M{"$skip": productFilter.Offset},
+				bson.M{"$limit": productFilter.Limit},
+			},
+			"categories":    facetBuckets("category"),
+			"subcategories": facetBuckets("subcategory"),
+			"tags":          append(bson.A{bson.M{"$unwind": "$tags"}}, facetBuckets("tags")...),
+			"discounts":     facetBuckets("discount"),
+			"prices": bson.A{
+				bson.M{"$match": bson.M{"$expr": bson.M{"$gte": bson.A{priceExpression, boundaries[0]}}}},
+				bson.M{"$bucket": bson.M{
+					"groupBy":    priceExpression,
+					"boundaries": boundaries,
+					"default":    priceBucketOverflow,
+					"output":     bson.M{"count": bson.M{"$sum": 1}},
+				}},
+			},
+		}}},
+	}
+
+	cursor, err := productRepository.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return facets, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	var results []struct {
+		Total []struct {
+			Count int64 `bson:"count"`
+		} `bson:"total"`
+		Products      []model.Product     `bson:"products"`
+		Categories    []model.FacetBucket `bson:"categories"`
+		Subcategories []model.FacetBucket `bson:"subcategories"`
+		Tags          []model.FacetBucket `bson:"tags"`
+		Discounts     []model.FacetBucket `bson:"discounts"`
+		Prices        []priceBucketCount  `bson:"prices"`
+	}
+
+	if err = cursor.All(ctx, &results); err != nil {
+		return facets, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	if len(results) == 0 {
+		return facets, nil
+	}
+
+	result := results[0]
+
+	if len(result.Total) > 0 {
+		facets.Total = result.Total[0].Count
+	}
+
+	now := time.Now()
+	for i := range result.Products {
+		applyDiscountWindow(&result.Products[i], now)
+	}
+
+	facets.Offset = productFilter.Offset
+	facets.Limit = productFilter.Limit
+	facets.Products = result.Products
+	facets.Categories = result.Categories
+	facets.Subcategories = result.Subcategories
+	facets.Tags = result.Tags
+	facets.Discounts = result.Discounts
+	facets.Prices = priceBuckets(boundaries, result.Prices)
+
+	return facets, nil
+}
+
+const priceBucketOverflow = "overflow"
+
+type priceBucketCount struct {
+	ID    interface{} `bson:"_id"`
+	Count int64       `bson:"count"`
+}
+
+var priceExpression = bson.M{"$convert": bson.M{"input": "$price", "to": "double", "onError": nil, "onNull": nil}}
+
+func productQuery(productFilter model.ProductFilter) bson.M {
+	filter := bson.M{fieldDeletedAt: notDeleted}
+
+	if productFilter.CategoryID != utils.EmptyString {
+		filter["category._id"] = productFilter.CategoryID
+	}
+
+	if productFilter.SubcategoryID != utils.EmptyString {
+		filter["subcategory._id"] = productFilter.SubcategoryID
+	}
+
+	if productFilter.TagID != utils.EmptyString {
+		filter["tags._id"] = productFilter.TagID
+	}
+
+	if productFilter.DiscountID != utils.EmptyString {
+		filter["discount._id"] = productFilter.DiscountID
+	}
+
+	price := bson.A{}
+
+	if productFilter.MinPrice != nil {
+		price = append(price, bson.M{"$gte": bson.A{priceExpression, *productFilter.MinPrice}})
+	}
+
+	if productFilter.MaxPrice != nil {
+		price = append(price, bson.M{"$lte": bson.A{priceExpression, *productFilter.MaxPrice}})
+	}
+
+	if len(price) > 0 {
+		filter["$expr"] = bson.M{"$and": price}
+	}
+
+	for _, attribute := range productFilter.Attributes {
+		filter["attributes."+attribute.Name] = attributeCondition(attribute)
+	}
+
+	if len(productFilter.Statuses) > 0 {
+		filter[fieldStatus] = statusCondition(productFilter.Statuses...)
+	}
+
+	return filter
+}
+
+func statusCondition(statuses ...string) bson.M {
+	values := bson.A{}
+
+	for _, status := range statuses {
+		values = append(values, status)
+
+		if status == model.ProductStatusPublished {
+			values = append(values, nil)
+		}
+	}
+
+	return bson.M{"$in": values}
+}
+
+func productSort(productFilter model.ProductFilter) bson.D {
+	if productFilter.Sort == model.ProductSortRating {
+		return bson.D{{Key: "rating.average", Value: -1}, {Key: "rating.count", Value: -1}, {Key: "_id", Value: 1}}
+	}
+
+	return bson.D{{Key: "_id", Value: 1}}
+}
+
+func facetBuckets(field string) bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{field + "._id": bson.M{"$nin": bson.A{nil, utils.EmptyString}}}},
+		bson.M{"$group": bson.M{
+			"_id":   "$" + field + "._id",
+			"title": bson.M{"$first": "$" + field + ".title"},
+			"count": bson.M{"$sum": 1},
+		}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "title", Value: 1}}},
+	}
+}
+
+func priceBuckets(boundaries []float64, results []priceBucketCount) []model.PriceBucket {
+	counts := make(map[float64]int64, len(results))
+	var overflow int64
+
+	for _, result := range results {
+		if lower, ok := result.ID.(float64); ok {
+			counts[lower] = result.Count
+			continue
+		}
+
+		overflow = result.Count
+	}
+
+	buckets := make([]model.PriceBucket, 0, len(boundaries))
+	for i := 0; i < len(boundaries)-1; i++ {
+		upper := boundaries[i+1]
+		buckets = append(buckets, model.PriceBucket{Min: boundaries[i], Max: &upper, Count: counts[boundaries[i]]})
+	}
+
+	buckets = append(buckets, model.PriceBucket{Min: boundaries[len(boundaries)-1], Count: overflow})
+
+	return buckets
+}
+
+func attributeCondition(attribute model.AttributeFilter) bson.M {
+	condition := bson.M{}
+
+	if len(attribute.Values) > 0 {
+		values := make([]interface{}, 0, len(attribute.Values)*2)
+		for _, value := range attribute.Values {
+			values = append(values, value)
+
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				values = append(values, number)
+			}
+
+			if value == "true" || value == "false" {
+				values = append(values, value == "true")
+			}
+		}
+
+		condition["$in"] = values
+	}
+
+	if attribute.Min != nil {
+		condition["$gte"] = *attribute.Min
+	}
+
+	if attribute.Max != nil {
+		condition["$lte"] = *attribute.Max
+	}
+
+	return condition
+}
+
+func applyDiscountWindow(product *model.Product, now time.Time) {
+	product.Discount.IsActive = product.Discount.ActiveAt(now)
+}
+
+func (productRepository *productRepository) GetProductBySKU(ctx context.Context, sku string) (*model.Product, error) {
+	var product *model.Product
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
+	filter := bson.M{"$or": bson.A{bson.M{"sku": sku}, bson.M{"variants.sku": sku}}, fieldDeletedAt: notDeleted}
+
+	result := productRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return product, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&product); err != nil {
+		return product, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	applyDiscountWindow(product, time.Now())
+
+	return product, nil
+}
+
+func (productRepository *productRepository) AddProductVariant(ctx context.Context, uuid string, variant *model.Variant) error {
 	oid, err := primitive.ObjectIDFromHex(uuid)
 	if err != nil {
-		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, "variants.sku": bson.M{"$ne": variant.SKU}, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$push": bson.M{"variants": variant},
+	}
 
-	result, err := productRepository.collection.DeleteOne(ctx, filter)
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"variant": variant}, utils.ErrorVariantNotFound)
+}
+
+func (productRepository *productRepository) UpdateProductVariant(ctx context.Context, uuid string, sku string, variant *model.Variant) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	filter := bson.M{"_id": oid, "variants.sku": sku, fieldDeletedAt: notDeleted}
+
+	variant.SKU = sku
+
+	update := bson.M{
+		"$set": bson.M{"variants.$.attributes": variant.Attributes},
+	}
+
+	if variant.Price != utils.EmptyString {
+		update["$set"].(bson.M)["variants.$.price"] = variant.Price
+	} else {
+		update["$unset"] = bson.M{"variants.$.price": utils.EmptyString}
+	}
+
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"variant": variant}, utils.ErrorVariantNotFound)
+}
+
+func (productRepository *productRepository) DeleteProductVariant(ctx context.Context, uuid string, sku string) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, "variants.sku": sku, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$pull": bson.M{"variants": bson.M{"sku": sku}},
+	}
+
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"variant": model.Variant{SKU: sku}}, utils.ErrorVariantNotFound)
+}
+
+func (productRepository *productRepository) AddProductImages(ctx context.Context, uuid string, images []model.ProductImage) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$push": bson.M{"images": bson.M{"$each": images}},
+	}
+
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"images": images}, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error()))
+}
+
+func (productRepository *productRepository) SetProductImages(ctx context.Context, uuid string, images []model.ProductImage) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$set": bson.M{"images": images},
+	}
+
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"images": images}, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error()))
+}
+
+func (productRepository *productRepository) DeleteProductImage(ctx context.Context, uuid string, imageID string) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, "images._id": imageID, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$pull": bson.M{"images": bson.M{"_id": imageID}},
+	}
+
+	return productRepository.updateEmbedded(ctx, uuid, filter, update, bson.M{"image": bson.M{"_id": imageID}}, utils.ErrorImageNotFound)
+}
+
+func (productRepository *productRepository) updateEmbedded(ctx context.Context, uuid string, filter bson.M, update bson.M, data bson.M, notFound error) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return productRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := productRepository.collection.UpdateOne(ctx, filter, update)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, utils.ErrorSKUExists
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, notFound
+		}
+
+		return []model.Event{newEvent(utils.CollNameProduct, model.EventActionUpdated, uuid, data)}, nil
+	})
+}
+
+func (productRepository *productRepository) SetProductTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error {
+	return setTranslation(ctx, productRepository.outbox, productRepository.collection, utils.CollNameProduct, uuid, locale, translation)
+}
+
+func (productRepository *productRepository) GetProductBySlug(ctx context.Context, slug string) (*model.Product, error) {
+	var product *model.Product
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := slugFilter(slug)
+	filter[fieldDeletedAt] = notDeleted
+
+	result := productRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return product, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&product); err != nil {
+		return product, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	applyDiscountWindow(product, time.Now())
+
+	return product, nil
+}
+
+func (productRepository *productRepository) renameSlugs(ctx context.Context, products ...*model.Product) error {
+	slugger := newSlugger(productRepository.collection, utils.CollNameProduct)
+
+	ids := make([]string, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+
+	states, err := slugger.states(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		state, ok := states[product.ID]
+		if !ok {
+			continue
+		}
+
+		product.Slug, product.SlugHistory, err = slugger.rename(ctx, state, product.Title)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -0,0 +1,256 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func (productRepository *productRepository) SetProductStatus(ctx context.Context, product *model.Product, from string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(product.ID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	schedulePublication(product, time.Now().UTC())
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted, fieldStatus: statusCondition(from)}
+
+	object := bson.M{fieldStatus: product.Status}
+
+	for field, value := range map[string]*time.Time{"publish-at": product.PublishAt, "unpublish-at": product.UnpublishAt, "published-at": product.PublishedAt} {
+		if value != nil {
+			object[field] = value
+		}
+	}
+
+	update := setOrUnset(object, "publish-at", "unpublish-at", "published-at")
+
+	return productRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		ids := []string{product.ID}
+
+		before, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(ids)))
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := productRepository.collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, utils.ErrorProductStatus
+		}
+
+		after, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(ids)))
+		if err != nil {
+			return nil, err
+		}
+
+		usage := tagUsage{}
+		usage.diff(ids, before, after)
+
+		if err = usage.apply(ctx, productRepository.tags); err != nil {
+			return nil, err
+		}
+
+		action := publicationAction(from, product.Status)
+
+		return []model.Event{newEvent(utils.CollNameProduct, action, product.ID, object)}, nil
+	})
+}
+
+func (productRepository *productRepository) PublishScheduledProducts(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		fieldDeletedAt: notDeleted,
+		fieldStatus:    model.ProductStatusDraft,
+		"publish-at":   bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set":   bson.M{fieldStatus: model.ProductStatusPublished, "published-at": now},
+		"$unset": bson.M{"publish-at": utils.EmptyString},
+	}
+
+	return productRepository.transitProducts(ctx, filter, update, model.EventActionPublished)
+}
+
+func (productRepository *productRepository) UnpublishScheduledProducts(ctx context.Context, now time.Time) (int64, error) {
+	filter := bson.M{
+		fieldDeletedAt: notDeleted,
+		fieldStatus:    statusCondition(model.ProductStatusPublished),
+		"unpublish-at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set":   bson.M{fieldStatus: model.ProductStatusDraft},
+		"$unset": bson.M{"unpublish-at": utils.EmptyString, "published-at": utils.EmptyString},
+	}
+
+	return productRepository.transitProducts(ctx, filter, update, model.EventActionUnpublished)
+}
+
+func (productRepository *productRepository) GetNextPublicationTransition(ctx context.Context, now time.Time) (*time.Time, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	publishes := bson.M{
+		fieldDeletedAt: notDeleted,
+		fieldStatus:    model.ProductStatusDraft,
+		"publish-at":   bson.M{"$gt": now},
+	}
+
+	unpublishes := bson.M{
+		fieldDeletedAt: notDeleted,
+		fieldStatus:    statusCondition(model.ProductStatusPublished),
+		"unpublish-at": bson.M{"$gt": now},
+	}
+
+	var next *time.Time
+
+	for field, filter := range map[string]bson.M{"publish-at": publishes, "unpublish-at": unpublishes} {
+		var product model.Product
+
+		opts := options.FindOne().SetSort(bson.M{field: 1}).SetProjection(bson.M{field: 1})
+
+		err := productRepository.collection.FindOne(ctx, filter, opts).Decode(&product)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			continue
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		at := product.PublishAt
+		if field == "unpublish-at" {
+			at = product.UnpublishAt
+		}
+
+		if next == nil || at.Before(*next) {
+			next = at
+		}
+	}
+
+	return next, nil
+}
+
+func (productRepository *productRepository) transitProducts(ctx context.Context, filter bson.M, update bson.M, action string) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	var transited int64
+
+	err := productRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		transited = 0
+
+		opts := options.Find().SetProjection(bson.M{fieldStatus: 1, "publish-at": 1, "unpublish-at": 1, "published-at": 1})
+
+		cursor, err := productRepository.collection.Find(ctx, filter, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		var products []model.Product
+
+		if err = cursor.All(ctx, &products); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		if len(products) == 0 {
+			return nil, nil
+		}
+
+		ids := make([]string, 0, len(products))
+		oids := make([]primitive.ObjectID, 0, len(products))
+		events := make([]model.Event, 0, len(products))
+
+		for i := range products {
+			oid, err := primitive.ObjectIDFromHex(products[i].ID)
+			if err != nil {
+				return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+			}
+
+			ids = append(ids, products[i].ID)
+			oids = append(oids, oid)
+			events = append(events, newEvent(utils.CollNameProduct, action, products[i].ID, update["$set"]))
+		}
+
+		transitFilter := bson.M{"$and": bson.A{filter, bson.M{"_id": bson.M{"$in": oids}}}}
+
+		before, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(ids)))
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := productRepository.collection.UpdateMany(ctx, transitFilter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		after, err := productTags(ctx, productRepository.collection, countedProducts(productIDs(ids)))
+		if err != nil {
+			return nil, err
+		}
+
+		usage := tagUsage{}
+		usage.diff(ids, before, after)
+
+		if err = usage.apply(ctx, productRepository.tags); err != nil {
+			return nil, err
+		}
+
+		transited = result.ModifiedCount
+
+		return events, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return transited, nil
+}
+
+func schedulePublication(product *model.Product, now time.Time) {
+	if product.Status == model.ProductStatusDraft && product.PublishAt != nil && !now.Before(*product.PublishAt) {
+		product.Status = model.ProductStatusPublished
+		product.PublishAt = nil
+	}
+
+	if !product.IsPublished() {
+		product.PublishedAt = nil
+		return
+	}
+
+	if product.PublishedAt == nil {
+		product.PublishedAt = &now
+	}
+}
+
+func publicationAction(from string, to string) string {
+	switch {
+	case to == model.ProductStatusPublished && from != model.ProductStatusPublished:
+		return model.EventActionPublished
+	case to == model.ProductStatusArchived:
+		return model.EventActionArchived
+	case from == model.ProductStatusPublished && to == model.ProductStatusDraft:
+		return model.EventActionUnpublished
+	default:
+		return model.EventActionUpdated
+	}
+}
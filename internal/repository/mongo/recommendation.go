@@ -0,0 +1,246 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type recommendationRepository struct {
+	collection *mongo.Collection
+	overrides  *mongo.Collection
+	products   *mongo.Collection
+	orders     *mongo.Collection
+}
+
+func NewRecommendationRepository(storage *mongo.Database, collection string) repository.RecommendationRepository {
+	return &recommendationRepository{
+		collection: storage.Collection(collection),
+		overrides:  storage.Collection(utils.CollNameRelatedOverride),
+		products:   storage.Collection(utils.CollNameProduct),
+		orders:     storage.Collection(utils.CollNameOrder),
+	}
+}
+
+func (recommendationRepository *recommendationRepository) RefreshCoPurchases(ctx context.Context, since time.Time, depth int) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"created-at": bson.M{"$gte": since}, "items.1": bson.M{"$exists": true}}}},
+		{{Key: "$project", Value: bson.M{"products": bson.M{"$setUnion": bson.A{"$items.product-id", bson.A{}}}}}},
+		{{Key: "$match", Value: bson.M{"products.1": bson.M{"$exists": true}}}},
+		{{Key: "$project", Value: bson.M{"product": "$products", "other": "$products"}}},
+		{{Key: "$unwind", Value: "$product"}},
+		{{Key: "$unwind", Value: "$other"}},
+		{{Key: "$match", Value: bson.M{"$expr": bson.M{"$ne": bson.A{"$product", "$other"}}}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"product": "$product", "other": "$other"}, "orders": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "orders", Value: -1}, {Key: "_id.other", Value: 1}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$_id.product",
+			"related": bson.M{"$push": bson.M{"product-id": "$_id.other", "orders": "$orders"}},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"related":    bson.M{"$slice": bson.A{"$related", depth}},
+			"updated-at": bson.M{"$literal": now},
+		}}},
+		{{Key: "$merge", Value: bson.M{
+			"into":           recommendationRepository.collection.Name(),
+			"whenMatched":    "replace",
+			"whenNotMatched": "insert",
+		}}},
+	}
+
+	cursor, err := recommendationRepository.orders.Aggregate(ctx, pipeline)
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.Close(ctx); err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if _, err = recommendationRepository.collection.DeleteMany(ctx, bson.M{"updated-at": bson.M{"$lt": now}}); err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	count, err := recommendationRepository.collection.CountDocuments(ctx, bson.M{})
+	if err != nil {
+		return 0, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return count, nil
+}
+
+func (recommendationRepository *recommendationRepository) GetCoPurchase(ctx context.Context, productID string) (*model.CoPurchase, error) {
+	coPurchase := &model.CoPurchase{ProductID: productID}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result := recommendationRepository.collection.FindOne(ctx, bson.M{"_id": productID})
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return coPurchase, nil
+	}
+
+	if result.Err() != nil {
+		return coPurchase, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(coPurchase); err != nil {
+		return coPurchase, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return coPurchase, nil
+}
+
+func (recommendationRepository *recommendationRepository) GetSimilarCandidates(ctx context.Context, product *model.Product, limit int64) ([]model.Product, error) {
+	var products []model.Product
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(product.ID)
+	if err != nil {
+		return products, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	pipeline := similarCandidatesPipeline(product, oid, limit)
+	if pipeline == nil {
+		return products, nil
+	}
+
+	cursor, err := recommendationRepository.products.Aggregate(ctx, pipeline)
+	if err != nil {
+		return products, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &products); err != nil {
+		return products, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	now := time.Now()
+	for i := range products {
+		applyDiscountWindow(&products[i], now)
+	}
+
+	return products, nil
+}
+
+func similarCandidatesPipeline(product *model.Product, oid primitive.ObjectID, limit int64) mongo.Pipeline {
+	conditions := bson.A{}
+
+	tagIDs := make([]string, 0, len(product.Tags))
+	for _, tag := range product.Tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	if len(tagIDs) > 0 {
+		conditions = append(conditions, bson.M{"tags._id": bson.M{"$in": tagIDs}})
+	}
+
+	if product.Subcategory.ID != utils.EmptyString {
+		conditions = append(conditions, bson.M{"subcategory._id": product.Subcategory.ID})
+	}
+
+	if product.Category.ID != utils.EmptyString {
+		conditions = append(conditions, bson.M{"category._id": product.Category.ID})
+	}
+
+	if len(conditions) == 0 {
+		return nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$ne": oid}, fieldDeletedAt: notDeleted, fieldStatus: statusCondition(model.ProductStatusPublished), "$or": conditions}
+
+	relevance := bson.M{
+		"shared-tags":      bson.M{"$size": bson.M{"$setIntersection": bson.A{bson.M{"$ifNull": bson.A{"$tags._id", bson.A{}}}, tagIDs}}},
+		"same-subcategory": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$subcategory._id", product.Subcategory.ID}}, 1, 0}},
+		"same-category":    bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$category._id", product.Category.ID}}, 1, 0}},
+	}
+
+	return mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$addFields", Value: bson.M{"relevance": relevance}}},
+		{{Key: "$sort", Value: bson.D{
+			{Key: "relevance.shared-tags", Value: -1},
+			{Key: "relevance.same-subcategory", Value: -1},
+			{Key: "relevance.same-category", Value: -1},
+			{Key: "rating.average", Value: -1},
+			{Key: "rating.count", Value: -1},
+			{Key: "_id", Value: 1},
+		}}},
+		{{Key: "$limit", Value: limit}},
+		{{Key: "$unset", Value: "relevance"}},
+	}
+}
+
+func (recommendationRepository *recommendationRepository) GetRelatedOverride(ctx context.Context, productID string) (*model.RelatedOverride, error) {
+	override := &model.RelatedOverride{ProductID: productID}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result := recommendationRepository.overrides.FindOne(ctx, bson.M{"_id": productID})
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return override, nil
+	}
+
+	if result.Err() != nil {
+		return override, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(override); err != nil {
+		return override, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return override, nil
+}
+
+func (recommendationRepository *recommendationRepository) SaveRelatedOverride(ctx context.Context, override *model.RelatedOverride) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	override.UpdatedAt = time.Now().UTC()
+
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := recommendationRepository.overrides.ReplaceOne(ctx, bson.M{"_id": override.ProductID}, override, opts); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (recommendationRepository *recommendationRepository) DeleteRelatedOverride(ctx context.Context, productID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result, err := recommendationRepository.overrides.DeleteOne(ctx, bson.M{"_id": productID})
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestSimilarCandidatesPipelineSortsBeforeLimit(t *testing.T) {
+	product := &model.Product{
+		Tags:     []model.Tag{{ID: "cotton"}},
+		Category: model.Category{ID: "clothes"},
+	}
+
+	pipeline := similarCandidatesPipeline(product, primitive.NewObjectID(), 20)
+
+	stages := make([]string, 0, len(pipeline))
+	for _, stage := range pipeline {
+		stages = append(stages, stage[0].Key)
+	}
+
+	want := []string{"$match", "$addFields", "$sort", "$limit", "$unset"}
+	if !reflect.DeepEqual(stages, want) {
+		t.Fatalf("stages = %v, want %v", stages, want)
+	}
+
+	sort := pipeline[2][0].Value.(bson.D)
+
+	keys := make([]string, 0, len(sort))
+	for _, key := range sort {
+		keys = append(keys, key.Key)
+	}
+
+	wantKeys := []string{"relevance.shared-tags", "relevance.same-subcategory", "relevance.same-category", "rating.average", "rating.count", "_id"}
+	if !reflect.DeepEqual(keys, wantKeys) {
+		t.Fatalf("sort keys = %v, want %v", keys, wantKeys)
+	}
+
+	if limit := pipeline[3][0].Value; limit != int64(20) {
+		t.Errorf("limit = %v, want 20", limit)
+	}
+}
+
+func TestSimilarCandidatesPipelineWithoutSignals(t *testing.T) {
+	if pipeline := similarCandidatesPipeline(&model.Product{}, primitive.NewObjectID(), 20); pipeline != nil {
+		t.Fatalf("expected no pipeline for a product without tags or categories, got %v", pipeline)
+	}
+}
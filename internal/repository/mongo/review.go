@@ -0,0 +1,257 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type reviewRepository struct {
+	collection *mongo.Collection
+	products   *mongo.Collection
+	outbox     *outbox
+}
+
+func NewReviewRepository(storage *mongo.Database, collection string) repository.ReviewRepository {
+	return &reviewRepository{
+		collection: storage.Collection(collection),
+		products:   storage.Collection(utils.CollNameProduct),
+		outbox:     newOutbox(storage),
+	}
+}
+
+func (reviewRepository *reviewRepository) CreateReview(ctx context.Context, review *model.Review) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+	review.CreatedAt, review.UpdatedAt = now, now
+
+	var createdReviewID string
+
+	err := reviewRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := reviewRepository.collection.InsertOne(ctx, review)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		}
+
+		createdReviewID = oid.Hex()
+		review.ID = createdReviewID
+
+		if err = reviewRepository.applyRating(ctx, review.ProductID, nil, review); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(utils.CollNameReview, model.EventActionCreated, createdReviewID, review)}, nil
+	})
+	if err != nil {
+		return utils.EmptyString, err
+	}
+
+	return createdReviewID, nil
+}
+
+func (reviewRepository *reviewRepository) GetReview(ctx context.Context, uuid string) (*model.Review, error) {
+	var review *model.Review
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return review, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result := reviewRepository.collection.FindOne(ctx, bson.M{"_id": oid})
+	if result.Err() != nil {
+		return review, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&review); err != nil {
+		return review, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return review, nil
+}
+
+func (reviewRepository *reviewRepository) GetReviews(ctx context.Context, reviewFilter model.ReviewFilter) (*[]model.Review, error) {
+	var reviews []model.Review
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{}
+
+	if reviewFilter.ProductID != utils.EmptyString {
+		filter["product-id"] = reviewFilter.ProductID
+	}
+
+	if reviewFilter.Status != utils.EmptyString {
+		filter["status"] = reviewFilter.Status
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created-at", Value: -1}})
+
+	cursor, err := reviewRepository.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return &reviews, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &reviews); err != nil {
+		return &reviews, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &reviews, nil
+}
+
+func (reviewRepository *reviewRepository) UpdateReview(ctx context.Context, review *model.Review) error {
+	update := bson.M{
+		"$set": bson.M{
+			"rating":     review.Rating,
+			"title":      review.Title,
+			"body":       review.Body,
+			"status":     review.Status,
+			"updated-at": time.Now().UTC(),
+		},
+		"$unset": bson.M{"moderation-note": "", "moderated-by": "", "moderated-at": ""},
+	}
+
+	return reviewRepository.change(ctx, review.ID, update, model.EventActionUpdated)
+}
+
+func (reviewRepository *reviewRepository) ModerateReview(ctx context.Context, review *model.Review) error {
+	update := bson.M{
+		"$set": bson.M{
+			"status":          review.Status,
+			"moderation-note": review.ModerationNote,
+			"moderated-by":    review.ModeratedBy,
+			"moderated-at":    review.ModeratedAt,
+			"updated-at":      time.Now().UTC(),
+		},
+	}
+
+	return reviewRepository.change(ctx, review.ID, update, model.EventActionModerated)
+}
+
+func (reviewRepository *reviewRepository) DeleteReview(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return reviewRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var before model.Review
+
+		result := reviewRepository.collection.FindOneAndDelete(ctx, bson.M{"_id": oid})
+		if result.Err() != nil {
+			return nil, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err := result.Decode(&before); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		if err := reviewRepository.applyRating(ctx, before.ProductID, &before, nil); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(utils.CollNameReview, model.EventActionDeleted, uuid, nil)}, nil
+	})
+}
+
+func (reviewRepository *reviewRepository) change(ctx context.Context, uuid string, update bson.M, action string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return reviewRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var before, after model.Review
+
+		result := reviewRepository.collection.FindOne(ctx, bson.M{"_id": oid})
+		if result.Err() != nil {
+			return nil, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err := result.Decode(&before); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		result = reviewRepository.collection.FindOneAndUpdate(ctx, bson.M{"_id": oid}, update, opts)
+		if result.Err() != nil {
+			return nil, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err := result.Decode(&after); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		if err := reviewRepository.applyRating(ctx, after.ProductID, &before, &after); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(utils.CollNameReview, action, uuid, after)}, nil
+	})
+}
+
+func (reviewRepository *reviewRepository) applyRating(ctx mongo.SessionContext, productID string, before *model.Review, after *model.Review) error {
+	sumBefore, countBefore := before.Contribution()
+	sumAfter, countAfter := after.Contribution()
+
+	sum, count := sumAfter-sumBefore, countAfter-countBefore
+	if sum == 0 && count == 0 {
+		return nil
+	}
+
+	oid, err := primitive.ObjectIDFromHex(productID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"rating.sum":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating.sum", 0}}, sum}},
+			"rating.count": bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$rating.count", 0}}, count}},
+		}}},
+		{{Key: "$set", Value: bson.M{
+			"rating.average": bson.M{"$cond": bson.A{
+				bson.M{"$gt": bson.A{"$rating.count", 0}},
+				bson.M{"$round": bson.A{bson.M{"$divide": bson.A{"$rating.sum", "$rating.count"}}, 2}},
+				0,
+			}},
+		}}},
+	}
+
+	if _, err = reviewRepository.products.UpdateOne(ctx, bson.M{"_id": oid}, update); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,199 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/slug"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	fieldSlug        = "slug"
+	fieldSlugHistory = "slug-history"
+	slugAttempts     = 3
+)
+
+type slugState struct {
+	ID          string   `bson:"_id"`
+	Title       string   `bson:"title"`
+	Slug        string   `bson:"slug"`
+	SlugHistory []string `bson:"slug-history"`
+}
+
+type slugger struct {
+	collection *mongo.Collection
+	fallback   string
+	taken      map[string]string
+}
+
+func newSlugger(collection *mongo.Collection, fallback string) *slugger {
+	return &slugger{collection: collection, fallback: fallback, taken: make(map[string]string)}
+}
+
+func slugFilter(value string) bson.M {
+	return bson.M{"$or": bson.A{bson.M{fieldSlug: value}, bson.M{fieldSlugHistory: value}}}
+}
+
+func isSlugConflict(err error) bool {
+	var serverErr mongo.ServerError
+
+	return errors.As(err, &serverErr) && serverErr.HasErrorCodeWithMessage(11000, "index: "+fieldSlug+"_1 ")
+}
+
+func retrySlugConflicts(write func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := write()
+		if !isSlugConflict(err) {
+			return err
+		}
+
+		if attempt == slugAttempts {
+			return utils.ErrorSlugExists
+		}
+	}
+}
+
+func (slugger *slugger) generate(ctx context.Context, title string, ownerID string) (string, error) {
+	base := slug.Make(title)
+	if base == utils.EmptyString {
+		base = slugger.fallback
+	}
+
+	for n := 1; ; n++ {
+		candidate := slug.WithSuffix(base, n)
+
+		if owner, ok := slugger.taken[candidate]; ok && owner != ownerID {
+			continue
+		}
+
+		filter := slugFilter(candidate)
+		if oid, err := primitive.ObjectIDFromHex(ownerID); err == nil {
+			filter["_id"] = bson.M{"$ne": oid}
+		}
+
+		count, err := slugger.collection.CountDocuments(ctx, filter, options.Count().SetLimit(1))
+		if err != nil {
+			return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if count == 0 {
+			slugger.taken[candidate] = ownerID
+			return candidate, nil
+		}
+	}
+}
+
+func (slugger *slugger) states(ctx context.Context, ids []string) (map[string]slugState, error) {
+	states := make(map[string]slugState, len(ids))
+
+	oids := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if oid, err := primitive.ObjectIDFromHex(id); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+
+	if len(oids) == 0 {
+		return states, nil
+	}
+
+	filter := bson.M{"_id": bson.M{"$in": oids}}
+	opts := options.Find().SetProjection(bson.M{"title": 1, fieldSlug: 1, fieldSlugHistory: 1})
+
+	cursor, err := slugger.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return states, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	var found []slugState
+
+	if err = cursor.All(ctx, &found); err != nil {
+		return states, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	for _, state := range found {
+		states[state.ID] = state
+	}
+
+	return states, nil
+}
+
+func (slugger *slugger) rename(ctx context.Context, state slugState, title string) (string, []string, error) {
+	base := slug.Make(title)
+	if base == utils.EmptyString {
+		base = slugger.fallback
+	}
+
+	if state.Slug != utils.EmptyString && slug.Derives(state.Slug, base) {
+		slugger.taken[state.Slug] = state.ID
+		return state.Slug, state.SlugHistory, nil
+	}
+
+	current, err := slugger.generate(ctx, title, state.ID)
+	if err != nil {
+		return utils.EmptyString, nil, err
+	}
+
+	history := make([]string, 0, len(state.SlugHistory)+1)
+	for _, previous := range state.SlugHistory {
+		if previous != current {
+			history = append(history, previous)
+		}
+	}
+
+	if state.Slug != utils.EmptyString {
+		history = append(history, state.Slug)
+	}
+
+	return current, history, nil
+}
+
+func BackfillSlugs(ctx context.Context, storage *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+
+	defer cancel()
+
+	for _, name := range []string{utils.CollNameProduct, utils.CollNameCategory} {
+		collection := storage.Collection(name)
+		slugger := newSlugger(collection, name)
+
+		opts := options.Find().SetProjection(bson.M{"title": 1})
+
+		cursor, err := collection.Find(ctx, bson.M{fieldSlug: bson.M{"$exists": false}}, opts)
+		if err != nil {
+			return errors.Wrapf(err, "find %s without slugs", name)
+		}
+
+		var states []slugState
+
+		if err = cursor.All(ctx, &states); err != nil {
+			return errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		for _, state := range states {
+			value, err := slugger.generate(ctx, state.Title, state.ID)
+			if err != nil {
+				return err
+			}
+
+			oid, err := primitive.ObjectIDFromHex(state.ID)
+			if err != nil {
+				return errors.Wrap(err, utils.ErrorConvert.Error())
+			}
+
+			filter := bson.M{"_id": oid, fieldSlug: bson.M{"$exists": false}}
+			if _, err = collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{fieldSlug: value}}); err != nil {
+				return errors.Wrapf(err, "backfill %s slug", name)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"testing"
+
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func duplicateKeyError(index string) error {
+	return mongo.WriteException{WriteErrors: mongo.WriteErrors{{
+		Code:    11000,
+		Message: "E11000 duplicate key error collection: store.product index: " + index + " dup key: { : \"shirt\" }",
+	}}}
+}
+
+func TestIsSlugConflict(t *testing.T) {
+	if !isSlugConflict(errors.Wrap(duplicateKeyError("slug_1"), utils.ErrorExecuteQuery.Error())) {
+		t.Fatal("expected a slug index violation to be a slug conflict")
+	}
+
+	if isSlugConflict(duplicateKeyError("variants.sku_1")) {
+		t.Fatal("expected a sku index violation not to be a slug conflict")
+	}
+
+	if isSlugConflict(nil) {
+		t.Fatal("expected nil not to be a slug conflict")
+	}
+}
+
+func TestRetrySlugConflicts(t *testing.T) {
+	attempts := 0
+
+	err := retrySlugConflicts(func() error {
+		attempts++
+		if attempts < 2 {
+			return duplicateKeyError("slug_1")
+		}
+
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Fatalf("expected success on the second attempt, got %v after %d attempts", err, attempts)
+	}
+
+	attempts = 0
+
+	err = retrySlugConflicts(func() error {
+		attempts++
+		return duplicateKeyError("slug_1")
+	})
+	if !errors.Is(err, utils.ErrorSlugExists) || attempts != slugAttempts {
+		t.Fatalf("expected ErrorSlugExists after %d attempts, got %v after %d", slugAttempts, err, attempts)
+	}
+
+	sku := duplicateKeyError("variants.sku_1")
+
+	err = retrySlugConflicts(func() error {
+		return sku
+	})
+	if !mongo.IsDuplicateKeyError(err) || isSlugConflict(err) {
+		t.Fatalf("expected the sku violation to be returned as is, got %v", err)
+	}
+}
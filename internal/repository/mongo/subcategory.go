@@ -12,15 +12,20 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type subcategoryRepository struct {
 	collection *mongo.Collection
+	categories *mongo.Collection
+	outbox     *outbox
 }
 
 func NewSubcategoryRepository(storage *mongo.Database, collection string) repository.SubcategoryRepository {
 	return &subcategoryRepository{
 		collection: storage.Collection(collection),
+		categories: storage.Collection(utils.CollNameCategory),
+		outbox:     newOutbox(storage),
 	}
 }
 
@@ -36,7 +41,7 @@ func (subcategoryRepository *subcategoryRepository) GetSubcategory(ctx context.C
 		return subcategory, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	result := subcategoryRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -50,14 +55,14 @@ func (subcategoryRepository *subcategoryRepository) GetSubcategory(ctx context.C
 	return subcategory, nil
 }
 
-func (subcategoryRepository *subcategoryRepository) GetSubcategoryByTitle(ctx context.Context, title string) (*model.Subcategory, error) {
+func (subcategoryRepository *subcategoryRepository) GetSubcategoryByTitle(ctx context.Context, title string, locale string) (*model.Subcategory, error) {
 	var subcategory *model.Subcategory
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	filter := bson.M{"title": title}
+	filter := titleFilter(title, locale)
 
 	result := subcategoryRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
@@ -74,7 +79,7 @@ func (subcategoryRepository *subcategoryRepository) GetSubcategoryByTitle(ctx co
 func (subcategoryRepository *subcategoryRepository) GetAllSubcategories(ctx context.Context) (*[]model.Subcategory, error) {
 	var subcategories []model.Subcategory
 
-	filter := bson.M{}
+	filter := bson.M{fieldDeletedAt: notDeleted}
 
 	cursor, err := subcategoryRepository.collection.Find(ctx, filter)
 	if err != nil {
@@ -93,17 +98,32 @@ func (subcategoryRepository *subcategoryRepository) CreateSubcategory(ctx contex
 
 	defer cancel()
 
-	result, err := subcategoryRepository.collection.InsertOne(ctx, subcategory)
-	if err != nil {
-		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
-	}
+	var createdSubcategoryID string
+
+	err := subcategoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := subcategoryRepository.collection.InsertOne(ctx, subcategory)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		}
+
+		createdSubcategoryID = oid.Hex()
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		if err = subcategoryRepository.syncCategories(ctx, subcategory.CategoryID); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(utils.CollNameSubcategory, model.EventActionCreated, createdSubcategoryID, subcategory)}, nil
+	})
+	if err != nil {
+		return utils.EmptyString, err
 	}
 
-	return oid.Hex(), nil
+	return createdSubcategoryID, nil
 }
 
 func (subcategoryRepository *subcategoryRepository) UpdateSubcategory(ctx context.Context, category *model.Subcategory) error {
@@ -116,7 +136,7 @@ func (subcategoryRepository *subcategoryRepository) UpdateSubcategory(ctx contex
 		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	categoryByte, err := bson.Marshal(category)
 	if err != nil {
@@ -131,43 +151,322 @@ func (subcategoryRepository *subcategoryRepository) UpdateSubcategory(ctx contex
 	}
 
 	delete(object, "_id")
+	delete(object, "category-id")
 
 	update := bson.M{
 		"$set": object,
 	}
 
-	result, err := subcategoryRepository.collection.UpdateOne(ctx, filter, update)
+	return subcategoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var updated model.Subcategory
+
+		opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+		err := subcategoryRepository.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&updated)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if err = subcategoryRepository.syncCategories(ctx, updated.CategoryID); err != nil {
+			return nil, err
+		}
+
+		category.CategoryID = updated.CategoryID
+
+		return []model.Event{newEvent(utils.CollNameSubcategory, model.EventActionUpdated, category.ID, category)}, nil
+	})
+}
+
+func (subcategoryRepository *subcategoryRepository) DeleteSubcategory(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return softDelete(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, uuid, subcategoryRepository.syncCategoriesOf(uuid))
+}
+
+func (subcategoryRepository *subcategoryRepository) RestoreSubcategory(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return restore(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, uuid, subcategoryRepository.syncCategoriesOf(uuid))
+}
+
+func (subcategoryRepository *subcategoryRepository) GetDeletedSubcategories(ctx context.Context) (*[]model.Subcategory, error) {
+	var subcategories []model.Subcategory
+
+	if err := findDeleted(ctx, subcategoryRepository.collection, &subcategories); err != nil {
+		return &subcategories, err
+	}
+
+	return &subcategories, nil
+}
+
+func (subcategoryRepository *subcategoryRepository) PurgeSubcategories(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, before)
+}
+
+func (subcategoryRepository *subcategoryRepository) BulkCreateSubcategories(ctx context.Context, subcategories []model.Subcategory, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	categoryIDs := make([]string, 0, len(subcategories))
+	documents := make([]interface{}, 0, len(subcategories))
+	for i := range subcategories {
+		categoryIDs = append(categoryIDs, subcategories[i].CategoryID)
+		documents = append(documents, subcategories[i])
+	}
+
+	results, err := bulkCreate(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, documents, ordered, nil)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return nil, err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	return results, subcategoryRepository.resyncCategories(ctx, categoryIDs...)
+}
+
+func (subcategoryRepository *subcategoryRepository) BulkUpdateSubcategories(ctx context.Context, subcategories []model.Subcategory, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	ids := make([]string, 0, len(subcategories))
+	documents := make([]interface{}, 0, len(subcategories))
+	for i := range subcategories {
+		object, err := toDocument(subcategories[i])
+		if err != nil {
+			return nil, err
+		}
+
+		delete(object, "category-id")
+
+		ids = append(ids, subcategories[i].ID)
+		documents = append(documents, object)
 	}
 
-	return nil
+	results, err := bulkUpdate(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, ids, documents, ordered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs, err := subcategoryRepository.categoryIDsOf(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, subcategoryRepository.resyncCategories(ctx, categoryIDs...)
 }
 
-func (subcategoryRepository *subcategoryRepository) DeleteSubcategory(ctx context.Context, uuid string) error {
+func (subcategoryRepository *subcategoryRepository) BulkDeleteSubcategories(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	results, err := bulkDelete(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, uuids, ordered, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryIDs, err := subcategoryRepository.categoryIDsOf(ctx, uuids)
+	if err != nil {
+		return nil, err
+	}
+
+	return results, subcategoryRepository.resyncCategories(ctx, categoryIDs...)
+}
+
+func (subcategoryRepository *subcategoryRepository) GetSubcategoriesByCategory(ctx context.Context, categoryID string) (*[]model.Subcategory, error) {
+	var subcategories []model.Subcategory
+
+	filter := bson.M{"category-id": categoryID, fieldDeletedAt: notDeleted}
+
+	cursor, err := subcategoryRepository.collection.Find(ctx, filter)
+	if err != nil {
+		return &subcategories, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &subcategories); err != nil {
+		return &subcategories, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &subcategories, nil
+}
+
+func (subcategoryRepository *subcategoryRepository) AssignSubcategory(ctx context.Context, uuid string, categoryID string) error {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
 	oid, err := primitive.ObjectIDFromHex(uuid)
 	if err != nil {
-		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	update := bson.M{"$set": bson.M{"category-id": categoryID}}
+	if categoryID == utils.EmptyString {
+		update = bson.M{"$unset": bson.M{"category-id": utils.EmptyString}}
 	}
 
-	filter := bson.M{"_id": oid}
+	return subcategoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var before model.Subcategory
+
+		err := subcategoryRepository.collection.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if err = subcategoryRepository.syncCategories(ctx, before.CategoryID, categoryID); err != nil {
+			return nil, err
+		}
+
+		before.CategoryID = categoryID
+
+		return []model.Event{newEvent(utils.CollNameSubcategory, model.EventActionUpdated, uuid, before)}, nil
+	})
+}
+
+func (subcategoryRepository *subcategoryRepository) syncCategoriesOf(uuid string) trashHook {
+	return func(ctx mongo.SessionContext) error {
+		categoryIDs, err := subcategoryRepository.categoryIDsOf(ctx, []string{uuid})
+		if err != nil {
+			return err
+		}
 
-	result, err := subcategoryRepository.collection.DeleteOne(ctx, filter)
+		return subcategoryRepository.syncCategories(ctx, categoryIDs...)
+	}
+}
+
+func (subcategoryRepository *subcategoryRepository) categoryIDsOf(ctx context.Context, uuids []string) ([]string, error) {
+	oids := make([]primitive.ObjectID, 0, len(uuids))
+	for _, uuid := range uuids {
+		if oid, err := primitive.ObjectIDFromHex(uuid); err == nil {
+			oids = append(oids, oid)
+		}
+	}
+
+	values, err := subcategoryRepository.collection.Distinct(ctx, "category-id", bson.M{"_id": bson.M{"$in": oids}})
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	categoryIDs := make([]string, 0, len(values))
+	for _, value := range values {
+		if categoryID, ok := value.(string); ok {
+			categoryIDs = append(categoryIDs, categoryID)
+		}
+	}
+
+	return categoryIDs, nil
+}
+
+func (subcategoryRepository *subcategoryRepository) resyncCategories(ctx context.Context, categoryIDs ...string) error {
+	return subcategoryRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		return nil, subcategoryRepository.syncCategories(ctx, categoryIDs...)
+	})
+}
+
+func (subcategoryRepository *subcategoryRepository) syncCategories(ctx context.Context, categoryIDs ...string) error {
+	synced := make(map[string]bool, len(categoryIDs))
+
+	for _, categoryID := range categoryIDs {
+		if categoryID == utils.EmptyString || synced[categoryID] {
+			continue
+		}
+
+		synced[categoryID] = true
+
+		oid, err := primitive.ObjectIDFromHex(categoryID)
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorConvert.Error())
+		}
+
+		subcategories, err := subcategoryRepository.GetSubcategoriesByCategory(ctx, categoryID)
+		if err != nil {
+			return err
+		}
+
+		embedded := *subcategories
+		if embedded == nil {
+			embedded = []model.Subcategory{}
+		}
+
+		update := bson.M{"$set": bson.M{"subcategories": embedded}}
+
+		result, err := subcategoryRepository.categories.UpdateOne(ctx, bson.M{"_id": oid}, update)
+		if err != nil {
+			return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return errors.Wrap(errors.New("category not found"), utils.ErrorExecuteQuery.Error())
+		}
 	}
 
 	return nil
 }
+
+func (subcategoryRepository *subcategoryRepository) SetSubcategoryTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error {
+	return setTranslation(ctx, subcategoryRepository.outbox, subcategoryRepository.collection, utils.CollNameSubcategory, uuid, locale, translation)
+}
+
+func LinkLegacySubcategories(ctx context.Context, storage *mongo.Database) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+
+	defer cancel()
+
+	subcategories := storage.Collection(utils.CollNameSubcategory)
+	products := storage.Collection(utils.CollNameProduct)
+
+	filter := bson.M{"category-id": bson.M{"$in": bson.A{nil, utils.EmptyString}}, fieldDeletedAt: notDeleted}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := subcategories.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "find subcategories without category")
+	}
+
+	var legacy []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	if err = cursor.All(ctx, &legacy); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	unlinked := make([]string, 0)
+
+	for _, subcategory := range legacy {
+		categoryIDs, err := products.Distinct(ctx, "category._id", bson.M{"subcategory._id": subcategory.ID.Hex(), fieldDeletedAt: notDeleted})
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		categoryID, ok := utils.EmptyString, len(categoryIDs) == 1
+		if ok {
+			categoryID, ok = categoryIDs[0].(string)
+		}
+
+		if !ok || categoryID == utils.EmptyString {
+			unlinked = append(unlinked, subcategory.ID.Hex())
+			continue
+		}
+
+		if _, err = subcategories.UpdateOne(ctx, bson.M{"_id": subcategory.ID}, bson.M{"$set": bson.M{"category-id": categoryID}}); err != nil {
+			return nil, errors.Wrapf(err, "link subcategory %s", subcategory.ID.Hex())
+		}
+	}
+
+	return unlinked, nil
+}
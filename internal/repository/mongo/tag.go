@@ -2,6 +2,7 @@ package mongo
 
 import (
 	"context"
+	"regexp"
 	"time"
 
 	"github.com/Meystergod/online-store/internal/domain/model"
@@ -12,20 +13,26 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type tagRepository struct {
 	collection *mongo.Collection
+	products   *mongo.Collection
+	outbox     *outbox
 }
 
 func NewTagRepository(storage *mongo.Database, collection string) repository.TagRepository {
 	return &tagRepository{
 		collection: storage.Collection(collection),
+		products:   storage.Collection(utils.CollNameProduct),
+		outbox:     newOutbox(storage),
 	}
 }
 
 func (tagRepository *tagRepository) GetTag(ctx context.Context, uuid string) (*model.Tag, error) {
 	var tag *model.Tag
+	var document tagDocument
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
@@ -36,55 +43,63 @@ func (tagRepository *tagRepository) GetTag(ctx context.Context, uuid string) (*m
 		return tag, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	result := tagRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return tag, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
 	}
 
-	if err = result.Decode(&tag); err != nil {
+	if err = result.Decode(&document); err != nil {
 		return tag, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
-	return tag, nil
+	found := document.toModel()
+
+	return &found, nil
 }
 
-func (tagRepository *tagRepository) GetTagByTitle(ctx context.Context, title string) (*model.Tag, error) {
+func (tagRepository *tagRepository) GetTagByTitle(ctx context.Context, title string, locale string) (*model.Tag, error) {
 	var tag *model.Tag
+	var document tagDocument
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	filter := bson.M{"title": title}
+	filter := titleFilter(title, locale)
 
 	result := tagRepository.collection.FindOne(ctx, filter)
 	if result.Err() != nil {
 		return tag, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
 	}
 
-	if err := result.Decode(&tag); err != nil {
+	if err := result.Decode(&document); err != nil {
 		return tag, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
-	return tag, nil
+	found := document.toModel()
+
+	return &found, nil
 }
 
 func (tagRepository *tagRepository) GetAllTags(ctx context.Context) (*[]model.Tag, error) {
 	var tags []model.Tag
+	var documents []tagDocument
 
-	filter := bson.M{}
+	filter := bson.M{fieldDeletedAt: notDeleted}
 
 	cursor, err := tagRepository.collection.Find(ctx, filter)
 	if err != nil {
 		return &tags, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
 
-	if err = cursor.All(ctx, &tags); err != nil {
+	if err = cursor.All(ctx, &documents); err != nil {
 		return &tags, errors.Wrap(err, utils.ErrorDecode.Error())
 	}
 
+	tags = tagsFromDocuments(documents)
+
 	return &tags, nil
 }
 
@@ -93,17 +108,28 @@ func (tagRepository *tagRepository) CreateTag(ctx context.Context, tag *model.Ta
 
 	defer cancel()
 
-	result, err := tagRepository.collection.InsertOne(ctx, tag)
-	if err != nil {
-		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
-	}
+	var createdTagID string
+
+	err := tagRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := tagRepository.collection.InsertOne(ctx, tagDocument{Tag: *tag})
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
 
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		oid, ok := result.InsertedID.(primitive.ObjectID)
+		if !ok {
+			return nil, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		}
+
+		createdTagID = oid.Hex()
+
+		return []model.Event{newEvent(utils.CollNameTag, model.EventActionCreated, createdTagID, tag)}, nil
+	})
+	if err != nil {
+		return utils.EmptyString, err
 	}
 
-	return oid.Hex(), nil
+	return createdTagID, nil
 }
 
 func (tagRepository *tagRepository) UpdateTag(ctx context.Context, tag *model.Tag) error {
@@ -116,7 +142,7 @@ func (tagRepository *tagRepository) UpdateTag(ctx context.Context, tag *model.Ta
 		return errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
 
 	tagByte, err := bson.Marshal(tag)
 	if err != nil {
@@ -136,38 +162,218 @@ func (tagRepository *tagRepository) UpdateTag(ctx context.Context, tag *model.Ta
 		"$set": object,
 	}
 
-	result, err := tagRepository.collection.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	return tagRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := tagRepository.collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		return []model.Event{newEvent(utils.CollNameTag, model.EventActionUpdated, tag.ID, tag)}, nil
+	})
+}
+
+func (tagRepository *tagRepository) DeleteTag(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return softDelete(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, uuid)
+}
+
+func (tagRepository *tagRepository) RestoreTag(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	return restore(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, uuid)
+}
+
+func (tagRepository *tagRepository) GetDeletedTags(ctx context.Context) (*[]model.Tag, error) {
+	var tags []model.Tag
+	var documents []tagDocument
+
+	if err := findDeleted(ctx, tagRepository.collection, &documents); err != nil {
+		return &tags, err
 	}
 
-	if result.MatchedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	tags = tagsFromDocuments(documents)
+
+	return &tags, nil
+}
+
+func (tagRepository *tagRepository) PurgeTags(ctx context.Context, before time.Time) (int64, error) {
+	return purge(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, before)
+}
+
+func (tagRepository *tagRepository) BulkCreateTags(ctx context.Context, tags []model.Tag, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	documents := make([]interface{}, 0, len(tags))
+	for i := range tags {
+		documents = append(documents, tagDocument{Tag: tags[i]})
 	}
 
-	return nil
+	return bulkCreate(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, documents, ordered, nil)
 }
 
-func (tagRepository *tagRepository) DeleteTag(ctx context.Context, uuid string) error {
+func (tagRepository *tagRepository) BulkUpdateTags(ctx context.Context, tags []model.Tag, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	ids := make([]string, 0, len(tags))
+	documents := make([]interface{}, 0, len(tags))
+	for i := range tags {
+		ids = append(ids, tags[i].ID)
+		documents = append(documents, tags[i])
+	}
+
+	return bulkUpdate(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, ids, documents, ordered, nil)
+}
+
+func (tagRepository *tagRepository) BulkDeleteTags(ctx context.Context, uuids []string, ordered bool) ([]model.BulkResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	return bulkDelete(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, uuids, ordered, nil)
+}
+
+func (tagRepository *tagRepository) SetTagTranslation(ctx context.Context, uuid string, locale string, translation *model.Translation) error {
+	return setTranslation(ctx, tagRepository.outbox, tagRepository.collection, utils.CollNameTag, uuid, locale, translation)
+}
+
+func (tagRepository *tagRepository) SuggestTags(ctx context.Context, prefix string, locales []string, limit int) ([]model.Tag, error) {
+	var tags []model.Tag
+	var documents []tagDocument
+
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 
 	defer cancel()
 
-	oid, err := primitive.ObjectIDFromHex(uuid)
+	pattern := primitive.Regex{Pattern: "^" + regexp.QuoteMeta(prefix), Options: "i"}
+
+	conditions := make(bson.A, 0, len(locales))
+	for _, locale := range locales {
+		field := "title"
+		if locale != utils.EmptyString {
+			field = fieldTranslations + "." + locale + ".title"
+		}
+
+		conditions = append(conditions, bson.M{field: pattern})
+	}
+
+	filter := bson.M{"$or": conditions, fieldDeletedAt: notDeleted}
+	opts := options.Find().
+		SetSort(bson.D{{Key: fieldUsage, Value: -1}, {Key: "title", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := tagRepository.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+		return tags, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
 	}
 
-	filter := bson.M{"_id": oid}
+	if err = cursor.All(ctx, &documents); err != nil {
+		return tags, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return tagsFromDocuments(documents), nil
+}
 
-	result, err := tagRepository.collection.DeleteOne(ctx, filter)
+func (tagRepository *tagRepository) MergeTags(ctx context.Context, sourceID string, target *model.Tag) (*model.TagMerge, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(sourceID)
 	if err != nil {
-		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
 	}
 
-	if result.DeletedCount == 0 {
-		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	embedded := *target
+	embedded.DeletedAt = nil
+	embedded.Usage = nil
+
+	merge := &model.TagMerge{SourceID: sourceID, Target: embedded}
+
+	err = tagRepository.outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		merge.Products = 0
+
+		filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+		update := bson.M{"$set": bson.M{fieldDeletedAt: time.Now().UTC()}}
+
+		result, err := tagRepository.collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		targetOID, err := primitive.ObjectIDFromHex(embedded.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+		}
+
+		moved, err := tagRepository.products.CountDocuments(ctx, countedProducts(bson.M{"tags._id": bson.M{"$eq": sourceID, "$ne": embedded.ID}}))
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+		cursor, err := tagRepository.products.Find(ctx, bson.M{"tags._id": sourceID}, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		var products []struct {
+			ID string `bson:"_id"`
+		}
+
+		if err = cursor.All(ctx, &products); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		both := bson.M{"tags._id": bson.M{"$all": bson.A{sourceID, embedded.ID}}}
+		if _, err = tagRepository.products.UpdateMany(ctx, both, bson.M{"$pull": bson.M{"tags": bson.M{"_id": sourceID}}}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if _, err = tagRepository.products.UpdateMany(ctx, bson.M{"tags._id": sourceID}, bson.M{"$set": bson.M{"tags.$": embedded}}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if _, err = tagRepository.collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": bson.M{fieldUsage: 0}}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if _, err = tagRepository.collection.UpdateOne(ctx, bson.M{"_id": targetOID}, bson.M{"$inc": bson.M{fieldUsage: moved}}); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		merge.Products = int64(len(products))
+
+		events := make([]model.Event, 0, len(products)+1)
+		events = append(events, newEvent(utils.CollNameTag, model.EventActionMerged, sourceID, merge))
+
+		for _, product := range products {
+			data := bson.M{"merged-tag": sourceID, "tag": embedded}
+			events = append(events, newEvent(utils.CollNameProduct, model.EventActionUpdated, product.ID, data))
+		}
+
+		return events, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return merge, nil
 }
@@ -0,0 +1,203 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const fieldUsage = "usage"
+
+type tagDocument struct {
+	model.Tag `bson:",inline"`
+	Usage     int64 `bson:"usage"`
+}
+
+type tagUsage map[string]int64
+
+func (document *tagDocument) toModel() model.Tag {
+	tag := document.Tag
+	usage := document.Usage
+	tag.Usage = &usage
+
+	return tag
+}
+
+func tagsFromDocuments(documents []tagDocument) []model.Tag {
+	tags := make([]model.Tag, 0, len(documents))
+	for i := range documents {
+		tags = append(tags, documents[i].toModel())
+	}
+
+	return tags
+}
+
+func (usage tagUsage) add(tags []model.Tag, delta int64) {
+	seen := make(map[string]bool, len(tags))
+
+	for _, tag := range tags {
+		if tag.ID == utils.EmptyString || seen[tag.ID] {
+			continue
+		}
+
+		seen[tag.ID] = true
+		usage[tag.ID] += delta
+	}
+}
+
+func (usage tagUsage) diff(ids []string, before map[string][]model.Tag, after map[string][]model.Tag) {
+	for _, id := range ids {
+		usage.add(before[id], -1)
+		usage.add(after[id], 1)
+	}
+}
+
+func (usage tagUsage) apply(ctx context.Context, tags *mongo.Collection) error {
+	writeModels := make([]mongo.WriteModel, 0, len(usage))
+
+	for id, delta := range usage {
+		if delta == 0 {
+			continue
+		}
+
+		oid, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			continue
+		}
+
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": oid}).
+			SetUpdate(bson.M{"$inc": bson.M{fieldUsage: delta}}))
+	}
+
+	if len(writeModels) == 0 {
+		return nil
+	}
+
+	if _, err := tags.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(false)); err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func productTags(ctx context.Context, products *mongo.Collection, filter bson.M) (map[string][]model.Tag, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "tags": 1})
+
+	cursor, err := products.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	var documents []struct {
+		ID   primitive.ObjectID `bson:"_id"`
+		Tags []model.Tag        `bson:"tags"`
+	}
+
+	if err = cursor.All(ctx, &documents); err != nil {
+		return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	tags := make(map[string][]model.Tag, len(documents))
+	for _, document := range documents {
+		tags[document.ID.Hex()] = document.Tags
+	}
+
+	return tags, nil
+}
+
+func countedProducts(filter bson.M) bson.M {
+	counted := bson.M{fieldDeletedAt: notDeleted, fieldStatus: statusCondition(model.ProductStatusPublished)}
+	for key, value := range filter {
+		counted[key] = value
+	}
+
+	return counted
+}
+
+func productIDs(ids []string) bson.M {
+	oids, _ := parseBulkIDs(ids)
+
+	return bson.M{"_id": bson.M{"$in": oids}}
+}
+
+func BackfillTagUsage(ctx context.Context, storage *mongo.Database) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+
+	defer cancel()
+
+	tags := storage.Collection(utils.CollNameTag)
+	products := storage.Collection(utils.CollNameProduct)
+
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+
+	cursor, err := tags.Find(ctx, bson.M{fieldUsage: bson.M{"$exists": false}}, opts)
+	if err != nil {
+		return errors.Wrap(err, "find tags without usage")
+	}
+
+	var legacy []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+
+	if err = cursor.All(ctx, &legacy); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	if len(legacy) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(legacy))
+	for _, tag := range legacy {
+		ids = append(ids, tag.ID.Hex())
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: countedProducts(bson.M{"tags._id": bson.M{"$in": ids}})}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$match", Value: bson.M{"tags._id": bson.M{"$in": ids}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags._id", "products": bson.M{"$addToSet": "$_id"}}}},
+		{{Key: "$project", Value: bson.M{"count": bson.M{"$size": "$products"}}}},
+	}
+
+	cursor, err = products.Aggregate(ctx, pipeline)
+	if err != nil {
+		return errors.Wrap(err, "count tag usage")
+	}
+
+	var counts []struct {
+		ID    string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+
+	if err = cursor.All(ctx, &counts); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	usage := make(map[string]int64, len(counts))
+	for _, count := range counts {
+		usage[count.ID] = count.Count
+	}
+
+	writeModels := make([]mongo.WriteModel, 0, len(legacy))
+	for _, tag := range legacy {
+		writeModels = append(writeModels, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": tag.ID, fieldUsage: bson.M{"$exists": false}}).
+			SetUpdate(bson.M{"$set": bson.M{fieldUsage: usage[tag.ID.Hex()]}}))
+	}
+
+	if _, err = tags.BulkWrite(ctx, writeModels, options.BulkWrite().SetOrdered(false)); err != nil {
+		return errors.Wrap(err, "backfill tag usage")
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	fieldDeletedAt = "deleted-at"
+	purgeBatchSize = 500
+)
+
+var (
+	notDeleted = bson.M{"$exists": false}
+	isDeleted  = bson.M{"$exists": true}
+)
+
+type trashHook func(ctx mongo.SessionContext) error
+
+type purgeHook func(ctx mongo.SessionContext, ids []string) error
+
+func softDelete(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, uuid string, hooks ...trashHook) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: notDeleted}
+
+	update := bson.M{
+		"$set": bson.M{fieldDeletedAt: time.Now().UTC()},
+	}
+
+	return outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		result, err := collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err = runTrashHooks(ctx, hooks); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(entityType, model.EventActionDeleted, uuid, nil)}, nil
+	})
+}
+
+func restore(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, uuid string, hooks ...trashHook) error {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, fieldDeletedAt: isDeleted}
+
+	update := bson.M{
+		"$unset": bson.M{fieldDeletedAt: utils.EmptyString},
+	}
+
+	return outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		var document struct {
+			Title string `bson:"title"`
+		}
+
+		opts := options.FindOne().SetProjection(bson.M{"title": 1})
+
+		err := collection.FindOne(ctx, filter, opts).Decode(&document)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, errors.Wrap(errors.New("not found in trash"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		conflicts, err := collection.CountDocuments(ctx, bson.M{"_id": bson.M{"$ne": oid}, "title": document.Title, fieldDeletedAt: notDeleted})
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if conflicts > 0 {
+			return nil, utils.ErrorTitleExists
+		}
+
+		result, err := collection.UpdateOne(ctx, filter, update)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		if result.MatchedCount == 0 {
+			return nil, errors.Wrap(errors.New("not found in trash"), utils.ErrorExecuteQuery.Error())
+		}
+
+		if err = runTrashHooks(ctx, hooks); err != nil {
+			return nil, err
+		}
+
+		return []model.Event{newEvent(entityType, model.EventActionRestored, uuid, nil)}, nil
+	})
+}
+
+func runTrashHooks(ctx mongo.SessionContext, hooks []trashHook) error {
+	for _, hook := range hooks {
+		if err := hook(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func findDeleted(ctx context.Context, collection *mongo.Collection, results interface{}) error {
+	filter := bson.M{fieldDeletedAt: isDeleted}
+	opts := options.Find().SetSort(bson.M{fieldDeletedAt: -1})
+
+	cursor, err := collection.Find(ctx, filter, opts)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, results); err != nil {
+		return errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return nil
+}
+
+func purge(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, before time.Time, hooks ...purgeHook) (int64, error) {
+	var total int64
+
+	for {
+		purged, err := purgeBatch(ctx, outbox, collection, entityType, before, hooks)
+		if err != nil {
+			return total, err
+		}
+
+		total += purged
+
+		if purged < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func purgeBatch(ctx context.Context, outbox *outbox, collection *mongo.Collection, entityType string, before time.Time, hooks []purgeHook) (int64, error) {
+	filter := bson.M{fieldDeletedAt: bson.M{"$lte": before}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(purgeBatchSize)
+
+	var purged int64
+
+	err := outbox.transact(ctx, func(ctx mongo.SessionContext) ([]model.Event, error) {
+		purged = 0
+
+		cursor, err := collection.Find(ctx, filter, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		var documents []struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+
+		if err = cursor.All(ctx, &documents); err != nil {
+			return nil, errors.Wrap(err, utils.ErrorDecode.Error())
+		}
+
+		if len(documents) == 0 {
+			return nil, nil
+		}
+
+		oids := make([]primitive.ObjectID, 0, len(documents))
+		ids := make([]string, 0, len(documents))
+		events := make([]model.Event, 0, len(documents))
+
+		for _, document := range documents {
+			oids = append(oids, document.ID)
+			ids = append(ids, document.ID.Hex())
+			events = append(events, newEvent(entityType, model.EventActionPurged, document.ID.Hex(), nil))
+		}
+
+		result, err := collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": oids}})
+		if err != nil {
+			return nil, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+
+		for _, hook := range hooks {
+			if err = hook(ctx, ids); err != nil {
+				return nil, err
+			}
+		}
+
+		purged = result.DeletedCount
+
+		return events, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
@@ -0,0 +1,142 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type warehouseRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWarehouseRepository(storage *mongo.Database, collection string) repository.WarehouseRepository {
+	return &warehouseRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (warehouseRepository *warehouseRepository) GetWarehouse(ctx context.Context, uuid string) (*model.Warehouse, error) {
+	var warehouse *model.Warehouse
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return warehouse, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result := warehouseRepository.collection.FindOne(ctx, bson.M{"_id": oid})
+	if result.Err() != nil {
+		return warehouse, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&warehouse); err != nil {
+		return warehouse, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return warehouse, nil
+}
+
+func (warehouseRepository *warehouseRepository) GetAllWarehouses(ctx context.Context) (*[]model.Warehouse, error) {
+	var warehouses []model.Warehouse
+
+	opts := options.Find().SetSort(bson.D{{Key: "priority", Value: 1}, {Key: "code", Value: 1}})
+
+	cursor, err := warehouseRepository.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return &warehouses, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &warehouses); err != nil {
+		return &warehouses, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &warehouses, nil
+}
+
+func (warehouseRepository *warehouseRepository) CreateWarehouse(ctx context.Context, warehouse *model.Warehouse) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	warehouse.CreatedAt = time.Now().UTC()
+
+	result, err := warehouseRepository.collection.InsertOne(ctx, warehouse)
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func (warehouseRepository *warehouseRepository) UpdateWarehouse(ctx context.Context, warehouse *model.Warehouse) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(warehouse.ID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	object := bson.M{
+		"title":     warehouse.Title,
+		"address":   warehouse.Address,
+		"priority":  warehouse.Priority,
+		"is-active": warehouse.IsActive,
+	}
+
+	if warehouse.Location != nil {
+		object["location"] = warehouse.Location
+	}
+
+	result, err := warehouseRepository.collection.UpdateOne(ctx, bson.M{"_id": oid}, setOrUnset(object, "location"))
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (warehouseRepository *warehouseRepository) DeleteWarehouse(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result, err := warehouseRepository.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
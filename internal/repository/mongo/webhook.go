@@ -0,0 +1,151 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type webhookRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookRepository(storage *mongo.Database, collection string) repository.WebhookRepository {
+	return &webhookRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (webhookRepository *webhookRepository) GetWebhook(ctx context.Context, uuid string) (*model.Webhook, error) {
+	var webhook *model.Webhook
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return webhook, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	result := webhookRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return webhook, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = result.Decode(&webhook); err != nil {
+		return webhook, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return webhook, nil
+}
+
+func (webhookRepository *webhookRepository) GetAllWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	return webhookRepository.findWebhooks(ctx, bson.M{})
+}
+
+func (webhookRepository *webhookRepository) GetActiveWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	return webhookRepository.findWebhooks(ctx, bson.M{"is-active": true})
+}
+
+func (webhookRepository *webhookRepository) findWebhooks(ctx context.Context, filter bson.M) (*[]model.Webhook, error) {
+	var webhooks []model.Webhook
+
+	cursor, err := webhookRepository.collection.Find(ctx, filter)
+	if err != nil {
+		return &webhooks, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &webhooks); err != nil {
+		return &webhooks, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &webhooks, nil
+}
+
+func (webhookRepository *webhookRepository) CreateWebhook(ctx context.Context, webhook *model.Webhook) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	webhook.CreatedAt = time.Now().UTC()
+
+	result, err := webhookRepository.collection.InsertOne(ctx, webhook)
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func (webhookRepository *webhookRepository) UpdateWebhook(ctx context.Context, webhook *model.Webhook) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(webhook.ID)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	update := bson.M{
+		"$set": bson.M{
+			"url":         webhook.URL,
+			"secret":      webhook.Secret,
+			"event-types": webhook.EventTypes,
+			"is-active":   webhook.IsActive,
+		},
+	}
+
+	result, err := webhookRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (webhookRepository *webhookRepository) DeleteWebhook(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	result, err := webhookRepository.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
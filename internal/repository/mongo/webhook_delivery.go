@@ -0,0 +1,202 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(storage *mongo.Database, collection string) repository.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		collection: storage.Collection(collection, options.Collection().SetRegistry(documentRegistry())),
+	}
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	documents := make([]interface{}, 0, len(deliveries))
+	for i := range deliveries {
+		documents = append(documents, deliveries[i])
+	}
+
+	_, err := webhookDeliveryRepository.collection.InsertMany(ctx, documents, options.InsertMany().SetOrdered(false))
+	if err == nil {
+		return nil
+	}
+
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	for _, writeErr := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(writeErr.WriteError) {
+			return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+		}
+	}
+
+	return nil
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	var delivery *model.WebhookDelivery
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	filter := bson.M{
+		"status":          model.WebhookDeliveryPending,
+		"next-attempt-at": bson.M{"$lte": now},
+	}
+
+	update := bson.M{
+		"$set": bson.M{"next-attempt-at": now.Add(lease)},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.M{"next-attempt-at": 1}).
+		SetReturnDocument(options.After)
+
+	result := webhookDeliveryRepository.collection.FindOneAndUpdate(ctx, filter, update, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if result.Err() != nil {
+		return delivery, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&delivery); err != nil {
+		return delivery, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return delivery, nil
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) MarkWebhookDelivered(ctx context.Context, uuid string, attempts int) error {
+	deliveredAt := time.Now().UTC()
+
+	return webhookDeliveryRepository.updateDelivery(ctx, uuid, bson.M{
+		"$set": bson.M{
+			"status":       model.WebhookDeliveryDelivered,
+			"attempts":     attempts,
+			"delivered-at": deliveredAt,
+		},
+		"$unset": bson.M{"last-error": utils.EmptyString},
+	})
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) RetryWebhookDeliveryAt(ctx context.Context, uuid string, attempts int, next time.Time, lastError string) error {
+	return webhookDeliveryRepository.updateDelivery(ctx, uuid, bson.M{
+		"$set": bson.M{
+			"attempts":        attempts,
+			"next-attempt-at": next,
+			"last-error":      lastError,
+		},
+	})
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) MarkWebhookDeliveryDead(ctx context.Context, uuid string, attempts int, lastError string) error {
+	return webhookDeliveryRepository.updateDelivery(ctx, uuid, bson.M{
+		"$set": bson.M{
+			"status":     model.WebhookDeliveryDead,
+			"attempts":   attempts,
+			"last-error": lastError,
+		},
+	})
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) GetDeadWebhookDeliveries(ctx context.Context) (*[]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+
+	filter := bson.M{"status": model.WebhookDeliveryDead}
+	opts := options.Find().SetSort(bson.M{"created-at": -1})
+
+	cursor, err := webhookDeliveryRepository.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return &deliveries, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return &deliveries, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &deliveries, nil
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) RequeueWebhookDelivery(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid, "status": model.WebhookDeliveryDead}
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":          model.WebhookDeliveryPending,
+			"attempts":        0,
+			"next-attempt-at": time.Now().UTC(),
+		},
+	}
+
+	result, err := webhookDeliveryRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (webhookDeliveryRepository *webhookDeliveryRepository) updateDelivery(ctx context.Context, uuid string, update bson.M) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter := bson.M{"_id": oid}
+
+	result, err := webhookDeliveryRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
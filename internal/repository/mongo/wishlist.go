@@ -0,0 +1,198 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type wishlistRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWishlistRepository(storage *mongo.Database, collection string) repository.WishlistRepository {
+	return &wishlistRepository{
+		collection: storage.Collection(collection),
+	}
+}
+
+func (wishlistRepository *wishlistRepository) GetWishlist(ctx context.Context, uuid string) (*model.Wishlist, error) {
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return nil, errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	return wishlistRepository.findWishlist(ctx, bson.M{"_id": oid})
+}
+
+func (wishlistRepository *wishlistRepository) GetWishlistByShareToken(ctx context.Context, token string) (*model.Wishlist, error) {
+	return wishlistRepository.findWishlist(ctx, bson.M{"share-token": token})
+}
+
+func (wishlistRepository *wishlistRepository) findWishlist(ctx context.Context, filter bson.M) (*model.Wishlist, error) {
+	var wishlist *model.Wishlist
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	result := wishlistRepository.collection.FindOne(ctx, filter)
+	if result.Err() != nil {
+		return wishlist, errors.Wrap(result.Err(), utils.ErrorExecuteQuery.Error())
+	}
+
+	if err := result.Decode(&wishlist); err != nil {
+		return wishlist, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return wishlist, nil
+}
+
+func (wishlistRepository *wishlistRepository) GetWishlistsByCustomer(ctx context.Context, customerID string) (*[]model.Wishlist, error) {
+	var wishlists []model.Wishlist
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created-at", Value: 1}})
+
+	cursor, err := wishlistRepository.collection.Find(ctx, bson.M{"customer-id": customerID}, opts)
+	if err != nil {
+		return &wishlists, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if err = cursor.All(ctx, &wishlists); err != nil {
+		return &wishlists, errors.Wrap(err, utils.ErrorDecode.Error())
+	}
+
+	return &wishlists, nil
+}
+
+func (wishlistRepository *wishlistRepository) CreateWishlist(ctx context.Context, wishlist *model.Wishlist) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	now := time.Now().UTC()
+	wishlist.CreatedAt, wishlist.UpdatedAt = now, now
+
+	result, err := wishlistRepository.collection.InsertOne(ctx, wishlist)
+	if err != nil {
+		return utils.EmptyString, errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	oid, ok := result.InsertedID.(primitive.ObjectID)
+	if !ok {
+		return utils.EmptyString, errors.Wrap(errors.New("error convert hex to oid"), utils.ErrorConvert.Error())
+	}
+
+	return oid.Hex(), nil
+}
+
+func (wishlistRepository *wishlistRepository) RenameWishlist(ctx context.Context, uuid string, name string) error {
+	update := bson.M{
+		"$set": bson.M{"name": name, "updated-at": time.Now().UTC()},
+	}
+
+	return wishlistRepository.update(ctx, uuid, bson.M{}, update, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error()))
+}
+
+func (wishlistRepository *wishlistRepository) SetWishlistShareToken(ctx context.Context, uuid string, token string) error {
+	object := bson.M{"updated-at": time.Now().UTC()}
+	if token != utils.EmptyString {
+		object["share-token"] = token
+	}
+
+	update := setOrUnset(object, "share-token")
+
+	return wishlistRepository.update(ctx, uuid, bson.M{}, update, errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error()))
+}
+
+func (wishlistRepository *wishlistRepository) AddWishlistItem(ctx context.Context, uuid string, item *model.WishlistItem) error {
+	item.AddedAt = time.Now().UTC()
+
+	filter := bson.M{"items.product-id": bson.M{"$ne": item.ProductID}}
+
+	update := bson.M{
+		"$push": bson.M{"items": item},
+		"$set":  bson.M{"updated-at": item.AddedAt},
+	}
+
+	err := wishlistRepository.update(ctx, uuid, filter, update, utils.ErrorWishlistItemExists)
+	if !errors.Is(err, utils.ErrorWishlistItemExists) {
+		return err
+	}
+
+	if _, err = wishlistRepository.GetWishlist(ctx, uuid); err != nil {
+		return err
+	}
+
+	return utils.ErrorWishlistItemExists
+}
+
+func (wishlistRepository *wishlistRepository) RemoveWishlistItem(ctx context.Context, uuid string, productID string) error {
+	filter := bson.M{"items.product-id": productID}
+
+	update := bson.M{
+		"$pull": bson.M{"items": bson.M{"product-id": productID}},
+		"$set":  bson.M{"updated-at": time.Now().UTC()},
+	}
+
+	return wishlistRepository.update(ctx, uuid, filter, update, utils.ErrorWishlistItemNotFound)
+}
+
+func (wishlistRepository *wishlistRepository) DeleteWishlist(ctx context.Context, uuid string) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	result, err := wishlistRepository.collection.DeleteOne(ctx, bson.M{"_id": oid})
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.DeletedCount == 0 {
+		return errors.Wrap(errors.New("not found"), utils.ErrorExecuteQuery.Error())
+	}
+
+	return nil
+}
+
+func (wishlistRepository *wishlistRepository) update(ctx context.Context, uuid string, filter bson.M, update bson.M, notFound error) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+
+	defer cancel()
+
+	oid, err := primitive.ObjectIDFromHex(uuid)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorConvert.Error())
+	}
+
+	filter["_id"] = oid
+
+	result, err := wishlistRepository.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorExecuteQuery.Error())
+	}
+
+	if result.MatchedCount == 0 {
+		return notFound
+	}
+
+	return nil
+}
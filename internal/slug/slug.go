@@ -0,0 +1,80 @@
+package slug
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const maxLength = 80
+
+var transliteration = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "kh", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "shch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya", 'і': "i", 'ї': "yi", 'є': "ye", 'ґ': "g", 'ў': "u",
+	'à': "a", 'á': "a", 'â': "a", 'ã': "a", 'ä': "a", 'å': "a", 'æ': "ae", 'ç': "c",
+	'è': "e", 'é': "e", 'ê': "e", 'ë': "e", 'ì': "i", 'í': "i", 'î': "i", 'ï': "i",
+	'ñ': "n", 'ò': "o", 'ó': "o", 'ô': "o", 'õ': "o", 'ö': "o", 'ø': "o", 'ù': "u",
+	'ú': "u", 'û': "u", 'ü': "u", 'ý': "y", 'ÿ': "y", 'ß': "ss",
+}
+
+func Make(title string) string {
+	var builder strings.Builder
+
+	separate := false
+
+	for _, r := range strings.ToLower(title) {
+		part, ok := transliteration[r]
+		if !ok {
+			if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+				part = string(r)
+			} else {
+				separate = builder.Len() > 0
+				continue
+			}
+		}
+
+		if part == "" {
+			continue
+		}
+
+		if separate {
+			builder.WriteByte('-')
+			separate = false
+		}
+
+		builder.WriteString(part)
+	}
+
+	result := builder.String()
+	if len(result) > maxLength {
+		result = strings.TrimRight(result[:maxLength], "-")
+	}
+
+	return result
+}
+
+func WithSuffix(base string, n int) string {
+	if n <= 1 {
+		return base
+	}
+
+	return base + "-" + strconv.Itoa(n)
+}
+
+func Derives(slug string, base string) bool {
+	if slug == base {
+		return true
+	}
+
+	suffix, found := strings.CutPrefix(slug, base+"-")
+	if !found || suffix == "" {
+		return false
+	}
+
+	n, err := strconv.Atoi(suffix)
+
+	return err == nil && n > 1 && strconv.Itoa(n) == suffix
+}
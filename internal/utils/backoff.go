@@ -0,0 +1,21 @@
+package utils
+
+import (
+	"math/rand"
+	"time"
+)
+
+func Backoff(base time.Duration, max time.Duration, attempt int) time.Duration {
+	delay := base
+	for i := 1; i < attempt && delay < max; i++ {
+		delay *= 2
+	}
+
+	if delay > max {
+		delay = max
+	}
+
+	jitter := time.Duration(rand.Int63n(int64(delay)/5 + 1))
+
+	return delay - delay/10 + jitter
+}
@@ -1,9 +1,27 @@
 package utils
 
 const (
-	CollNameCategory    = "category"
-	CollNameSubcategory = "subcategory"
-	CollNameDiscount    = "discount"
-	CollNameProduct     = "product"
-	CollNameTag         = "tag"
+	CollNameCategory         = "category"
+	CollNameSubcategory      = "subcategory"
+	CollNameDiscount         = "discount"
+	CollNameProduct          = "product"
+	CollNameTag              = "tag"
+	CollNameCoupon           = "coupon"
+	CollNameCouponRedemption = "coupon_redemption"
+	CollNamePricingRule      = "pricing_rule"
+	CollNameExchangeRate     = "exchange_rate"
+	CollNameStockMovement    = "stock_movement"
+	CollNameStockAlert       = "stock_alert"
+	CollNameWarehouse        = "warehouse"
+	CollNameWarehouseStock   = "warehouse_stock"
+	CollNameReview           = "review"
+	CollNameCart             = "cart"
+	CollNameOrder            = "order"
+	CollNameWishlist         = "wishlist"
+	CollNameCoPurchase       = "co_purchase"
+	CollNameRelatedOverride  = "related_override"
+	CollNameAudit            = "audit"
+	CollNameWebhook          = "webhook"
+	CollNameWebhookDelivery  = "webhook_delivery"
+	CollNameOutbox           = "outbox"
 )
@@ -1,5 +1,7 @@
 package utils
 
 const (
-	EmptyString = ""
+	EmptyString     = ""
+	ContextKeyActor = "actor"
+	DefaultActor    = "anonymous"
 )
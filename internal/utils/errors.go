@@ -3,13 +3,44 @@ package utils
 import "github.com/pkg/errors"
 
 var (
-	ErrorDecode                 = errors.New("failed to decode")
-	ErrorMarshal                = errors.New("failed to marshal")
-	ErrorConvert                = errors.New("failed to convert")
-	ErrorUnmarshal              = errors.New("failed to unmarshal")
-	ErrorExecuteQuery           = errors.New("failed to execute query")
-	ErrorGetUrlParams           = errors.New("failed to get param from query url")
-	ErrorBindAndValidatePayload = errors.New("failed to validate or bind payload value")
-	ErrorDatabaseConnect        = errors.New("failed to connect to database")
-	ErrorDatabasePing           = errors.New("failed to ping to database")
+	ErrorDecode                   = errors.New("failed to decode")
+	ErrorMarshal                  = errors.New("failed to marshal")
+	ErrorConvert                  = errors.New("failed to convert")
+	ErrorUnmarshal                = errors.New("failed to unmarshal")
+	ErrorExecuteQuery             = errors.New("failed to execute query")
+	ErrorGetUrlParams             = errors.New("failed to get param from query url")
+	ErrorBindAndValidatePayload   = errors.New("failed to validate or bind payload value")
+	ErrorDiscountWindow           = errors.New("discount ends-at must be after starts-at")
+	ErrorTitleExists              = errors.New("active entity with this title is exist")
+	ErrorCategoryParentDeleted    = errors.New("category has a parent in trash, restore the parent first")
+	ErrorCategoryCycle            = errors.New("category cannot be moved under itself or its descendant")
+	ErrorSubcategoryMembership    = errors.New("subcategory does not belong to the category")
+	ErrorSKUExists                = errors.New("product or variant with this sku is exist")
+	ErrorSlugExists               = errors.New("entity with this slug is exist")
+	ErrorVariantNotFound          = errors.New("variant not found")
+	ErrorMediaType                = errors.New("unsupported media type")
+	ErrorMediaSize                = errors.New("media file is too large")
+	ErrorImageNotFound            = errors.New("image not found")
+	ErrorCartItemNotFound         = errors.New("sku is not in the cart")
+	ErrorCartChanged              = errors.New("cart changed during checkout")
+	ErrorOrderNotFound            = errors.New("order is not found")
+	ErrorWishlistItemExists       = errors.New("product is already in the wishlist")
+	ErrorWishlistItemNotFound     = errors.New("product is not in the wishlist")
+	ErrorUnsupportedCurrency      = errors.New("currency is not supported")
+	ErrorExchangeRateMissing      = errors.New("exchange rate is not set for currency")
+	ErrorInvalidLocale            = errors.New("invalid locale")
+	ErrorDefaultLocale            = errors.New("default locale content is stored on the document itself")
+	ErrorInsufficientStock        = errors.New("insufficient stock")
+	ErrorAllocationStrategy       = errors.New("unknown allocation strategy")
+	ErrorProductNotFound          = errors.New("product is not found")
+	ErrorProductStatus            = errors.New("product status transition is not allowed")
+	ErrorPublicationSchedule      = errors.New("publish-at is allowed for drafts only and unpublish-at must follow publication")
+	ErrorCouponCustomerLimit      = errors.New("coupon usage limit per customer is reached")
+	ErrorCouponRedemptionNotFound = errors.New("coupon redemption is not found")
+	ErrorProductPrice             = errors.New("product price is not a valid number")
+	ErrorWebhookTarget            = errors.New("webhook target must be a public http or https address")
+	ErrorUnauthorized             = errors.New("invalid credentials")
+	ErrorDatabaseConnect          = errors.New("failed to connect to database")
+	ErrorDatabasePing             = errors.New("failed to ping to database")
+	ErrorTransactionsUnsupported  = errors.New("mongodb must run as a replica set or sharded cluster: the outbox writes events in transactions; start mongod with --replSet and set DB_REPLICA_SET")
 )
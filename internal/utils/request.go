@@ -0,0 +1,18 @@
+package utils
+
+import (
+	"github.com/labstack/echo/v4"
+)
+
+func GetActor(c echo.Context) string {
+	actor, ok := c.Get(ContextKeyActor).(string)
+	if !ok || actor == EmptyString {
+		return DefaultActor
+	}
+
+	return actor
+}
+
+func GetRequestID(c echo.Context) string {
+	return c.Response().Header().Get(echo.HeaderXRequestID)
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 )
@@ -10,7 +13,17 @@ type Validator struct {
 }
 
 func NewValidator() echo.Validator {
-	return &Validator{validator: validator.New()}
+	validate := validator.New()
+
+	_ = validate.RegisterValidation("price", validatePrice)
+
+	return &Validator{validator: validate}
+}
+
+func validatePrice(fl validator.FieldLevel) bool {
+	value, err := strconv.ParseFloat(strings.TrimSpace(strings.ReplaceAll(fl.Field().String(), ",", ".")), 64)
+
+	return err == nil && value >= 0
 }
 
 func (v *Validator) Validate(i interface{}) error {
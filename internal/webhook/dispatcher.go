@@ -0,0 +1,67 @@
+package webhook
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+)
+
+const wildcard = "*"
+
+type Dispatcher struct {
+	webhookRepository         repository.WebhookRepository
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+}
+
+func NewDispatcher(webhookRepository repository.WebhookRepository, webhookDeliveryRepository repository.WebhookDeliveryRepository) *Dispatcher {
+	return &Dispatcher{
+		webhookRepository:         webhookRepository,
+		webhookDeliveryRepository: webhookDeliveryRepository,
+	}
+}
+
+func (dispatcher *Dispatcher) Publish(ctx context.Context, event model.Event) error {
+	webhooks, err := dispatcher.webhookRepository.GetActiveWebhooks(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	deliveries := make([]model.WebhookDelivery, 0, len(*webhooks))
+
+	for _, webhook := range *webhooks {
+		if !Subscribed(webhook.EventTypes, event.Type) {
+			continue
+		}
+
+		deliveries = append(deliveries, model.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			Event:         event,
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	return dispatcher.webhookDeliveryRepository.CreateWebhookDeliveries(ctx, deliveries)
+}
+
+func Subscribed(eventTypes []string, eventType string) bool {
+	if len(eventTypes) == 0 {
+		return true
+	}
+
+	for _, pattern := range eventTypes {
+		switch {
+		case pattern == wildcard, pattern == eventType:
+			return true
+		case strings.HasSuffix(pattern, "."+wildcard) && strings.HasPrefix(eventType, strings.TrimSuffix(pattern, wildcard)):
+			return true
+		}
+	}
+
+	return false
+}
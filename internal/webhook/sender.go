@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+type Sender struct {
+	client       *http.Client
+	allowPrivate bool
+}
+
+func NewSender(timeout time.Duration, allowPrivate bool) *Sender {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+
+	if !allowPrivate {
+		dialer := &net.Dialer{Timeout: timeout, Control: publicOnly}
+		transport.DialContext = dialer.DialContext
+	}
+
+	return &Sender{client: &http.Client{Timeout: timeout, Transport: transport}, allowPrivate: allowPrivate}
+}
+
+func (sender *Sender) Send(ctx context.Context, webhook *model.Webhook, delivery *model.WebhookDelivery) error {
+	if err := ValidateTarget(ctx, webhook.URL, sender.allowPrivate); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorMarshal.Error())
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "create webhook request")
+	}
+
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(HeaderEvent, delivery.Event.Type)
+	request.Header.Set(HeaderDelivery, delivery.ID)
+	request.Header.Set(HeaderTimestamp, timestamp)
+	request.Header.Set(HeaderSignature, Sign(webhook.Secret, timestamp, body))
+
+	response, err := sender.client.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "send webhook request")
+	}
+
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+)
+
+func TestSenderSignsRequest(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription := &model.Webhook{URL: server.URL, Secret: "top-secret", IsActive: true}
+	delivery := &model.WebhookDelivery{
+		ID:    "delivery-1",
+		Event: model.Event{ID: "event-1", Type: "product.created", EntityID: "product-1"},
+	}
+
+	if err := NewSender(time.Second, true).Send(context.Background(), subscription, delivery); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+
+	if got := header.Get(HeaderEvent); got != "product.created" {
+		t.Errorf("event header = %q, want product.created", got)
+	}
+
+	if got := header.Get(HeaderDelivery); got != "delivery-1" {
+		t.Errorf("delivery header = %q, want delivery-1", got)
+	}
+
+	timestamp := header.Get(HeaderTimestamp)
+	signature := header.Get(HeaderSignature)
+
+	if !Verify("top-secret", timestamp, body, signature) {
+		t.Fatalf("signature %q does not verify", signature)
+	}
+
+	if Verify("other-secret", timestamp, body, signature) {
+		t.Error("signature verifies with a wrong secret")
+	}
+
+	if Verify("top-secret", timestamp, append(body, ' '), signature) {
+		t.Error("signature verifies a tampered body")
+	}
+
+	if Verify("top-secret", timestamp+"0", body, signature) {
+		t.Error("signature verifies a tampered timestamp")
+	}
+
+	var event model.Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		t.Fatalf("unmarshal body: %v", err)
+	}
+
+	if event.ID != "event-1" {
+		t.Errorf("event id = %q, want event-1", event.ID)
+	}
+}
+
+func TestSenderRejectsNonSuccessStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	subscription := &model.Webhook{URL: server.URL, Secret: "secret", IsActive: true}
+	delivery := &model.WebhookDelivery{ID: "delivery-1", Event: model.Event{Type: "product.updated"}}
+
+	if err := NewSender(time.Second, true).Send(context.Background(), subscription, delivery); err == nil {
+		t.Fatal("send succeeded on 502")
+	}
+}
+
+func TestSign(t *testing.T) {
+	want := "sha256=a438e398bfafc57e4396bb7fc2304422f0f768e965d073ca313cb52e22e6ad03"
+
+	if got := Sign("key", "1700000000", []byte(`{"a":1}`)); got != want {
+		t.Fatalf("Sign = %q, want %q", got, want)
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	cases := []struct {
+		patterns []string
+		event    string
+		want     bool
+	}{
+		{nil, "product.created", true},
+		{[]string{"*"}, "product.created", true},
+		{[]string{"product.created"}, "product.created", true},
+		{[]string{"product.*"}, "product.deleted", true},
+		{[]string{"product.*"}, "category.deleted", false},
+		{[]string{"category.created"}, "product.created", false},
+	}
+
+	for _, c := range cases {
+		if got := Subscribed(c.patterns, c.event); got != c.want {
+			t.Errorf("Subscribed(%v, %q) = %v, want %v", c.patterns, c.event, got, c.want)
+		}
+	}
+}
+
+func TestValidateTarget(t *testing.T) {
+	cases := []struct {
+		url          string
+		allowPrivate bool
+		valid        bool
+	}{
+		{"http://127.0.0.1:8080/hook", false, false},
+		{"http://[::1]/hook", false, false},
+		{"http://169.254.169.254/latest/meta-data", false, false},
+		{"https://10.0.0.5/hook", false, false},
+		{"https://192.168.1.10/hook", false, false},
+		{"http://0.0.0.0/hook", false, false},
+		{"https://93.184.216.34/hook", false, true},
+		{"http://127.0.0.1:8080/hook", true, true},
+		{"ftp://93.184.216.34/hook", true, false},
+		{"https:///hook", true, false},
+	}
+
+	for _, c := range cases {
+		err := ValidateTarget(context.Background(), c.url, c.allowPrivate)
+		if (err == nil) != c.valid {
+			t.Errorf("ValidateTarget(%q, %v) = %v, want valid %v", c.url, c.allowPrivate, err, c.valid)
+		}
+	}
+}
+
+func TestSenderRejectsPrivateTarget(t *testing.T) {
+	received := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	subscription := &model.Webhook{URL: server.URL, Secret: "secret", IsActive: true}
+	delivery := &model.WebhookDelivery{ID: "delivery-1", Event: model.Event{Type: "product.updated"}}
+
+	err := NewSender(time.Second, false).Send(context.Background(), subscription, delivery)
+	if !errors.Is(err, utils.ErrorWebhookTarget) {
+		t.Fatalf("send to loopback = %v, want ErrorWebhookTarget", err)
+	}
+
+	if received {
+		t.Fatal("loopback receiver was called")
+	}
+}
+
+func TestSenderDialerRejectsPrivateAddress(t *testing.T) {
+	received := false
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+
+	sender := NewSender(time.Second, false)
+	request, _ := http.NewRequest(http.MethodGet, target.URL, nil)
+
+	if _, err := sender.client.Do(request); !errors.Is(err, utils.ErrorWebhookTarget) {
+		t.Fatalf("dial to loopback = %v, want ErrorWebhookTarget", err)
+	}
+
+	if received {
+		t.Fatal("loopback receiver was called")
+	}
+}
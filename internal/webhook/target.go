@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"syscall"
+
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/pkg/errors"
+)
+
+func IsPrivateAddress(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast()
+}
+
+func ValidateTarget(ctx context.Context, rawURL string, allowPrivate bool) error {
+	target, err := url.Parse(rawURL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == utils.EmptyString {
+		return utils.ErrorWebhookTarget
+	}
+
+	if allowPrivate {
+		return nil
+	}
+
+	addresses, err := net.DefaultResolver.LookupIPAddr(ctx, target.Hostname())
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorWebhookTarget.Error())
+	}
+
+	for _, address := range addresses {
+		if IsPrivateAddress(address.IP) {
+			return utils.ErrorWebhookTarget
+		}
+	}
+
+	return nil
+}
+
+func publicOnly(network string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return errors.Wrap(err, utils.ErrorWebhookTarget.Error())
+	}
+
+	if ip := net.ParseIP(host); ip == nil || IsPrivateAddress(ip) {
+		return utils.ErrorWebhookTarget
+	}
+
+	return nil
+}
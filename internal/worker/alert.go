@@ -0,0 +1,61 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type AlertRenotifier interface {
+	Renotify(ctx context.Context) (int, error)
+}
+
+type AlertWorkerDeps struct {
+	Renotifier AlertRenotifier
+	Interval   time.Duration
+}
+
+type AlertWorker struct {
+	renotifier AlertRenotifier
+	interval   time.Duration
+}
+
+func NewAlertWorker(deps *AlertWorkerDeps) *AlertWorker {
+	return &AlertWorker{
+		renotifier: deps.Renotifier,
+		interval:   deps.Interval,
+	}
+}
+
+func (w *AlertWorker) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("interval", w.interval).Msg("start stock alert worker")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		w.renotify(ctx)
+	}
+}
+
+func (w *AlertWorker) renotify(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+
+	notified, err := w.renotifier.Renotify(ctx)
+	if err != nil {
+		logger.Error().Err(err).Msg("renotify stock alerts")
+		return
+	}
+
+	if notified > 0 {
+		logger.Info().Int("notified", notified).Msg("renotify stock alerts")
+	}
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+type DiscountSchedulerDeps struct {
+	DiscountRepository repository.DiscountRepository
+	Interval           time.Duration
+}
+
+type DiscountScheduler struct {
+	discountRepository repository.DiscountRepository
+	interval           time.Duration
+}
+
+func NewDiscountScheduler(deps *DiscountSchedulerDeps) *DiscountScheduler {
+	return &DiscountScheduler{
+		discountRepository: deps.DiscountRepository,
+		interval:           deps.Interval,
+	}
+}
+
+func (s *DiscountScheduler) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("interval", s.interval).Msg("start discount scheduler")
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		timer.Reset(s.schedule(ctx))
+	}
+}
+
+func (s *DiscountScheduler) schedule(ctx context.Context) time.Duration {
+	logger := zerolog.Ctx(ctx)
+	now := time.Now().UTC()
+
+	activated, err := s.discountRepository.ActivateScheduledDiscounts(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("activate scheduled discounts")
+	} else if activated > 0 {
+		logger.Info().Int64("activated", activated).Msg("activate scheduled discounts")
+	}
+
+	expired, err := s.discountRepository.ExpireDiscounts(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("expire discounts")
+	} else if expired > 0 {
+		logger.Info().Int64("expired", expired).Msg("expire discounts")
+	}
+
+	next, err := s.discountRepository.GetNextDiscountTransition(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("get next discount transition")
+		return s.interval
+	}
+
+	if next == nil {
+		return s.interval
+	}
+
+	wait := next.Sub(time.Now())
+	if wait < 0 {
+		return 0
+	}
+
+	if wait > s.interval {
+		return s.interval
+	}
+
+	return wait
+}
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"expvar"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/events"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+
+	"github.com/rs/zerolog"
+)
+
+var outboxMetrics = expvar.NewMap("outbox")
+
+type OutboxRelayDeps struct {
+	OutboxRepository repository.OutboxRepository
+	Publisher        events.Publisher
+	PollInterval     time.Duration
+	Lease            time.Duration
+	BackoffBase      time.Duration
+	BackoffMax       time.Duration
+}
+
+type OutboxRelay struct {
+	outboxRepository repository.OutboxRepository
+	publisher        events.Publisher
+	pollInterval     time.Duration
+	lease            time.Duration
+	backoffBase      time.Duration
+	backoffMax       time.Duration
+}
+
+func NewOutboxRelay(deps *OutboxRelayDeps) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepository: deps.OutboxRepository,
+		publisher:        deps.Publisher,
+		pollInterval:     deps.PollInterval,
+		lease:            deps.Lease,
+		backoffBase:      deps.BackoffBase,
+		backoffMax:       deps.BackoffMax,
+	}
+}
+
+func (w *OutboxRelay) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("poll_interval", w.pollInterval).Msg("start outbox relay")
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.relayDue(ctx)
+		w.collectStats(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *OutboxRelay) relayDue(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+
+	for ctx.Err() == nil {
+		message, err := w.outboxRepository.ClaimOutboxMessage(ctx, time.Now().UTC(), w.lease)
+		if err != nil {
+			logger.Error().Err(err).Msg("claim outbox message")
+			return
+		}
+
+		if message == nil {
+			return
+		}
+
+		w.relay(ctx, message)
+	}
+}
+
+func (w *OutboxRelay) relay(ctx context.Context, message *model.OutboxMessage) {
+	logger := zerolog.Ctx(ctx).With().Str("event_id", message.Event.ID).Str("event", message.Event.Type).Logger()
+	attempts := message.Attempts + 1
+
+	publishErr := w.publisher.Publish(ctx, message.Event)
+	if publishErr == nil {
+		outboxMetrics.Add("published", 1)
+
+		if err := w.outboxRepository.MarkOutboxMessagePublished(ctx, message.ID, attempts); err != nil {
+			logger.Error().Err(err).Msg("mark outbox message published")
+		}
+
+		return
+	}
+
+	outboxMetrics.Add("failed", 1)
+
+	next := time.Now().UTC().Add(utils.Backoff(w.backoffBase, w.backoffMax, attempts))
+
+	logger.Warn().Err(publishErr).Int("attempts", attempts).Time("next_attempt_at", next).Msg("retry outbox message")
+
+	if err := w.outboxRepository.RetryOutboxMessageAt(ctx, message.ID, attempts, next, publishErr.Error()); err != nil {
+		logger.Error().Err(err).Msg("schedule outbox message retry")
+	}
+}
+
+func (w *OutboxRelay) collectStats(ctx context.Context) {
+	stats, err := w.outboxRepository.GetOutboxStats(ctx)
+	if err != nil {
+		zerolog.Ctx(ctx).Error().Err(err).Msg("collect outbox stats")
+		return
+	}
+
+	pending := new(expvar.Int)
+	pending.Set(stats.Pending)
+	outboxMetrics.Set("pending", pending)
+
+	lag := new(expvar.Float)
+	if stats.OldestPending != nil {
+		lag.Set(time.Since(*stats.OldestPending).Seconds())
+	}
+
+	outboxMetrics.Set("lag_seconds", lag)
+}
@@ -0,0 +1,85 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+type PublicationSchedulerDeps struct {
+	ProductRepository repository.ProductRepository
+	Interval          time.Duration
+}
+
+type PublicationScheduler struct {
+	productRepository repository.ProductRepository
+	interval          time.Duration
+}
+
+func NewPublicationScheduler(deps *PublicationSchedulerDeps) *PublicationScheduler {
+	return &PublicationScheduler{
+		productRepository: deps.ProductRepository,
+		interval:          deps.Interval,
+	}
+}
+
+func (s *PublicationScheduler) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("interval", s.interval).Msg("start publication scheduler")
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-timer.C:
+		}
+
+		timer.Reset(s.schedule(ctx))
+	}
+}
+
+func (s *PublicationScheduler) schedule(ctx context.Context) time.Duration {
+	logger := zerolog.Ctx(ctx)
+	now := time.Now().UTC()
+
+	published, err := s.productRepository.PublishScheduledProducts(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("publish scheduled products")
+	} else if published > 0 {
+		logger.Info().Int64("published", published).Msg("publish scheduled products")
+	}
+
+	unpublished, err := s.productRepository.UnpublishScheduledProducts(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("unpublish scheduled products")
+	} else if unpublished > 0 {
+		logger.Info().Int64("unpublished", unpublished).Msg("unpublish scheduled products")
+	}
+
+	next, err := s.productRepository.GetNextPublicationTransition(ctx, now)
+	if err != nil {
+		logger.Error().Err(err).Msg("get next publication transition")
+		return s.interval
+	}
+
+	if next == nil {
+		return s.interval
+	}
+
+	wait := next.Sub(time.Now())
+	if wait < 0 {
+		return 0
+	}
+
+	if wait > s.interval {
+		return s.interval
+	}
+
+	return wait
+}
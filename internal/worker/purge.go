@@ -0,0 +1,87 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/media"
+	"github.com/Meystergod/online-store/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+type PurgeFunc func(ctx context.Context, before time.Time) (int64, error)
+
+type PurgeWorkerDeps struct {
+	Retention time.Duration
+	Interval  time.Duration
+	Targets   map[string]PurgeFunc
+}
+
+type PurgeWorker struct {
+	retention time.Duration
+	interval  time.Duration
+	targets   map[string]PurgeFunc
+}
+
+func NewPurgeWorker(deps *PurgeWorkerDeps) *PurgeWorker {
+	return &PurgeWorker{
+		retention: deps.Retention,
+		interval:  deps.Interval,
+		targets:   deps.Targets,
+	}
+}
+
+func (w *PurgeWorker) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("retention", w.retention).Dur("interval", w.interval).Msg("start purge worker")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *PurgeWorker) purge(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	before := time.Now().UTC().Add(-w.retention)
+
+	for name, purge := range w.targets {
+		purged, err := purge(ctx, before)
+		if err != nil {
+			logger.Error().Err(err).Str("target", name).Msg("purge expired documents")
+			continue
+		}
+
+		if purged > 0 {
+			logger.Info().Str("target", name).Int64("purged", purged).Msg("purge expired documents")
+		}
+	}
+}
+
+func PurgeProducts(productRepository repository.ProductRepository, mediaService *media.Service) PurgeFunc {
+	return func(ctx context.Context, before time.Time) (int64, error) {
+		logger := zerolog.Ctx(ctx)
+
+		products, err := productRepository.GetPurgeableProductImages(ctx, before)
+		if err != nil {
+			return 0, err
+		}
+
+		for _, product := range *products {
+			if err = mediaService.Remove(ctx, product.Images...); err != nil {
+				logger.Error().Err(err).Str("product", product.ID).Msg("remove product image blobs")
+			}
+		}
+
+		return productRepository.PurgeProducts(ctx, before)
+	}
+}
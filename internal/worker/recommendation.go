@@ -0,0 +1,64 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/repository"
+
+	"github.com/rs/zerolog"
+)
+
+type CoPurchaseWorkerDeps struct {
+	RecommendationRepository repository.RecommendationRepository
+	Interval                 time.Duration
+	Window                   time.Duration
+	Depth                    int
+}
+
+type CoPurchaseWorker struct {
+	recommendationRepository repository.RecommendationRepository
+	interval                 time.Duration
+	window                   time.Duration
+	depth                    int
+}
+
+func NewCoPurchaseWorker(deps *CoPurchaseWorkerDeps) *CoPurchaseWorker {
+	return &CoPurchaseWorker{
+		recommendationRepository: deps.RecommendationRepository,
+		interval:                 deps.Interval,
+		window:                   deps.Window,
+		depth:                    deps.Depth,
+	}
+}
+
+func (w *CoPurchaseWorker) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("interval", w.interval).Dur("window", w.window).Msg("start co-purchase worker")
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		w.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *CoPurchaseWorker) refresh(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+	since := time.Now().UTC().Add(-w.window)
+
+	products, err := w.recommendationRepository.RefreshCoPurchases(ctx, since, w.depth)
+	if err != nil {
+		logger.Error().Err(err).Msg("refresh co-purchases")
+		return
+	}
+
+	logger.Info().Int64("products", products).Msg("refresh co-purchases")
+}
@@ -0,0 +1,127 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/repository"
+	"github.com/Meystergod/online-store/internal/utils"
+	"github.com/Meystergod/online-store/internal/webhook"
+
+	"github.com/rs/zerolog"
+)
+
+type WebhookWorkerDeps struct {
+	WebhookRepository         repository.WebhookRepository
+	WebhookDeliveryRepository repository.WebhookDeliveryRepository
+	Sender                    *webhook.Sender
+	PollInterval              time.Duration
+	Lease                     time.Duration
+	MaxAttempts               int
+	BackoffBase               time.Duration
+	BackoffMax                time.Duration
+}
+
+type WebhookWorker struct {
+	webhookRepository         repository.WebhookRepository
+	webhookDeliveryRepository repository.WebhookDeliveryRepository
+	sender                    *webhook.Sender
+	pollInterval              time.Duration
+	lease                     time.Duration
+	maxAttempts               int
+	backoffBase               time.Duration
+	backoffMax                time.Duration
+}
+
+func NewWebhookWorker(deps *WebhookWorkerDeps) *WebhookWorker {
+	return &WebhookWorker{
+		webhookRepository:         deps.WebhookRepository,
+		webhookDeliveryRepository: deps.WebhookDeliveryRepository,
+		sender:                    deps.Sender,
+		pollInterval:              deps.PollInterval,
+		lease:                     deps.Lease,
+		maxAttempts:               deps.MaxAttempts,
+		backoffBase:               deps.BackoffBase,
+		backoffMax:                deps.BackoffMax,
+	}
+}
+
+func (w *WebhookWorker) Run(ctx context.Context) error {
+	logger := zerolog.Ctx(ctx)
+	logger.Info().Dur("poll_interval", w.pollInterval).Int("max_attempts", w.maxAttempts).Msg("start webhook worker")
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.deliverDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *WebhookWorker) deliverDue(ctx context.Context) {
+	logger := zerolog.Ctx(ctx)
+
+	for ctx.Err() == nil {
+		delivery, err := w.webhookDeliveryRepository.ClaimWebhookDelivery(ctx, time.Now().UTC(), w.lease)
+		if err != nil {
+			logger.Error().Err(err).Msg("claim webhook delivery")
+			return
+		}
+
+		if delivery == nil {
+			return
+		}
+
+		w.deliver(ctx, delivery)
+	}
+}
+
+func (w *WebhookWorker) deliver(ctx context.Context, delivery *model.WebhookDelivery) {
+	logger := zerolog.Ctx(ctx).With().Str("delivery_id", delivery.ID).Str("event", delivery.Event.Type).Logger()
+	attempts := delivery.Attempts + 1
+
+	subscription, err := w.webhookRepository.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil || !subscription.IsActive {
+		logger.Warn().Msg("webhook is removed or inactive, move delivery to dead letters")
+
+		if err = w.webhookDeliveryRepository.MarkWebhookDeliveryDead(ctx, delivery.ID, delivery.Attempts, "webhook is removed or inactive"); err != nil {
+			logger.Error().Err(err).Msg("mark webhook delivery dead")
+		}
+
+		return
+	}
+
+	sendErr := w.sender.Send(ctx, subscription, delivery)
+	if sendErr == nil {
+		if err = w.webhookDeliveryRepository.MarkWebhookDelivered(ctx, delivery.ID, attempts); err != nil {
+			logger.Error().Err(err).Msg("mark webhook delivered")
+		}
+
+		return
+	}
+
+	if attempts >= w.maxAttempts {
+		logger.Warn().Err(sendErr).Int("attempts", attempts).Msg("webhook delivery exhausted, move to dead letters")
+
+		if err = w.webhookDeliveryRepository.MarkWebhookDeliveryDead(ctx, delivery.ID, attempts, sendErr.Error()); err != nil {
+			logger.Error().Err(err).Msg("mark webhook delivery dead")
+		}
+
+		return
+	}
+
+	next := time.Now().UTC().Add(utils.Backoff(w.backoffBase, w.backoffMax, attempts))
+
+	logger.Info().Err(sendErr).Int("attempts", attempts).Time("next_attempt_at", next).Msg("retry webhook delivery")
+
+	if err = w.webhookDeliveryRepository.RetryWebhookDeliveryAt(ctx, delivery.ID, attempts, next, sendErr.Error()); err != nil {
+		logger.Error().Err(err).Msg("schedule webhook delivery retry")
+	}
+}
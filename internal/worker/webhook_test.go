@@ -0,0 +1,313 @@
+package worker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Meystergod/online-store/internal/domain/model"
+	"github.com/Meystergod/online-store/internal/webhook"
+
+	"github.com/pkg/errors"
+)
+
+type fakeWebhookRepository struct {
+	webhooks map[string]*model.Webhook
+}
+
+func (repository *fakeWebhookRepository) CreateWebhook(ctx context.Context, subscription *model.Webhook) (string, error) {
+	repository.webhooks[subscription.ID] = subscription
+	return subscription.ID, nil
+}
+
+func (repository *fakeWebhookRepository) GetWebhook(ctx context.Context, uuid string) (*model.Webhook, error) {
+	subscription, ok := repository.webhooks[uuid]
+	if !ok {
+		return nil, errors.New("webhook not found")
+	}
+
+	return subscription, nil
+}
+
+func (repository *fakeWebhookRepository) GetAllWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	webhooks := make([]model.Webhook, 0, len(repository.webhooks))
+	for _, subscription := range repository.webhooks {
+		webhooks = append(webhooks, *subscription)
+	}
+
+	return &webhooks, nil
+}
+
+func (repository *fakeWebhookRepository) GetActiveWebhooks(ctx context.Context) (*[]model.Webhook, error) {
+	return repository.GetAllWebhooks(ctx)
+}
+
+func (repository *fakeWebhookRepository) UpdateWebhook(ctx context.Context, subscription *model.Webhook) error {
+	repository.webhooks[subscription.ID] = subscription
+	return nil
+}
+
+func (repository *fakeWebhookRepository) DeleteWebhook(ctx context.Context, uuid string) error {
+	delete(repository.webhooks, uuid)
+	return nil
+}
+
+type fakeWebhookDeliveryRepository struct {
+	mu         sync.Mutex
+	deliveries map[string]*model.WebhookDelivery
+}
+
+func (repository *fakeWebhookDeliveryRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []model.WebhookDelivery) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	for i := range deliveries {
+		delivery := deliveries[i]
+		repository.deliveries[delivery.ID] = &delivery
+	}
+
+	return nil
+}
+
+func (repository *fakeWebhookDeliveryRepository) ClaimWebhookDelivery(ctx context.Context, now time.Time, lease time.Duration) (*model.WebhookDelivery, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	for _, delivery := range repository.deliveries {
+		if delivery.Status != model.WebhookDeliveryPending || delivery.NextAttemptAt.After(now) {
+			continue
+		}
+
+		delivery.NextAttemptAt = now.Add(lease)
+		claimed := *delivery
+
+		return &claimed, nil
+	}
+
+	return nil, nil
+}
+
+func (repository *fakeWebhookDeliveryRepository) MarkWebhookDelivered(ctx context.Context, uuid string, attempts int) error {
+	return repository.update(uuid, func(delivery *model.WebhookDelivery) {
+		now := time.Now().UTC()
+		delivery.Status = model.WebhookDeliveryDelivered
+		delivery.Attempts = attempts
+		delivery.DeliveredAt = &now
+	})
+}
+
+func (repository *fakeWebhookDeliveryRepository) RetryWebhookDeliveryAt(ctx context.Context, uuid string, attempts int, next time.Time, lastError string) error {
+	return repository.update(uuid, func(delivery *model.WebhookDelivery) {
+		delivery.Attempts = attempts
+		delivery.NextAttemptAt = next
+		delivery.LastError = lastError
+	})
+}
+
+func (repository *fakeWebhookDeliveryRepository) MarkWebhookDeliveryDead(ctx context.Context, uuid string, attempts int, lastError string) error {
+	return repository.update(uuid, func(delivery *model.WebhookDelivery) {
+		delivery.Status = model.WebhookDeliveryDead
+		delivery.Attempts = attempts
+		delivery.LastError = lastError
+	})
+}
+
+func (repository *fakeWebhookDeliveryRepository) GetDeadWebhookDeliveries(ctx context.Context) (*[]model.WebhookDelivery, error) {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	deliveries := make([]model.WebhookDelivery, 0)
+	for _, delivery := range repository.deliveries {
+		if delivery.Status == model.WebhookDeliveryDead {
+			deliveries = append(deliveries, *delivery)
+		}
+	}
+
+	return &deliveries, nil
+}
+
+func (repository *fakeWebhookDeliveryRepository) RequeueWebhookDelivery(ctx context.Context, uuid string) error {
+	return repository.update(uuid, func(delivery *model.WebhookDelivery) {
+		delivery.Status = model.WebhookDeliveryPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = time.Now().UTC()
+	})
+}
+
+func (repository *fakeWebhookDeliveryRepository) update(uuid string, apply func(delivery *model.WebhookDelivery)) error {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	delivery, ok := repository.deliveries[uuid]
+	if !ok {
+		return errors.New("delivery not found")
+	}
+
+	apply(delivery)
+
+	return nil
+}
+
+func (repository *fakeWebhookDeliveryRepository) get(uuid string) model.WebhookDelivery {
+	repository.mu.Lock()
+	defer repository.mu.Unlock()
+
+	return *repository.deliveries[uuid]
+}
+
+func (repository *fakeWebhookDeliveryRepository) makeDue(uuid string) {
+	_ = repository.update(uuid, func(delivery *model.WebhookDelivery) {
+		delivery.NextAttemptAt = time.Now().UTC().Add(-time.Second)
+	})
+}
+
+func newWebhookWorkerFixture(t *testing.T, handler http.HandlerFunc, active bool, maxAttempts int) (*WebhookWorker, *fakeWebhookDeliveryRepository) {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	webhookRepository := &fakeWebhookRepository{webhooks: map[string]*model.Webhook{
+		"webhook-1": {ID: "webhook-1", URL: server.URL, Secret: "secret", IsActive: active},
+	}}
+
+	deliveryRepository := &fakeWebhookDeliveryRepository{deliveries: map[string]*model.WebhookDelivery{
+		"delivery-1": {
+			ID:            "delivery-1",
+			WebhookID:     "webhook-1",
+			Event:         model.Event{ID: "event-1", Type: "product.created"},
+			Status:        model.WebhookDeliveryPending,
+			NextAttemptAt: time.Now().UTC().Add(-time.Second),
+		},
+	}}
+
+	worker := NewWebhookWorker(&WebhookWorkerDeps{
+		WebhookRepository:         webhookRepository,
+		WebhookDeliveryRepository: deliveryRepository,
+		Sender:                    webhook.NewSender(time.Second, true),
+		PollInterval:              time.Second,
+		Lease:                     time.Minute,
+		MaxAttempts:               maxAttempts,
+		BackoffBase:               10 * time.Second,
+		BackoffMax:                time.Hour,
+	})
+
+	return worker, deliveryRepository
+}
+
+func TestWebhookWorkerDeliversSignedEvent(t *testing.T) {
+	verified := make(chan bool, 1)
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		verified <- webhook.Verify("secret", r.Header.Get(webhook.HeaderTimestamp), body, r.Header.Get(webhook.HeaderSignature))
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	worker, deliveries := newWebhookWorkerFixture(t, handler, true, 3)
+
+	worker.deliverDue(context.Background())
+
+	if !<-verified {
+		t.Fatal("receiver could not verify signature")
+	}
+
+	delivery := deliveries.get("delivery-1")
+	if delivery.Status != model.WebhookDeliveryDelivered || delivery.Attempts != 1 {
+		t.Fatalf("delivery = %s after %d attempts, want delivered after 1", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestWebhookWorkerRetriesWithBackoff(t *testing.T) {
+	var calls int32
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	worker, deliveries := newWebhookWorkerFixture(t, handler, true, 5)
+
+	for attempt, base := range []time.Duration{10 * time.Second, 20 * time.Second} {
+		started := time.Now().UTC()
+
+		worker.deliverDue(context.Background())
+
+		delivery := deliveries.get("delivery-1")
+		if delivery.Status != model.WebhookDeliveryPending || delivery.Attempts != attempt+1 {
+			t.Fatalf("attempt %d: delivery = %s after %d attempts", attempt+1, delivery.Status, delivery.Attempts)
+		}
+
+		if delivery.LastError == "" {
+			t.Errorf("attempt %d: last error is empty", attempt+1)
+		}
+
+		delay := delivery.NextAttemptAt.Sub(started)
+		if delay < base*9/10 || delay > base*11/10+time.Second {
+			t.Errorf("attempt %d: next attempt in %s, want about %s", attempt+1, delay, base)
+		}
+
+		worker.deliverDue(context.Background())
+
+		if atomic.LoadInt32(&calls) != int32(attempt+1) {
+			t.Fatalf("attempt %d: delivery retried before its backoff elapsed", attempt+1)
+		}
+
+		deliveries.makeDue("delivery-1")
+	}
+
+	worker.deliverDue(context.Background())
+
+	delivery := deliveries.get("delivery-1")
+	if delivery.Status != model.WebhookDeliveryDelivered || delivery.Attempts != 3 {
+		t.Fatalf("delivery = %s after %d attempts, want delivered after 3", delivery.Status, delivery.Attempts)
+	}
+}
+
+func TestWebhookWorkerMovesExhaustedDeliveryToDeadLetters(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	worker, deliveries := newWebhookWorkerFixture(t, handler, true, 2)
+
+	worker.deliverDue(context.Background())
+	deliveries.makeDue("delivery-1")
+	worker.deliverDue(context.Background())
+
+	dead, _ := deliveries.GetDeadWebhookDeliveries(context.Background())
+	if len(*dead) != 1 {
+		t.Fatalf("dead letters = %d, want 1", len(*dead))
+	}
+
+	delivery := (*dead)[0]
+	if delivery.Attempts != 2 || delivery.LastError == "" {
+		t.Fatalf("dead delivery has %d attempts and error %q", delivery.Attempts, delivery.LastError)
+	}
+}
+
+func TestWebhookWorkerDeadLettersInactiveWebhook(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		t.Error("inactive webhook received a delivery")
+	}
+
+	worker, deliveries := newWebhookWorkerFixture(t, handler, false, 3)
+
+	worker.deliverDue(context.Background())
+
+	delivery := deliveries.get("delivery-1")
+	if delivery.Status != model.WebhookDeliveryDead || delivery.Attempts != 0 {
+		t.Fatalf("delivery = %s after %d attempts, want dead after 0", delivery.Status, delivery.Attempts)
+	}
+}
@@ -19,9 +19,10 @@ type MongoConfig struct {
 	AuthSource   string
 	Username     string
 	Password     string
+	ReplicaSet   string
 }
 
-func NewMongoConfig(authSource, username, password, host, port, db string) *MongoConfig {
+func NewMongoConfig(authSource, username, password, host, port, db, replicaSet string) *MongoConfig {
 	return &MongoConfig{
 		Host:         host,
 		Port:         port,
@@ -29,6 +30,7 @@ func NewMongoConfig(authSource, username, password, host, port, db string) *Mong
 		AuthSource:   authSource,
 		Username:     username,
 		Password:     password,
+		ReplicaSet:   replicaSet,
 	}
 }
 
@@ -48,6 +50,10 @@ func NewMongoClient(ctx context.Context, cfg *MongoConfig) (*mongo.Database, err
 
 	clientOptions := options.Client().ApplyURI(url)
 
+	if cfg.ReplicaSet != "" {
+		clientOptions.SetReplicaSet(cfg.ReplicaSet)
+	}
+
 	if !anonymous {
 		clientOptions.SetAuth(options.Credential{
 			AuthSource:  cfg.AuthSource,
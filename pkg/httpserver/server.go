@@ -25,6 +25,7 @@ type Server struct {
 func NewServer(deps *ServerDeps) *Server {
 	echoServer := echo.New()
 	echoServer.Use(middleware.Recover())
+	echoServer.Use(middleware.RequestID())
 	echoServer.Debug = true
 	echoServer.DisableHTTP2 = true
 	echoServer.HideBanner = true
@@ -47,6 +48,7 @@ func (s *Server) Server() *echo.Echo {
 func (s *Server) Start(ctx context.Context) error {
 	logger := zerolog.Ctx(ctx)
 	logger.Info().Str("bind_addr", s.address).Msg("listen and serve http api")
+	s.echoServer.Use(loggerMiddleware(logger))
 	if err := s.echoServer.Start(s.address); err != nil {
 		return errors.Wrap(err, "start echo server")
 	}
@@ -63,3 +65,14 @@ func (s *Server) Shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+func loggerMiddleware(logger *zerolog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			requestLogger := logger.With().Str("request_id", c.Response().Header().Get(echo.HeaderXRequestID)).Logger()
+			c.SetRequest(c.Request().WithContext(requestLogger.WithContext(c.Request().Context())))
+
+			return next(c)
+		}
+	}
+}